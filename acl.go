@@ -4,38 +4,174 @@
 package pductl
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
+	"path"
 	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-type AccessControlEntry struct {
-	Name       string   `mapstructure:"name"`
+var (
+	ErrUnknownRole  = errors.New("unknown role")
+	ErrRoleLoop     = errors.New("role references itself")
+	ErrInvalidTime  = errors.New("invalid time restriction")
+	ErrInvalidMatch = errors.New("invalid ACL expression")
+)
+
+// Built-in roles which can be overridden in the configuration
+var defaultRoles = map[string][]string{
+	"viewer": {
+		"status",
+		"events",
+		"history",
+		"temperature",
+		"diagnostics",
+		"who-am-i",
+		"status-outlet",
+		"list-schedules",
+		"list-schedule-runs",
+		"list-sequences",
+		"get-sequence",
+		"list-alerts",
+	},
+	"operator": {
+		"viewer",
+		"switch-outlet",
+		"reboot-outlet",
+		"lock-outlet",
+		"clear-maximum-currents",
+		"add-schedule",
+		"delete-schedule",
+		"run-sequence",
+		"audit",
+	},
+	"admin": {
+		"*",
+	},
+}
+
+// Short names of the *-outlet operations in the outlet rules
+var outletOperations = map[string][]string{
+	"status": {"status-outlet"},
+	"switch": {"switch-outlet", "reboot-outlet"},
+	"reboot": {"reboot-outlet"},
+	"lock":   {"lock-outlet"},
+}
+
+// AccessRequest is an operation of a client which is checked against the ACL.
+type AccessRequest struct {
+	Identity  *Identity
+	PDU       string
+	Operation string
+	Time      time.Time
+
+	// Status and aliases of the PDU for scoping outlet rules by groups, breakers and tags
+	Status  *Status
+	Aliases map[string]string
+
+	tags map[string]map[int]bool
+}
+
+// AccessTime restricts an ACL entry to a time of day on some days of the week.
+// The time range wraps around midnight if the end is before the start.
+type AccessTime struct {
+	Days     []string `mapstructure:"days"`
+	From     string   `mapstructure:"from"`
+	To       string   `mapstructure:"to"`
+	TimeZone string   `mapstructure:"timezone"`
+
+	days     map[time.Weekday]bool
+	from, to time.Duration
+	location *time.Location
+}
+
+// OutletAccess permits operations on outlets whose ID or name matches an expression.
+// Outlets can also be selected by their group, breaker or a tag which is the name of an alias.
+type OutletAccess struct {
+	ID         string   `mapstructure:"id"`
+	Group      string   `mapstructure:"group"`
+	Breaker    string   `mapstructure:"breaker"`
+	Tag        string   `mapstructure:"tag"`
+	Roles      []string `mapstructure:"roles"`
 	Operations []string `mapstructure:"operations"`
-	Outlets    []struct {
-		ID         string   `mapstructure:"id"`
-		Operations []string `mapstructure:"operations"`
 
-		regexID *regexp.Regexp
-	} `mapstructure:"outlets"`
+	regexID      *matchExpr
+	regexGroup   *matchExpr
+	regexBreaker *matchExpr
+	operations   []string
+}
+
+// AccessControlEntry permits or denies operations to clients whose identity matches all expressions.
+// Deny entries take precedence over entries which permit an operation.
+type AccessControlEntry struct {
+	Name       string         `mapstructure:"name"`
+	SAN        string         `mapstructure:"san"`
+	OU         string         `mapstructure:"ou"`
+	Group      string         `mapstructure:"group"`
+	Method     string         `mapstructure:"method"`
+	PDU        string         `mapstructure:"pdu"`
+	Deny       bool           `mapstructure:"deny"`
+	Time       *AccessTime    `mapstructure:"time"`
+	Roles      []string       `mapstructure:"roles"`
+	Operations []string       `mapstructure:"operations"`
+	Outlets    []OutletAccess `mapstructure:"outlets"`
 
-	regexName *regexp.Regexp
+	regexName   *matchExpr
+	regexSAN    *matchExpr
+	regexOU     *matchExpr
+	regexGroup  *matchExpr
+	regexMethod *matchExpr
+	regexPDU    *matchExpr
+	operations  []string
 }
 
 type AccessControlList []AccessControlEntry
 
-func (a AccessControlList) Init() (err error) {
+// Init compiles the expressions and expands the roles of all entries.
+// Roles are looked up in the given roles before the built-in ones.
+func (a AccessControlList) Init(roles map[string][]string) (err error) {
 	for i := range a {
 		e := &a[i]
 
-		if e.regexName, err = regexp.Compile(e.Name); err != nil {
-			return fmt.Errorf("invalid ACL name expression: %s: %w", e.Name, err)
+		// Entries without a PDU expression apply to all PDUs
+		if e.PDU == "" {
+			e.PDU = ".*"
 		}
 
-		for j := range e.Outlets {
-			o := &e.Outlets[j]
+		for _, m := range []struct {
+			expr  string
+			regex **matchExpr
+		}{
+			{e.Name, &e.regexName},
+			{e.SAN, &e.regexSAN},
+			{e.OU, &e.regexOU},
+			{e.Group, &e.regexGroup},
+			{e.Method, &e.regexMethod},
+			{e.PDU, &e.regexPDU},
+		} {
+			if *m.regex, err = compileMatch(m.expr); err != nil {
+				return err
+			}
+		}
 
-			if o.regexID, err = regexp.Compile(o.ID); err != nil {
-				return fmt.Errorf("invalid outlet ID expression: %s: %w", o.ID, err)
+		if e.operations, err = expandRoles(e.Roles, e.Operations, roles); err != nil {
+			return err
+		}
+
+		if e.Time != nil {
+			if err := e.Time.init(); err != nil {
+				return err
+			}
+		}
+
+		for j := range e.Outlets {
+			if err := e.Outlets[j].init(roles); err != nil {
+				return err
 			}
 		}
 	}
@@ -43,30 +179,422 @@ func (a AccessControlList) Init() (err error) {
 	return nil
 }
 
-func (a AccessControlList) Check(commonName, operationID, outletID string) bool {
+// Check checks the access to an operation which does not select outlets.
+func (a AccessControlList) Check(req *AccessRequest) error {
+	if !a.permitted(req, nil) {
+		return ErrAccessDenied
+	}
+
+	return nil
+}
+
+// CheckOutlets checks the access to an operation for each of the outlets.
+func (a AccessControlList) CheckOutlets(req *AccessRequest, outlets []OutletStatus) error {
+	for i := range outlets {
+		if !a.permitted(req, &outlets[i]) {
+			return fmt.Errorf("%w: outlet %d", ErrAccessDenied, outlets[i].ID)
+		}
+	}
+
+	return nil
+}
+
+// Scoped returns true if any outlet rule selects outlets by their group, breaker or tag.
+// Only then the status of the PDU is required for checking the access.
+func (a AccessControlList) Scoped() bool {
 	for _, e := range a {
-		if !e.regexName.MatchString(commonName) {
+		if scopedOutletRules(e.Outlets) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// permitted returns true if the operation is permitted by any entry and not denied by another.
+func (a AccessControlList) permitted(req *AccessRequest, o *OutletStatus) bool {
+	permitted := false
+
+	for i := range a {
+		e := &a[i]
+
+		if !e.matches(req) || !e.covers(req, o) {
 			continue
 		}
 
-		for _, op := range e.Operations {
-			if op == operationID {
-				return true
+		if e.Deny {
+			return false
+		}
+
+		permitted = true
+	}
+
+	return permitted
+}
+
+// matches returns true if the entry applies to the client, PDU and time of the request.
+func (e *AccessControlEntry) matches(req *AccessRequest) bool {
+	id := req.Identity
+	if id == nil {
+		id = &Identity{}
+	}
+
+	if (e.regexName != nil && !e.regexName.MatchString(id.Name)) || !e.regexPDU.MatchString(req.PDU) {
+		return false
+	}
+
+	if e.regexMethod != nil && !e.regexMethod.MatchString(id.Method) {
+		return false
+	}
+
+	if !matchAny(e.regexSAN, id.SANs) || !matchAny(e.regexOU, id.OrganizationalUnits) || !matchAny(e.regexGroup, id.Groups) {
+		return false
+	}
+
+	return e.Time == nil || e.Time.contains(req.Time)
+}
+
+// covers returns true if the entry lists the operation for the outlet.
+func (e *AccessControlEntry) covers(req *AccessRequest, o *OutletStatus) bool {
+	if matchOperation(e.operations, req.Operation) {
+		return true
+	}
+
+	if o == nil {
+		return false
+	}
+
+	return permitsOutlet(e.Outlets, req, o)
+}
+
+func (o *OutletAccess) init(roles map[string][]string) (err error) {
+	if o.regexID, err = compileMatch(o.ID); err != nil {
+		return fmt.Errorf("invalid outlet ID expression: %w", err)
+	}
+
+	if o.regexGroup, err = compileMatch(o.Group); err != nil {
+		return err
+	}
+
+	if o.regexBreaker, err = compileMatch(o.Breaker); err != nil {
+		return err
+	}
+
+	ops := []string{}
+	for _, op := range o.Operations {
+		if full, ok := outletOperations[op]; ok {
+			ops = append(ops, full...)
+		} else {
+			ops = append(ops, op)
+		}
+	}
+
+	if o.operations, err = expandRoles(o.Roles, ops, roles); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// selects returns true if the outlet matches the ID, group, breaker and tag of the rule.
+// Rules without an ID expression select all outlets.
+func (o *OutletAccess) selects(req *AccessRequest, os *OutletStatus) bool {
+	if o.regexID != nil && !o.regexID.MatchString(strconv.Itoa(os.ID)) && !o.regexID.MatchString(os.Name) {
+		return false
+	}
+
+	if o.regexGroup != nil && !o.regexGroup.MatchString(strconv.Itoa(os.GroupID)) && !o.regexGroup.MatchString(req.groupName(os.GroupID)) {
+		return false
+	}
+
+	if o.regexBreaker != nil && !o.regexBreaker.MatchString(strconv.Itoa(os.BreakerID)) && !o.regexBreaker.MatchString(req.breakerName(os.BreakerID)) {
+		return false
+	}
+
+	return o.Tag == "" || req.tagged(o.Tag)[os.ID]
+}
+
+// scoped returns true if the rule selects outlets by their group, breaker or tag.
+func (o *OutletAccess) scoped() bool {
+	return o.Group != "" || o.Breaker != "" || o.Tag != ""
+}
+
+// permitsOutlet returns true if any of the rules selects the outlet and lists the operation.
+// This is also used by MQTT and SNMP which only have outlet rules.
+func permitsOutlet(rules []OutletAccess, req *AccessRequest, o *OutletStatus) bool {
+	for i := range rules {
+		if r := &rules[i]; r.selects(req, o) && matchOperation(r.operations, req.Operation) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// scopedOutletRules returns true if any of the rules requires the status of the PDU.
+func scopedOutletRules(rules []OutletAccess) bool {
+	for i := range rules {
+		if rules[i].scoped() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *AccessTime) init() (err error) {
+	t.days = map[time.Weekday]bool{}
+
+	for _, d := range t.Days {
+		wd, ok := parseWeekday(d)
+		if !ok {
+			return fmt.Errorf("%w: unknown day: %s", ErrInvalidTime, d)
+		}
+
+		t.days[wd] = true
+	}
+
+	if t.from, err = parseTimeOfDay(t.From, 0); err != nil {
+		return err
+	}
+
+	if t.to, err = parseTimeOfDay(t.To, 24*time.Hour); err != nil {
+		return err
+	}
+
+	if t.location, err = time.LoadLocation(t.TimeZone); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTime, err)
+	}
+
+	return nil
+}
+
+// contains returns true if the time is within the time range on one of the days.
+// Without days the time range applies to all days.
+func (t *AccessTime) contains(now time.Time) bool {
+	now = now.In(t.location)
+
+	day := now.Weekday()
+	tod := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute + time.Duration(now.Second())*time.Second
+
+	if t.to < t.from && tod < t.to {
+		// The range started on the previous day
+		day = (day + 6) % 7
+	}
+
+	if len(t.days) > 0 && !t.days[day] {
+		return false
+	}
+
+	if t.from <= t.to {
+		return tod >= t.from && tod < t.to
+	}
+
+	return tod >= t.from || tod < t.to
+}
+
+// groupName returns the name of the group or an empty string if it is unknown.
+func (req *AccessRequest) groupName(id int) string {
+	if req.Status != nil {
+		for _, g := range req.Status.Groups {
+			if g.ID == id {
+				return g.Name
 			}
 		}
+	}
 
-		for _, o := range e.Outlets {
-			if !o.regexID.MatchString(outletID) {
-				continue
+	return ""
+}
+
+// breakerName returns the name of the breaker or an empty string if it is unknown.
+func (req *AccessRequest) breakerName(id int) string {
+	if req.Status != nil {
+		for _, b := range req.Status.Breakers {
+			if b.ID == id {
+				return b.Name
 			}
+		}
+	}
+
+	return ""
+}
+
+// tagged returns the IDs of the outlets selected by the alias.
+func (req *AccessRequest) tagged(tag string) map[int]bool {
+	if ids, ok := req.tags[tag]; ok {
+		return ids
+	}
+
+	if req.tags == nil {
+		req.tags = map[string]map[int]bool{}
+	}
+
+	ids := map[int]bool{}
+	req.tags[tag] = ids
+
+	if _, ok := req.Aliases[tag]; !ok || req.Status == nil {
+		return ids
+	}
+
+	expr, err := ExpandAliases(tag, req.Aliases)
+	if err != nil {
+		return ids
+	}
+
+	outlets, err := ResolveOutlets(expr, req.Status.Outlets)
+	if err != nil {
+		return ids
+	}
+
+	for _, o := range outlets {
+		ids[o.ID] = true
+	}
+
+	return ids
+}
+
+// expandRoles returns the operations of the roles followed by the operations.
+// Roles may include other roles by their name.
+func expandRoles(names, operations []string, roles map[string][]string) ([]string, error) {
+	ops := slices.Clone(operations)
+
+	for _, name := range names {
+		expanded, err := expandRole(name, roles, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		ops = append(ops, expanded...)
+	}
 
-			for _, op := range o.Operations {
-				if op == operationID {
-					return true
-				}
+	return ops, nil
+}
+
+func expandRole(name string, roles map[string][]string, visited []string) ([]string, error) {
+	if slices.Contains(visited, name) {
+		return nil, fmt.Errorf("%w: %s", ErrRoleLoop, name)
+	}
+
+	role, ok := roles[name]
+	if !ok {
+		if role, ok = defaultRoles[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownRole, name)
+		}
+	}
+
+	ops := []string{}
+	for _, op := range role {
+		if _, isRole := roles[op]; isRole || defaultRoles[op] != nil {
+			included, err := expandRole(op, roles, append(visited, name))
+			if err != nil {
+				return nil, err
 			}
+
+			ops = append(ops, included...)
+		} else {
+			ops = append(ops, op)
+		}
+	}
+
+	return ops, nil
+}
+
+// matchOperation returns true if the operation ID matches one of the glob patterns.
+func matchOperation(patterns []string, operationID string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, operationID); ok {
+			return true
 		}
 	}
 
 	return false
 }
+
+// matchAny returns true if the expression is not set or matches any of the values.
+func matchAny(re *matchExpr, values []string) bool {
+	if re == nil {
+		return true
+	}
+
+	for _, v := range values {
+		if re.MatchString(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchExpr is a regular expression of the ACL which must match the whole value.
+type matchExpr struct {
+	*regexp.Regexp
+
+	expr   string
+	legacy *regexp.Regexp // Unanchored form which matched parts of the value in previous versions
+}
+
+// legacyMatches holds the expressions and values for which a warning has been logged.
+var legacyMatches sync.Map
+
+// compileMatch compiles an optional regular expression which must match the whole value.
+func compileMatch(expr string) (*matchExpr, error) {
+	if expr == "" {
+		return nil, nil
+	}
+
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidMatch, expr, err)
+	}
+
+	return &matchExpr{
+		Regexp: re,
+		expr:   expr,
+		legacy: regexp.MustCompile(expr),
+	}, nil
+}
+
+// MatchString returns true if the expression matches the whole value.
+// A warning is logged once for values of which only a part is matched,
+// as previous versions accepted them.
+func (m *matchExpr) MatchString(s string) bool {
+	if m.Regexp.MatchString(s) {
+		return true
+	}
+
+	if m.legacy.MatchString(s) {
+		if _, logged := legacyMatches.LoadOrStore(m.expr+"\x00"+s, nil); !logged {
+			slog.Warn("ACL expression only matches a part of the value. Expressions must match the whole value.",
+				slog.String("expression", m.expr), slog.String("value", s))
+		}
+	}
+
+	return false
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	s = strings.ToLower(s)
+
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+
+	return 0, false
+}
+
+// parseTimeOfDay parses a time like 08:30 into the duration since midnight.
+func parseTimeOfDay(s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidTime, s)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
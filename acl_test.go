@@ -0,0 +1,183 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"errors"
+	"testing"
+)
+
+// testStatus returns a status of 20 outlets in the groups "servers" and "storage".
+func testStatus() *Status {
+	sts := &Status{
+		Groups: []GroupStatus{
+			{ID: 1, Name: "servers"},
+			{ID: 2, Name: "storage"},
+		},
+	}
+
+	for id := 1; id <= 20; id++ {
+		sts.Outlets = append(sts.Outlets, OutletStatus{
+			ID:      id,
+			Name:    "outlet" + string(rune('a'+id-1)),
+			GroupID: 1 + (id-1)/10,
+		})
+	}
+
+	return sts
+}
+
+// permittedIDs returns the IDs of the outlets for which the rules permit the operation.
+func permittedIDs(rules []OutletAccess, req *AccessRequest) []int {
+	ids := []int{}
+	for _, o := range req.Status.Outlets {
+		if permitsOutlet(rules, req, &o) {
+			ids = append(ids, o.ID)
+		}
+	}
+
+	return ids
+}
+
+func TestOutletAccessAnchored(t *testing.T) {
+	rules := []OutletAccess{
+		{ID: "1", Operations: []string{"switch"}},
+		{ID: "outleta|2", Operations: []string{"lock"}},
+	}
+
+	for i := range rules {
+		if err := rules[i].init(nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := &AccessRequest{Operation: "switch-outlet", Status: testStatus()}
+	if ids := permittedIDs(rules, req); len(ids) != 1 || ids[0] != 1 {
+		t.Errorf("Expected only outlet 1 to be switchable, got %v", ids)
+	}
+
+	req.Operation = "lock-outlet"
+	if ids := permittedIDs(rules, req); len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("Expected outlets 1 and 2 to be lockable, got %v", ids)
+	}
+}
+
+func TestOutletAccessScoped(t *testing.T) {
+	rules := []OutletAccess{
+		{Group: "storage", Operations: []string{"switch"}},
+		{Tag: "web", Operations: []string{"switch"}},
+	}
+
+	for i := range rules {
+		if err := rules[i].init(nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !scopedOutletRules(rules) {
+		t.Errorf("Rules are not scoped")
+	}
+
+	req := &AccessRequest{
+		Operation: "switch-outlet",
+		Status:    testStatus(),
+		Aliases:   map[string]string{"web": "3-4"},
+	}
+
+	ids := permittedIDs(rules, req)
+	if len(ids) != 12 || ids[0] != 3 || ids[1] != 4 || ids[2] != 11 {
+		t.Errorf("Expected outlets 3, 4 and 11-20, got %v", ids)
+	}
+}
+
+func TestAccessControlListAnchored(t *testing.T) {
+	acl := AccessControlList{
+		{Name: "client1", Operations: []string{"status"}},
+		{Name: "client", PDU: "rack", Outlets: []OutletAccess{{ID: "1", Operations: []string{"switch"}}}},
+	}
+
+	if err := acl.Init(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name, pdu, op string
+		permitted     bool
+	}{
+		{"client1", "rack", "status", true},
+		{"client10", "rack", "status", false},
+		{"xclient1", "rack", "status", false},
+		{"client", "rack", "status", false},
+	} {
+		req := &AccessRequest{Identity: &Identity{Name: tc.name}, PDU: tc.pdu, Operation: tc.op}
+		if err := acl.Check(req); (err == nil) != tc.permitted {
+			t.Errorf("Unexpected result for %s: %v", tc.name, err)
+		}
+	}
+
+	sts := testStatus()
+	req := &AccessRequest{Identity: &Identity{Name: "client"}, PDU: "rack1", Operation: "switch-outlet"}
+	if err := acl.CheckOutlets(req, sts.Outlets[:1]); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Expected PDU rack1 to be denied, got %v", err)
+	}
+
+	req.PDU = "rack"
+	if err := acl.CheckOutlets(req, sts.Outlets[:1]); err != nil {
+		t.Errorf("Expected outlet 1 to be permitted, got %v", err)
+	}
+
+	if err := acl.CheckOutlets(req, sts.Outlets[9:10]); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Expected outlet 10 to be denied, got %v", err)
+	}
+}
+
+// Previous versions matched expressions anywhere in the value.
+func TestAccessControlListUnanchored(t *testing.T) {
+	acl := AccessControlList{
+		{Name: "admin", Operations: []string{"*"}},
+		{Name: ".*admin", PDU: "lab", Operations: []string{"status"}},
+	}
+
+	if err := acl.Init(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &AccessRequest{Identity: &Identity{Name: "sysadmin"}, PDU: "rack", Operation: "switch-outlet"}
+	if err := acl.Check(req); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Expected sysadmin not to match admin, got %v", err)
+	}
+
+	req.PDU, req.Operation = "lab", "status"
+	if err := acl.Check(req); err != nil {
+		t.Errorf("Expected sysadmin to match .*admin, got %v", err)
+	}
+
+	req.PDU = "lab2"
+	if err := acl.Check(req); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("Expected PDU lab2 not to match lab, got %v", err)
+	}
+}
+
+func TestAccessControlListMethod(t *testing.T) {
+	acl := AccessControlList{
+		{Name: "alice", Method: "certificate|jwt", Operations: []string{"switch-outlet"}},
+	}
+
+	if err := acl.Init(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for method, permitted := range map[string]bool{
+		AuthMethodCertificate: true,
+		AuthMethodJWT:         true,
+		AuthMethodBasic:       false,
+		AuthMethodAPIKey:      false,
+		"":                    false,
+	} {
+		req := &AccessRequest{Identity: &Identity{Name: "alice", Method: method}, Operation: "switch-outlet"}
+		if err := acl.Check(req); (err == nil) != permitted {
+			t.Errorf("Unexpected result for method %q: %v", method, err)
+		}
+	}
+}
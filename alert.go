@@ -0,0 +1,568 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/smtp"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stv0g/pductl/internal/api"
+)
+
+const (
+	// Maximum number of alerts waiting for delivery
+	alertQueueLength = 64
+
+	// Timeout for delivering an alert to a single webhook
+	alertWebhookTimeout = 10 * time.Second
+)
+
+var ErrInvalidAlertRule = errors.New("invalid alert rule")
+
+type (
+	Alert         = api.Alert
+	AlertSeverity = api.AlertSeverity
+	AlertState    = api.AlertState
+)
+
+const (
+	AlertInfo     = api.Info
+	AlertWarning  = api.Warning
+	AlertCritical = api.Critical
+
+	AlertFiring   = api.Firing
+	AlertResolved = api.Resolved
+
+	EventAlert = api.EventTypeAlert
+)
+
+// alertRuleType describes which measurement of the status is checked by an alert rule.
+type alertRuleType struct {
+	kind     string // outlet, group, breaker or empty for the PDU itself
+	quantity string // current, peak-current, voltage, temperature or state
+	unit     string
+}
+
+var alertRuleTypes = map[string]alertRuleType{
+	"outlet-current":       {"outlet", "current", "A"},
+	"outlet-peak-current":  {"outlet", "peak-current", "A"},
+	"outlet-voltage":       {"outlet", "voltage", "V"},
+	"outlet-off":           {"outlet", "state", ""},
+	"group-current":        {"group", "current", "A"},
+	"group-peak-current":   {"group", "peak-current", "A"},
+	"group-voltage":        {"group", "voltage", "V"},
+	"breaker-current":      {"breaker", "current", "A"},
+	"breaker-peak-current": {"breaker", "peak-current", "A"},
+	"temperature":          {"", "temperature", "°C"},
+}
+
+// AlertPDU is implemented by PDUs which evaluate alert rules.
+type AlertPDU interface {
+	Alerts() ([]Alert, error)
+	AlertsContext(ctx context.Context) ([]Alert, error)
+}
+
+// validate checks the rule and fills in defaults.
+func (rc *AlertRuleConfig) validate() error {
+	typ, ok := alertRuleTypes[rc.Type]
+	if !ok {
+		return fmt.Errorf("%w: unknown type: %q", ErrInvalidAlertRule, rc.Type)
+	}
+
+	switch AlertSeverity(rc.Severity) {
+	case "":
+		rc.Severity = string(AlertWarning)
+	case AlertInfo, AlertWarning, AlertCritical:
+	default:
+		return fmt.Errorf("%w: unknown severity: %q", ErrInvalidAlertRule, rc.Severity)
+	}
+
+	if _, err := regexp.Compile(rc.ID); err != nil {
+		return fmt.Errorf("%w: invalid ID expression: %s: %w", ErrInvalidAlertRule, rc.ID, err)
+	}
+
+	if rc.AboveRating != 0 && typ.unit != "A" {
+		return fmt.Errorf("%w: above_rating requires a current", ErrInvalidAlertRule)
+	}
+
+	if typ.quantity != "state" && rc.Above == nil && rc.Below == nil && rc.AboveRating == 0 {
+		return fmt.Errorf("%w: missing threshold", ErrInvalidAlertRule)
+	}
+
+	if rc.Hysteresis < 0 {
+		return fmt.Errorf("%w: negative hysteresis", ErrInvalidAlertRule)
+	}
+
+	return nil
+}
+
+type alertRule struct {
+	*AlertRuleConfig
+	alertRuleType
+
+	regexID *regexp.Regexp
+}
+
+// alertSample is a single measurement checked by an alert rule.
+type alertSample struct {
+	id     *int
+	name   string
+	value  float32
+	rating float32
+}
+
+// alertState tracks the condition of a rule for a single outlet, group or breaker.
+type alertState struct {
+	alert Alert
+
+	// Time since which the condition differs from the firing state of the alert
+	changing time.Time
+}
+
+// Alerter evaluates alert rules for each status update of a PDU
+// and delivers fired and resolved alerts to the log, webhooks and via email.
+type Alerter struct {
+	name   string
+	cfg    *AlertsConfig
+	rules  []alertRule
+	events *EventBroker
+	client *http.Client
+	states map[string]*alertState
+	queue  chan Alert
+	done   chan struct{}
+	closed bool
+	mu     sync.Mutex
+}
+
+// NewAlerter creates an alerter for the PDU with the rules from the configuration.
+// Alerts are published to the event broker unless it is nil.
+func NewAlerter(pc *PDUConfig, cfg *AlertsConfig, rcs []AlertRuleConfig, events *EventBroker) (*Alerter, error) {
+	a := &Alerter{
+		name:   pc.Name,
+		cfg:    cfg,
+		events: events,
+		client: &http.Client{
+			Timeout: alertWebhookTimeout,
+		},
+		states: map[string]*alertState{},
+		queue:  make(chan Alert, alertQueueLength),
+		done:   make(chan struct{}),
+	}
+
+	for i := range rcs {
+		rc := &rcs[i]
+
+		re, err := regexp.Compile(rc.ID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid ID expression: %s: %w", ErrInvalidAlertRule, rc.ID, err)
+		}
+
+		a.rules = append(a.rules, alertRule{
+			AlertRuleConfig: rc,
+			alertRuleType:   alertRuleTypes[rc.Type],
+			regexID:         re,
+		})
+	}
+
+	go a.deliver()
+
+	return a, nil
+}
+
+// Close stops the alerter after delivering all queued alerts.
+func (a *Alerter) Close() error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+
+	<-a.done
+
+	return nil
+}
+
+// Alerts returns the currently firing alerts ordered by the time they fired.
+func (a *Alerter) Alerts() ([]Alert, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	alerts := []Alert{}
+	for _, st := range a.states {
+		if st.alert.State == AlertFiring {
+			alerts = append(alerts, st.alert)
+		}
+	}
+
+	slices.SortFunc(alerts, func(a, b Alert) int {
+		if c := a.Since.Compare(b.Since); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Message, b.Message)
+	})
+
+	return alerts, nil
+}
+
+// Update evaluates all rules against a new status.
+func (a *Alerter) Update(sts *Status) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.closed {
+		return
+	}
+
+	for i := range a.rules {
+		r := &a.rules[i]
+
+		for _, smp := range r.samples(sts, a.cfg.BreakerRatings) {
+			a.evaluate(r, smp, sts.Timestamp)
+		}
+	}
+}
+
+// evaluate updates the state of the alert for a single sample.
+// Alerts fire after the condition held for the hold time of the rule
+// and are resolved after the condition cleared for the clear hold time.
+func (a *Alerter) evaluate(r *alertRule, smp alertSample, now time.Time) {
+	key := r.Name + "/" + smp.name
+	if smp.id != nil {
+		key = fmt.Sprintf("%s/%d", r.Name, *smp.id)
+	}
+
+	st, ok := a.states[key]
+	if !ok {
+		st = &alertState{
+			alert: Alert{
+				Rule:     r.Name,
+				PDU:      a.name,
+				Type:     r.Type,
+				Severity: AlertSeverity(r.Severity),
+				State:    AlertResolved,
+				ID:       smp.id,
+			},
+		}
+
+		if smp.name != "" {
+			st.alert.Name = &smp.name
+		}
+	}
+
+	firing := st.alert.State == AlertFiring
+	violated, threshold := r.check(smp, firing)
+
+	st.alert.Value = smp.value
+	st.alert.Threshold = threshold
+
+	if violated == firing {
+		st.changing = time.Time{}
+
+		if firing {
+			st.alert.Message = r.message(smp, threshold, true)
+			a.states[key] = st
+		} else {
+			delete(a.states, key)
+		}
+
+		return
+	}
+
+	if st.changing.IsZero() {
+		st.changing = now
+	}
+
+	hold := r.For
+	if firing {
+		hold = r.ClearFor
+	}
+
+	if now.Sub(st.changing) < hold {
+		a.states[key] = st
+		return
+	}
+
+	st.changing = time.Time{}
+	st.alert.Message = r.message(smp, threshold, violated)
+
+	if violated {
+		st.alert.State = AlertFiring
+		st.alert.Since = now
+		st.alert.Resolved = nil
+
+		a.states[key] = st
+	} else {
+		st.alert.State = AlertResolved
+		st.alert.Resolved = &now
+
+		delete(a.states, key)
+	}
+
+	a.notify(st.alert)
+}
+
+func (a *Alerter) notify(al Alert) {
+	logger := slog.With(slog.String("pdu", al.PDU), slog.String("rule", al.Rule), slog.Float64("value", float64(al.Value)))
+
+	switch {
+	case al.State == AlertResolved:
+		logger.Info("Alert resolved: " + al.Message)
+	case al.Severity == AlertCritical:
+		logger.Error("Alert fired: " + al.Message)
+	case al.Severity == AlertWarning:
+		logger.Warn("Alert fired: " + al.Message)
+	default:
+		logger.Info("Alert fired: " + al.Message)
+	}
+
+	if a.events != nil {
+		a.events.Publish(&Event{
+			Type:      EventAlert,
+			Timestamp: time.Now(),
+			Alert:     &al,
+		})
+	}
+
+	select {
+	case a.queue <- al:
+	default:
+		slog.Warn("Dropped alert notification", slog.String("rule", al.Rule))
+	}
+}
+
+// deliver sends queued alerts to the webhooks and via email.
+func (a *Alerter) deliver() {
+	defer close(a.done)
+
+	for al := range a.queue {
+		for _, wh := range a.cfg.Webhooks {
+			if err := a.sendWebhook(&wh, &al); err != nil {
+				slog.Error("Failed to deliver alert to webhook", slog.String("url", wh.URL), slog.Any("error", err))
+			}
+		}
+
+		if len(a.cfg.Email.To) > 0 {
+			if err := a.sendEmail(&al); err != nil {
+				slog.Error("Failed to deliver alert via email", slog.Any("error", err))
+			}
+		}
+	}
+}
+
+func (a *Alerter) sendWebhook(wh *AlertWebhookConfig, al *Alert) error {
+	body, err := json.Marshal(al)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), alertWebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range wh.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return nil
+}
+
+func (a *Alerter) sendEmail(al *Alert) error {
+	msg := &bytes.Buffer{}
+
+	fmt.Fprintf(msg, "From: %s\r\n", a.cfg.Email.From)
+	fmt.Fprintf(msg, "To: %s\r\n", strings.Join(a.cfg.Email.To, ", "))
+	fmt.Fprintf(msg, "Subject: [%s] %s %s: %s\r\n", strings.ToUpper(string(al.State)), al.Severity, al.PDU, al.Message)
+	fmt.Fprintf(msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(msg, "Content-Type: text/plain; charset=utf-8\r\n")
+	fmt.Fprintf(msg, "\r\n")
+	fmt.Fprintf(msg, "%s\r\n\r\n", al.Message)
+	fmt.Fprintf(msg, "PDU:      %s\r\n", al.PDU)
+	fmt.Fprintf(msg, "Rule:     %s (%s)\r\n", al.Rule, al.Type)
+	fmt.Fprintf(msg, "Severity: %s\r\n", al.Severity)
+	fmt.Fprintf(msg, "Since:    %s\r\n", al.Since.Format(time.RFC3339))
+
+	if al.Resolved != nil {
+		fmt.Fprintf(msg, "Resolved: %s\r\n", al.Resolved.Format(time.RFC3339))
+	}
+
+	return smtp.SendMail(a.cfg.Email.SMTP, nil, a.cfg.Email.From, a.cfg.Email.To, msg.Bytes())
+}
+
+// samples returns the measurements of all outlets, groups or breakers matching the rule.
+func (r *alertRule) samples(sts *Status, ratings map[string]float32) (smps []alertSample) {
+	// Viper lower-cases all map keys
+	rating := func(breakerID int) float32 {
+		for _, b := range sts.Breakers {
+			if b.ID == breakerID {
+				return ratings[strings.ToLower(b.Name)]
+			}
+		}
+
+		return 0
+	}
+
+	match := func(id int, name string) bool {
+		return r.regexID.MatchString(fmt.Sprint(id)) || r.regexID.MatchString(name)
+	}
+
+	switch r.kind {
+	case "outlet":
+		for _, o := range sts.Outlets {
+			if match(o.ID, o.Name) {
+				smps = append(smps, alertSample{
+					id:     &o.ID,
+					name:   o.Name,
+					value:  r.measure(o.TrueRMSCurrent, o.PeakRMSCurrent, o.TrueRMSVoltage, o.State),
+					rating: rating(o.BreakerID),
+				})
+			}
+		}
+
+	case "group":
+		for _, g := range sts.Groups {
+			if match(g.ID, g.Name) {
+				smps = append(smps, alertSample{
+					id:     &g.ID,
+					name:   g.Name,
+					value:  r.measure(g.TrueRMSCurrent, g.PeakRMSCurrent, g.TrueRMSVoltage, true),
+					rating: rating(g.BreakerID),
+				})
+			}
+		}
+
+	case "breaker":
+		for _, b := range sts.Breakers {
+			if match(b.ID, b.Name) {
+				smps = append(smps, alertSample{
+					id:     &b.ID,
+					name:   b.Name,
+					value:  r.measure(b.TrueRMSCurrent, b.PeakRMSCurrent, 0, true),
+					rating: rating(b.ID),
+				})
+			}
+		}
+
+	default:
+		smps = append(smps, alertSample{
+			value: sts.Temperature,
+		})
+	}
+
+	return smps
+}
+
+func (r *alertRule) measure(current, peakCurrent, voltage float32, state bool) float32 {
+	switch r.quantity {
+	case "current":
+		return current
+	case "peak-current":
+		return peakCurrent
+	case "voltage":
+		return voltage
+	case "state":
+		if state {
+			return 1
+		}
+
+		return 0
+	}
+
+	return 0
+}
+
+// check returns true if the sample violates the rule and the crossed threshold.
+// The thresholds of firing alerts are shifted by the hysteresis.
+func (r *alertRule) check(smp alertSample, firing bool) (bool, *float32) {
+	if r.quantity == "state" {
+		return smp.value == 0, nil
+	}
+
+	var hysteresis float32
+	if firing {
+		hysteresis = r.Hysteresis
+	}
+
+	if r.Below != nil {
+		if below := *r.Below + hysteresis; smp.value < below {
+			return true, r.Below
+		}
+	}
+
+	above := r.Above
+	if r.AboveRating != 0 && smp.rating != 0 {
+		rated := r.AboveRating * smp.rating
+		above = &rated
+	}
+
+	if above != nil {
+		if smp.value > *above-hysteresis {
+			return true, above
+		}
+
+		return false, above
+	}
+
+	return false, r.Below
+}
+
+func (r *alertRule) message(smp alertSample, threshold *float32, violated bool) string {
+	subject := smp.name
+	if subject == "" {
+		subject = "PDU"
+	}
+
+	if r.quantity == "state" {
+		if violated {
+			return subject + " is off"
+		}
+
+		return subject + " is on again"
+	}
+
+	quantity := strings.ReplaceAll(r.quantity, "-", " ")
+
+	if !violated {
+		return fmt.Sprintf("%s %s is back to normal at %.2f %s", subject, quantity, smp.value, r.unit)
+	}
+
+	relation := "above"
+	if threshold != nil && smp.value < *threshold {
+		relation = "below"
+	}
+
+	if threshold == nil {
+		return fmt.Sprintf("%s %s of %.2f %s", subject, quantity, smp.value, r.unit)
+	}
+
+	return fmt.Sprintf("%s %s of %.2f %s is %s %.2f %s", subject, quantity, smp.value, r.unit, relation, *threshold, r.unit)
+}
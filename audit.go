@@ -0,0 +1,425 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stv0g/pductl/internal/api"
+)
+
+type AuditEntry = api.AuditEntry
+
+const (
+	AuditSuccess = api.Success
+	AuditPartial = api.Partial
+	AuditFailure = api.Failure
+)
+
+// Sources of audited commands
+const (
+	AuditSourceAPI          = "api"
+	AuditSourceRedfish      = "redfish"
+	AuditSourceMQTT         = "mqtt"
+	AuditSourceSNMP         = "snmp"
+	AuditSourceScheduler    = "scheduler"
+	AuditSourceSequencer    = "sequencer"
+	AuditSourceWatchdog     = "watchdog"
+	AuditSourceLoadShedding = "load-shedding"
+)
+
+var ErrAuditNotPersisted = errors.New("audit log is not persisted to a file")
+
+// AuditPDU is implemented by PDUs which record state-changing operations.
+type AuditPDU interface {
+	Audit(from, to time.Time, id, operation, client string, limit int) ([]AuditEntry, error)
+	AuditContext(ctx context.Context, from, to time.Time, id, operation, client string, limit int) ([]AuditEntry, error)
+}
+
+// auditSyslog is the subset of *syslog.Writer used by the audit log.
+type auditSyslog interface {
+	Info(m string) error
+	Warning(m string) error
+	Close() error
+}
+
+// AuditLog records state-changing API operations as JSON lines to an append-only file
+// and optionally to syslog.
+type AuditLog struct {
+	path   string
+	file   *os.File
+	syslog auditSyslog
+
+	mu sync.Mutex
+}
+
+// NewAuditLog opens the audit log file at path unless it is empty
+// and connects to syslog if enabled by the configuration.
+func NewAuditLog(cfg *AuditConfig, path string) (*AuditLog, error) {
+	l := &AuditLog{
+		path: path,
+	}
+
+	if path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create audit log directory: %w", err)
+		}
+
+		var err error
+		if l.file, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o640); err != nil {
+			return nil, fmt.Errorf("failed to open audit log: %w", err)
+		}
+	}
+
+	if cfg.Syslog {
+		var err error
+		if l.syslog, err = dialSyslog(cfg.SyslogAddress); err != nil {
+			if l.file != nil {
+				l.file.Close()
+			}
+
+			return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+	}
+
+	return l, nil
+}
+
+// Record appends an entry to the audit log.
+// Entries are discarded if the audit log is nil.
+func (l *AuditLog) Record(e *AuditEntry) {
+	if l == nil {
+		return
+	}
+
+	buf, err := json.Marshal(e)
+	if err != nil {
+		slog.Error("Failed to encode audit log entry", slog.Any("error", err))
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file != nil {
+		if _, err := l.file.Write(append(buf, '\n')); err != nil {
+			slog.Error("Failed to write audit log", slog.String("path", l.path), slog.Any("error", err))
+		}
+	}
+
+	if l.syslog != nil {
+		if e.Result == AuditSuccess {
+			err = l.syslog.Info(string(buf))
+		} else {
+			err = l.syslog.Warning(string(buf))
+		}
+
+		if err != nil {
+			slog.Error("Failed to write audit log to syslog", slog.Any("error", err))
+		}
+	}
+}
+
+// Query returns the entries of the PDU between from and to, oldest first.
+// If ids is not nil, only operations on at least one of the outlets are included.
+// Empty operation and client strings match all entries.
+// If limit is positive, only the most recent entries are returned.
+func (l *AuditLog) Query(pdu string, from, to time.Time, ids map[int]bool, operation, client string, limit int) ([]AuditEntry, error) {
+	if to.Before(from) {
+		return nil, ErrInvalidTimeRange
+	}
+
+	if l.path == "" {
+		return nil, ErrAuditNotPersisted
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []AuditEntry{}, nil
+		}
+
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+
+	defer f.Close()
+
+	entries, err := readAuditLog(f, func(e *AuditEntry) bool {
+		if e.PDU != pdu || e.Timestamp.Before(from) || e.Timestamp.After(to) {
+			return false
+		}
+
+		if operation != "" && e.Operation != operation {
+			return false
+		}
+
+		if client != "" && (e.Client == nil || *e.Client != client) {
+			return false
+		}
+
+		if ids != nil {
+			for _, o := range e.Before {
+				if ids[o.ID] {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries are appended once the operation completed
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.Before(entries[j].Timestamp)
+	})
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+
+	return entries, nil
+}
+
+// Close closes the audit log file and the connection to syslog.
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var errs []error
+
+	if l.file != nil {
+		errs = append(errs, l.file.Close())
+	}
+
+	if l.syslog != nil {
+		errs = append(errs, l.syslog.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+func readAuditLog(rd io.Reader, filter func(e *AuditEntry) bool) ([]AuditEntry, error) {
+	entries := []AuditEntry{}
+
+	scanner := bufio.NewScanner(rd)
+	scanner.Buffer(nil, 1<<20)
+
+	for scanner.Scan() {
+		e := AuditEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			slog.Warn("Skipping invalid audit log entry", slog.Any("error", err))
+			continue
+		}
+
+		if filter(&e) {
+			entries = append(entries, e)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+
+	return entries, nil
+}
+
+// AuditedPDU records the switch, lock, reboot and clear commands issued through it in the audit log
+// together with the source and the client which issued them.
+// All other commands are passed to the PDU unchanged.
+type AuditedPDU struct {
+	ContextPDU
+
+	log    *AuditLog
+	name   string
+	source string
+
+	// Client which issued the commands if any
+	identity      *Identity
+	remoteAddress string
+}
+
+// NewAuditedPDU returns a PDU which records the commands of the source in the audit log.
+// The commands are not recorded if the audit log is nil.
+func NewAuditedPDU(l *AuditLog, name string, p PDU, source string) *AuditedPDU {
+	return &AuditedPDU{
+		ContextPDU: AsContextPDU(p),
+		log:        l,
+		name:       name,
+		source:     source,
+	}
+}
+
+// WithClient returns a copy of the PDU which records the commands as issued by the client.
+func (a *AuditedPDU) WithClient(identity *Identity, remoteAddress string) *AuditedPDU {
+	c := *a
+	c.identity = identity
+	c.remoteAddress = remoteAddress
+
+	return &c
+}
+
+// Denied records an operation which has been rejected before reaching the PDU.
+func (a *AuditedPDU) Denied(ctx context.Context, operation, id string, state *bool, err error) {
+	e := a.entry(ctx, operation, id, state)
+	e.Result = AuditFailure
+	e.Error = errorString(err)
+
+	a.log.Record(e)
+}
+
+func (a *AuditedPDU) SwitchOutlet(id string, state bool) ([]OutletResult, error) {
+	return a.SwitchOutletContext(context.Background(), id, state)
+}
+
+func (a *AuditedPDU) SwitchOutletContext(ctx context.Context, id string, state bool) ([]OutletResult, error) {
+	return a.control(ctx, "switch-outlet", id, &state, func(o *OutletStatus) { o.State = state }, func() ([]OutletResult, error) {
+		return a.ContextPDU.SwitchOutletContext(ctx, id, state)
+	})
+}
+
+func (a *AuditedPDU) LockOutlet(id string, state bool) ([]OutletResult, error) {
+	return a.LockOutletContext(context.Background(), id, state)
+}
+
+func (a *AuditedPDU) LockOutletContext(ctx context.Context, id string, state bool) ([]OutletResult, error) {
+	return a.control(ctx, "lock-outlet", id, &state, func(o *OutletStatus) { o.Locked = state }, func() ([]OutletResult, error) {
+		return a.ContextPDU.LockOutletContext(ctx, id, state)
+	})
+}
+
+func (a *AuditedPDU) RebootOutlet(id string) ([]OutletResult, error) {
+	return a.RebootOutletContext(context.Background(), id)
+}
+
+// RebootOutletContext records the outlets as switched on after a successful reboot.
+func (a *AuditedPDU) RebootOutletContext(ctx context.Context, id string) ([]OutletResult, error) {
+	return a.control(ctx, "reboot-outlet", id, nil, func(o *OutletStatus) { o.State = true }, func() ([]OutletResult, error) {
+		return a.ContextPDU.RebootOutletContext(ctx, id)
+	})
+}
+
+func (a *AuditedPDU) ClearMaximumCurrents() error {
+	return a.ClearMaximumCurrentsContext(context.Background())
+}
+
+func (a *AuditedPDU) ClearMaximumCurrentsContext(ctx context.Context) error {
+	e := a.entry(ctx, "clear-maximum-currents", "", nil)
+
+	err := a.ContextPDU.ClearMaximumCurrentsContext(ctx)
+
+	e.Latency = float32(time.Since(e.Timestamp).Seconds())
+	e.Result = AuditSuccess
+
+	if err != nil {
+		e.Result = AuditFailure
+		e.Error = errorString(err)
+	}
+
+	a.log.Record(e)
+
+	return err
+}
+
+// control runs an outlet command and records it once it completed.
+// The status of the outlets after the command is derived from the status before
+// by applying the change to all outlets for which the command succeeded.
+func (a *AuditedPDU) control(ctx context.Context, operation, id string, state *bool, apply func(o *OutletStatus), cmd func() ([]OutletResult, error)) ([]OutletResult, error) {
+	e := a.entry(ctx, operation, id, state)
+
+	results, err := cmd()
+
+	e.Latency = float32(time.Since(e.Timestamp).Seconds())
+	e.Error = errorString(err)
+
+	succeeded := map[int]bool{}
+	for _, res := range results {
+		if res.Error == nil {
+			succeeded[res.ID] = true
+		} else if e.Error == nil {
+			e.Error = res.Error
+		}
+	}
+
+	switch {
+	case e.Error == nil:
+		e.Result = AuditSuccess
+	case len(succeeded) == 0:
+		e.Result = AuditFailure
+	default:
+		e.Result = AuditPartial
+	}
+
+	e.After = []OutletStatus{}
+	for _, o := range e.Before {
+		if succeeded[o.ID] {
+			apply(&o)
+		}
+
+		e.After = append(e.After, o)
+	}
+
+	a.log.Record(e)
+
+	return results, err
+}
+
+// entry returns a new entry with the status of the selected outlets before the operation.
+func (a *AuditedPDU) entry(ctx context.Context, operation, id string, state *bool) *AuditEntry {
+	e := &AuditEntry{
+		Timestamp:     time.Now(),
+		PDU:           a.name,
+		Source:        a.source,
+		Operation:     operation,
+		RemoteAddress: a.remoteAddress,
+		State:         state,
+	}
+
+	if a.identity != nil {
+		e.Client = &a.identity.Name
+
+		if a.identity.Method != "" {
+			e.Method = &a.identity.Method
+		}
+	}
+
+	if id != "" {
+		e.Outlet = &id
+
+		if outlets, err := a.StatusOutletsContext(ctx, id); err == nil {
+			e.Before = outlets
+		}
+	}
+
+	return e
+}
+
+func errorString(err error) *string {
+	if err == nil {
+		return nil
+	}
+
+	msg := err.Error()
+
+	return &msg
+}
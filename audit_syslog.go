@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows && !plan9
+
+package pductl
+
+import (
+	"fmt"
+	"log/syslog"
+	"net/url"
+)
+
+// dialSyslog connects to the local syslog daemon if the address is empty
+// or to a remote one given as URL like udp://host:514 or unix:///dev/log.
+func dialSyslog(address string) (auditSyslog, error) {
+	if address == "" {
+		return syslog.New(syslog.LOG_DAEMON|syslog.LOG_INFO, "pdud")
+	}
+
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog address: %w", err)
+	}
+
+	addr := u.Host
+	if u.Scheme == "unix" || u.Scheme == "unixgram" {
+		addr = u.Path
+	}
+
+	return syslog.Dial(u.Scheme, addr, syslog.LOG_DAEMON|syslog.LOG_INFO, "pdud")
+}
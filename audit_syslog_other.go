@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows || plan9
+
+package pductl
+
+import "errors"
+
+func dialSyslog(_ string) (auditSyslog, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var errTestSwitch = errors.New("outlet is locked")
+
+// auditTestPDU fails to switch outlet 2 and serves the status of outlets 1 and 2 which are off.
+// All other commands are not implemented.
+type auditTestPDU struct {
+	PDU
+}
+
+func (p auditTestPDU) StatusOutlets(id string) ([]OutletStatus, error) {
+	return ResolveOutlets(id, []OutletStatus{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}})
+}
+
+func (p auditTestPDU) SwitchOutlet(id string, _ bool) ([]OutletResult, error) {
+	outlets, err := p.StatusOutlets(id)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []OutletResult{}
+	for _, o := range outlets {
+		if o.ID == 2 {
+			results = append(results, NewOutletResult(o, errTestSwitch))
+		} else {
+			results = append(results, NewOutletResult(o, nil))
+		}
+	}
+
+	return results, OutletResultsError(results)
+}
+
+func TestAuditedPDU(t *testing.T) {
+	l, err := NewAuditLog(&AuditConfig{}, filepath.Join(t.TempDir(), "audit.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer l.Close()
+
+	p := NewAuditedPDU(l, "test", auditTestPDU{}, AuditSourceScheduler)
+
+	start := time.Now()
+
+	if _, err := p.SwitchOutlet("1", true); err != nil {
+		t.Fatalf("Failed to switch outlet: %v", err)
+	}
+
+	if _, err := p.WithClient(&Identity{Name: "alice"}, "192.0.2.1:1234").SwitchOutlet("1-2", true); err == nil {
+		t.Fatal("Expected switching outlet 2 to fail")
+	}
+
+	// Entries are recorded as soon as the commands returned
+	entries, err := l.Query("test", start, time.Now(), nil, "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+
+	if e := entries[0]; e.Result != AuditSuccess || e.Source != AuditSourceScheduler || e.Client != nil || len(e.After) != 1 || !e.After[0].State {
+		t.Errorf("Unexpected entry of the scheduler: %+v", e)
+	}
+
+	e := entries[1]
+	if e.Result != AuditPartial || e.Client == nil || *e.Client != "alice" || e.RemoteAddress != "192.0.2.1:1234" {
+		t.Errorf("Unexpected entry of the client: %+v", e)
+	}
+
+	if len(e.Before) != 2 || e.Before[0].State || e.Before[1].State {
+		t.Errorf("Unexpected state before the operation: %+v", e.Before)
+	}
+
+	if len(e.After) != 2 || !e.After[0].State || e.After[1].State {
+		t.Errorf("Unexpected state after the operation: %+v", e.After)
+	}
+}
@@ -0,0 +1,222 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const apiKeyHeader = "X-API-KEY"
+
+// Authentication methods of an identity
+const (
+	AuthMethodCertificate = "certificate"
+	AuthMethodAPIKey      = "api-key"
+	AuthMethodBasic       = "basic"
+	AuthMethodJWT         = "jwt"
+)
+
+var (
+	ErrMissingCredentials = errors.New("missing credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// APIKeyConfig describes a static API key which is passed in the X-API-KEY header.
+// Only the hex-encoded SHA-256 hash of the key is stored.
+type APIKeyConfig struct {
+	Name   string   `mapstructure:"name"`
+	Hash   string   `mapstructure:"hash"`
+	Groups []string `mapstructure:"groups"`
+
+	hash []byte
+}
+
+// JWTConfig configures the validation of bearer tokens issued by an OpenID Connect provider.
+// Tokens are verified against the public keys of a local JSON Web Key Set file.
+type JWTConfig struct {
+	JWKS        string        `mapstructure:"jwks"`
+	Issuer      string        `mapstructure:"issuer"`
+	Audience    string        `mapstructure:"audience"`
+	Claim       string        `mapstructure:"claim"`
+	GroupsClaim string        `mapstructure:"groups_claim"`
+	Leeway      time.Duration `mapstructure:"leeway"`
+
+	keys jwks
+}
+
+// AuthConfig configures the authentication of API clients in addition to client certificates.
+// Each method yields an identity which is matched by the ACL entries.
+type AuthConfig struct {
+	APIKeys  []APIKeyConfig `mapstructure:"api_keys"`
+	Htpasswd string         `mapstructure:"htpasswd"`
+	Htgroup  string         `mapstructure:"htgroup"`
+	JWT      JWTConfig      `mapstructure:"jwt"`
+
+	users  htpasswd
+	groups htgroup
+}
+
+// Identity is an authenticated client.
+type Identity struct {
+	// Common name of the client certificate, name of the API key, user name or claim of the token
+	Name string
+
+	// Method by which the client has been authenticated
+	Method string
+
+	OrganizationalUnits []string
+	SANs                []string
+	Groups              []string
+}
+
+// Init decodes the hashes of the API keys and loads the htpasswd and JWKS files.
+func (a *AuthConfig) Init() (err error) {
+	for i := range a.APIKeys {
+		k := &a.APIKeys[i]
+
+		if k.Name == "" {
+			return errors.New("missing name of API key")
+		}
+
+		if k.hash, err = hex.DecodeString(strings.TrimPrefix(k.Hash, "sha256:")); err != nil || len(k.hash) != sha256.Size {
+			return fmt.Errorf("invalid hash of API key %s: must be a hex-encoded SHA-256 hash", k.Name)
+		}
+	}
+
+	if a.Htpasswd != "" {
+		if a.users, err = loadHtpasswd(a.Htpasswd); err != nil {
+			return err
+		}
+	}
+
+	if a.Htgroup != "" {
+		if a.groups, err = loadHtgroup(a.Htgroup); err != nil {
+			return err
+		}
+	}
+
+	if a.JWT.JWKS != "" {
+		if a.JWT.Claim == "" {
+			return errors.New("missing claim of JWT identity")
+		}
+
+		if a.JWT.keys, err = loadJWKS(a.JWT.JWKS); err != nil {
+			return err
+		}
+	} else if a.JWT.Issuer != "" || a.JWT.Audience != "" {
+		return errors.New("missing JWKS file for validating JWTs")
+	}
+
+	return nil
+}
+
+// Enabled returns true if any authentication method besides client certificates is configured.
+func (a *AuthConfig) Enabled() bool {
+	return len(a.APIKeys) > 0 || a.Htpasswd != "" || a.JWT.JWKS != ""
+}
+
+// Authenticate returns the identity of the client.
+// Clients are identified by a verified client certificate, an API key,
+// a user name and password (Basic auth) or a JWT bearer token in this order.
+func (a *AuthConfig) Authenticate(r *http.Request) (*Identity, error) {
+	if identity, err := clientCertIdentity(r); err == nil {
+		return identity, nil
+	}
+
+	if key := r.Header.Get(apiKeyHeader); key != "" && len(a.APIKeys) > 0 {
+		return a.authenticateAPIKey(key)
+	}
+
+	auth := r.Header.Get("Authorization")
+	scheme, credentials, _ := strings.Cut(auth, " ")
+
+	switch {
+	case strings.EqualFold(scheme, "Basic") && a.users != nil:
+		username, password, ok := r.BasicAuth()
+		if !ok {
+			return nil, ErrInvalidCredentials
+		}
+
+		if err := a.users.verify(username, password); err != nil {
+			return nil, err
+		}
+
+		return &Identity{
+			Name:   username,
+			Method: AuthMethodBasic,
+			Groups: a.groups.of(username),
+		}, nil
+
+	case strings.EqualFold(scheme, "Bearer") && a.JWT.keys != nil:
+		return a.JWT.verify(strings.TrimSpace(credentials), time.Now())
+	}
+
+	if !a.Enabled() {
+		return nil, ErrMissingClientCert
+	}
+
+	return nil, ErrMissingCredentials
+}
+
+// Challenges returns the values of the WWW-Authenticate header for unauthenticated requests.
+func (a *AuthConfig) Challenges() (challenges []string) {
+	if a.Htpasswd != "" {
+		challenges = append(challenges, `Basic realm="pdud", charset="UTF-8"`)
+	}
+
+	if a.JWT.JWKS != "" {
+		challenges = append(challenges, `Bearer realm="pdud"`)
+	}
+
+	return challenges
+}
+
+func (a *AuthConfig) authenticateAPIKey(key string) (*Identity, error) {
+	hash := sha256.Sum256([]byte(key))
+
+	for _, k := range a.APIKeys {
+		if subtle.ConstantTimeCompare(hash[:], k.hash) == 1 {
+			return &Identity{
+				Name:   k.Name,
+				Method: AuthMethodAPIKey,
+				Groups: k.Groups,
+			}, nil
+		}
+	}
+
+	return nil, ErrInvalidCredentials
+}
+
+// clientCertIdentity returns the identity of the verified client certificate.
+func clientCertIdentity(r *http.Request) (*Identity, error) {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+		return nil, ErrMissingClientCert
+	}
+
+	cert := r.TLS.VerifiedChains[0][0]
+
+	sans := slices.Concat(cert.DNSNames, cert.EmailAddresses)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	return &Identity{
+		Name:                cert.Subject.CommonName,
+		Method:              AuthMethodCertificate,
+		OrganizationalUnits: cert.Subject.OrganizationalUnit,
+		SANs:                sans,
+	}, nil
+}
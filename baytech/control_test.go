@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package baytech
+
+import (
+	"errors"
+	"testing"
+
+	pdu "github.com/stv0g/pductl"
+)
+
+func TestControlError(t *testing.T) {
+	for _, tc := range []struct {
+		out string
+		id  int
+		err error
+	}{
+		{"", 1, nil},
+		{"Outlet 5 is locked", 5, pdu.ErrOutletLocked},
+		{"Outlet 5 is locked", 15, nil},
+		{"Outlet 3 is locked\r\nOutlet 4 is not assigned to user", 4, pdu.ErrAccessDenied},
+		{"Input error", 1, pdu.ErrRejected},
+		{"Invalid outlet number", 1, pdu.ErrRejected},
+		{"Access denied!", 1, pdu.ErrAccessDenied},
+	} {
+		if err := controlError(tc.out, tc.id); !errors.Is(err, tc.err) || (err == nil) != (tc.err == nil) {
+			t.Errorf("Unexpected error for outlet %d in reply %q: %v", tc.id, tc.out, err)
+		}
+	}
+}
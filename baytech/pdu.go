@@ -4,17 +4,21 @@
 package baytech
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.bug.st/serial"
@@ -35,13 +39,27 @@ const (
 	promptPassword        = "Enter Password: "
 	promptUsername        = "Enter user name: "
 	promptInvalidPassword = "Invalid user/password!"
+
+	// User names consist of up to 16 letters, digits, underscores and hyphens
+	usernamePattern = `[A-Za-z0-9_-]{1,16}`
+
+	minBackoff = 1 * time.Second
+	maxBackoff = 1 * time.Minute
+
+	// Commands are aborted if they do not complete within this duration unless changed by SetCommandTimeout
+	defaultCommandTimeout = 30 * time.Second
 )
 
 var (
-	ErrDecode = errors.New("failed to decode")
+	ErrDecode       = errors.New("failed to decode")
+	ErrDisconnected = errors.New("disconnected from PDU")
+	ErrTimeout      = errors.New("timed out waiting for PDU")
+	ErrClosed       = errors.New("connection to PDU has been closed")
 
+	reControlOutlet = regexp.MustCompile(`(?m)^Outlet (\d+) (is locked|is not assigned to user)\s*$`)
+	reControlError  = regexp.MustCompile(`(?mi)^\s*(Input error|Invalid.*?|Access denied.*?)\s*$`)
 	reTemperature   = regexp.MustCompile(`(?m)^Int\. Temp:\s*([0-9\.]+)\s*F`)
-	reWhoami        = regexp.MustCompile(`(?m)^Current User:\s*([A-Za-z0-9-]+)\s*$`)
+	reWhoami        = regexp.MustCompile(`(?m)^Current User:\s*(` + usernamePattern + `)\s*$`)
 	reStatusKWh     = regexp.MustCompile(`(?m)^Total kW-h: (\d+)`)
 	reStatusSwitch  = regexp.MustCompile(`(?m)^Switch 1: (Open|Closed) 2: (Open|Closed)`)
 	reStatusBreaker = regexp.MustCompile(`(?m)^\|\s*(CKT[1-2]|Input [A-Z]|Circuit M[1-4])\s*\|\s*([0-9\.]+)\s+Amps\s*\|\s*([0-9\.]+)\s+Amps\s*\|\s*$`)
@@ -49,33 +67,99 @@ var (
 	reOstatusOutlet = regexp.MustCompile(`(?m)^\|\s*([A-Za-z0-9- /]+?)\s*\|\s*([0-9\.]+)\s+A\s*\|\s*([0-9\.]+)\s+A\s*\|\s*([0-9\.]+)\s+V\s*\|\s*([0-9\.]+)\s+W\s*\|\s*([0-9\.]+)\s+VA\s*\|\s*(On|Off)\s*?(Locked|)\s*\|`)
 )
 
+func init() {
+	pdu.RegisterError(ErrDecode, pdu.ErrorCodeDecodeFailed, http.StatusBadGateway, true)
+	pdu.RegisterError(ErrTimeout, pdu.ErrorCodeTimeout, http.StatusGatewayTimeout, true)
+	pdu.RegisterError(ErrDisconnected, pdu.ErrorCodeDisconnected, http.StatusServiceUnavailable, true)
+	pdu.RegisterError(ErrClosed, pdu.ErrorCodeConnectionClosed, http.StatusServiceUnavailable, false)
+}
+
 type OutletID string
 
 type PDU struct {
-	conn    io.ReadWriteCloser
-	timeout time.Duration
-	mu      sync.Mutex
-	muLogin sync.Mutex
+	uri            *url.URL
+	conn           io.ReadWriteCloser
+	timeout        time.Duration
+	idleTimeout    time.Duration
+	commandTimeout atomic.Int64
+	muLogin        sync.Mutex
+
+	// Serializes the communication with the PDU.
+	// Unlike a mutex, waiting for it can be canceled.
+	lock chan any
+
+	// Output of an aborted command which has not been read yet
+	aborted bool
+
+	// Connection state and credentials for recovering the session
+	muState     sync.Mutex
+	username    string
+	password    string
+	state       pdu.ConnectionState
+	since       time.Time
+	lastError   error
+	reconnects  int
+	backoff     time.Duration
+	nextAttempt time.Time
+	closed      bool
+	connected   bool // Whether a connection has ever been established
+	loggedIn    bool
+	outlets     []pdu.OutletStatus
+
+	// Statistics of executed commands
+	commands int
+	latency  time.Duration
 }
 
+// NewPDU creates a driver for the PDU at the address.
+// The connection is established by the first command and
+// re-established with a backoff while the PDU is unreachable.
 func NewPDU(uri string) (p *PDU, err error) {
 	u, err := url.Parse(uri)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
 
+	switch u.Scheme {
+	case "tcp", "serial", "":
+	default:
+		return nil, fmt.Errorf("unsupported PDU address: %s", u)
+	}
+
 	p = &PDU{
-		timeout: 300 * time.Millisecond,
+		uri:         u,
+		timeout:     300 * time.Millisecond,
+		idleTimeout: 10 * time.Second,
+		lock:        make(chan any, 1),
+		state:       pdu.StateDisconnected,
+		since:       time.Now(),
 	}
 
-	switch u.Scheme {
+	p.commandTimeout.Store(int64(defaultCommandTimeout))
+
+	return p, nil
+}
+
+// SetCommandTimeout changes the duration after which commands are aborted.
+// A zero duration restores the default.
+func (p *PDU) SetCommandTimeout(timeout time.Duration) {
+	if timeout <= 0 {
+		timeout = defaultCommandTimeout
+	}
+
+	p.commandTimeout.Store(int64(timeout))
+}
+
+func (p *PDU) dial(ctx context.Context) (conn io.ReadWriteCloser, err error) {
+	switch p.uri.Scheme {
 	case "tcp":
-		if p.conn, err = net.Dial("tcp", u.Host); err != nil {
+		d := net.Dialer{}
+		if conn, err = d.DialContext(ctx, "tcp", p.uri.Host); err != nil {
 			return nil, fmt.Errorf("failed to establish TCP connection: %w", err)
 		}
 
 	case "serial", "":
-		if p.conn, err = serial.Open(u.Path, &serial.Mode{
+		if conn, err = serial.Open(p.uri.Opaque+p.uri.Path, &serial.Mode{
 			BaudRate: 9600,
 			DataBits: 8,
 			StopBits: serial.OneStopBit,
@@ -85,68 +169,277 @@ func NewPDU(uri string) (p *PDU, err error) {
 		}
 
 	default:
-		return nil, fmt.Errorf("unsupported PDU address: %s", uri)
+		return nil, fmt.Errorf("unsupported PDU address: %s", p.uri)
 	}
 
-	return p, nil
+	return conn, nil
 }
 
 func (p *PDU) Close() error {
-	if err := p.Logout(); err != nil {
-		return fmt.Errorf("failed to logout: %w", err)
+	p.muState.Lock()
+	state := p.state
+	p.muState.Unlock()
+
+	// The connection is closed even if the session is dead
+	var errs []error
+	if state == pdu.StateConnected {
+		if err := p.Logout(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to logout: %w", err))
+		}
 	}
 
-	if err := p.conn.Close(); err != nil {
-		return fmt.Errorf("failed to close: %w", err)
+	p.muState.Lock()
+	p.closed = true
+	p.muState.Unlock()
+
+	p.lock <- nil
+	defer func() { <-p.lock }()
+
+	if p.conn != nil {
+		if err := p.conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close: %w", err))
+		}
+
+		p.conn = nil
 	}
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// Connection returns the current state of the connection to the PDU.
+func (p *PDU) Connection() pdu.Connection {
+	p.muState.Lock()
+	defer p.muState.Unlock()
+
+	c := pdu.Connection{
+		State:      p.state,
+		Since:      p.since,
+		Reconnects: p.reconnects,
+	}
+
+	if p.lastError != nil {
+		errStr := p.lastError.Error()
+		c.LastError = &errStr
+	}
+
+	return c
 }
 
-func (p *PDU) SwitchOutlet(idStr string, state bool) (err error) {
-	id, err := p.lookupID(idStr)
+// Diagnostics returns the connection and session state and statistics of the executed commands.
+func (p *PDU) Diagnostics() (*pdu.Diagnostics, error) {
+	d := &pdu.Diagnostics{
+		Connection: p.Connection(),
+	}
+
+	p.muState.Lock()
+	defer p.muState.Unlock()
+
+	d.LoggedIn = p.loggedIn
+	d.Commands = p.commands
+
+	if p.commands > 0 {
+		d.AverageLatency = float32((p.latency / time.Duration(p.commands)).Seconds())
+	}
+
+	return d, nil
+}
+
+func (p *PDU) setLoggedIn(loggedIn bool) {
+	p.muState.Lock()
+	defer p.muState.Unlock()
+
+	p.loggedIn = loggedIn
+}
+
+func (p *PDU) setState(state pdu.ConnectionState, err error) {
+	p.muState.Lock()
+	defer p.muState.Unlock()
+
 	if err != nil {
-		return fmt.Errorf("invalid outlet ID: %s", err)
+		p.lastError = err
+	}
+
+	if p.state == state {
+		return
 	}
 
+	switch state {
+	case pdu.StateConnected:
+		if p.connected {
+			p.reconnects++
+			slog.Info("Reconnected to PDU", slog.Int("reconnects", p.reconnects))
+		} else {
+			slog.Info("Connected to PDU", slog.String("address", p.uri.String()))
+		}
+
+		p.connected = true
+		p.backoff = 0
+
+	case pdu.StateDisconnected:
+		if p.state == pdu.StateConnected {
+			p.backoff = 0
+			slog.Warn("Lost connection to PDU", slog.Any("error", err))
+		} else {
+			p.backoff = min(max(2*p.backoff, minBackoff), maxBackoff)
+
+			if p.connected {
+				slog.Warn("Failed to reconnect to PDU", slog.Any("error", err), slog.Duration("retry_in", p.backoff))
+			} else {
+				slog.Warn("Failed to connect to PDU", slog.Any("error", err), slog.Duration("retry_in", p.backoff))
+			}
+		}
+
+		p.nextAttempt = time.Now().Add(p.backoff)
+	}
+
+	// The session does not survive a lost connection
+	if state != pdu.StateConnected {
+		p.loggedIn = false
+	}
+
+	p.state = state
+	p.since = time.Now()
+}
+
+func (p *PDU) setCredentials(username, password string) {
+	p.muState.Lock()
+	defer p.muState.Unlock()
+
+	p.username = username
+	p.password = password
+}
+
+func (p *PDU) SwitchOutlet(id string, state bool) ([]pdu.OutletResult, error) {
+	return p.SwitchOutletContext(context.Background(), id, state)
+}
+
+func (p *PDU) SwitchOutletContext(ctx context.Context, id string, state bool) ([]pdu.OutletResult, error) {
 	if state {
-		_, err = p.execute("On %d", id)
+		return p.control(ctx, id, "On")
 	} else {
-		_, err = p.execute("Off %d", id)
+		return p.control(ctx, id, "Off")
 	}
-	return err
 }
 
-func (p *PDU) LockOutlet(idStr string, state bool) (err error) {
-	id, err := p.lookupID(idStr)
-	if err != nil {
-		return fmt.Errorf("%w: %s", pdu.ErrInvalidOutletID, err)
-	}
+func (p *PDU) LockOutlet(id string, state bool) ([]pdu.OutletResult, error) {
+	return p.LockOutletContext(context.Background(), id, state)
+}
 
+func (p *PDU) LockOutletContext(ctx context.Context, id string, state bool) ([]pdu.OutletResult, error) {
 	if state {
-		_, err = p.execute("Lock %d", id)
+		return p.control(ctx, id, "Lock")
 	} else {
-		_, err = p.execute("Unlock %d", id)
+		return p.control(ctx, id, "Unlock")
 	}
-	return err
 }
 
-func (p *PDU) RebootOutlet(idStr string) error {
-	id, err := p.lookupID(idStr)
+func (p *PDU) RebootOutlet(id string) ([]pdu.OutletResult, error) {
+	return p.RebootOutletContext(context.Background(), id)
+}
+
+func (p *PDU) RebootOutletContext(ctx context.Context, id string) ([]pdu.OutletResult, error) {
+	return p.control(ctx, id, "Reboot")
+}
+
+func (p *PDU) StatusOutlets(id string) ([]pdu.OutletStatus, error) {
+	return p.StatusOutletsContext(context.Background(), id)
+}
+
+func (p *PDU) StatusOutletsContext(ctx context.Context, id string) ([]pdu.OutletStatus, error) {
+	outlets, err := p.statusOutlets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdu.ResolveOutlets(id, outlets)
+}
+
+// control executes an outlet command for each of the outlets selected by the expression.
+func (p *PDU) control(ctx context.Context, id string, cmd string) ([]pdu.OutletResult, error) {
+	outlets, err := p.lookupOutlets(ctx, id)
 	if err != nil {
-		return fmt.Errorf("%w: %s", pdu.ErrInvalidOutletID, err)
+		return nil, err
 	}
 
-	_, err = p.execute("Reboot %d", id)
-	return err
+	results := []pdu.OutletResult{}
+	errs := []error{}
+
+	// The PDU can control all outlets with a single command
+	if len(outlets) == NumOutlets {
+		out, err := p.execute(ctx, "%s %d", cmd, All)
+		if err != nil {
+			for _, o := range outlets {
+				results = append(results, pdu.NewOutletResult(o, err))
+			}
+
+			return results, err
+		}
+
+		for _, o := range outlets {
+			err := controlError(out, o.ID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("outlet %d: %w", o.ID, err))
+			}
+
+			results = append(results, pdu.NewOutletResult(o, err))
+		}
+
+		return results, errors.Join(errs...)
+	}
+
+	for _, o := range outlets {
+		out, err := p.execute(ctx, "%s %d", cmd, o.ID)
+		if err == nil {
+			err = controlError(out, o.ID)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("outlet %d: %w", o.ID, err))
+		}
+
+		results = append(results, pdu.NewOutletResult(o, err))
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// controlError returns the error which the PDU reported for an outlet in the reply to an outlet command.
+// The PDU rejects commands for locked outlets and outlets which are not assigned to the current user.
+func controlError(out string, id int) error {
+	for _, m := range reControlOutlet.FindAllStringSubmatch(out, -1) {
+		if m[1] != strconv.Itoa(id) {
+			continue
+		}
+
+		if m[2] == "is locked" {
+			return pdu.ErrOutletLocked
+		}
+
+		return fmt.Errorf("%w: outlet is not assigned to user", pdu.ErrAccessDenied)
+	}
+
+	// Errors which refer to the whole command
+	if m := reControlError.FindStringSubmatch(out); m != nil {
+		if strings.HasPrefix(strings.ToLower(m[1]), "access denied") {
+			return fmt.Errorf("%w: %s", pdu.ErrAccessDenied, m[1])
+		}
+
+		return fmt.Errorf("%w: %s", pdu.ErrRejected, m[1])
+	}
+
+	return nil
 }
 
 func (p *PDU) Status(detailed bool) (*pdu.Status, error) {
+	return p.StatusContext(context.Background(), detailed)
+}
+
+func (p *PDU) StatusContext(ctx context.Context, detailed bool) (*pdu.Status, error) {
 	sts := &pdu.Status{
 		Timestamp: time.Now(),
 	}
 
-	out, err := p.execute("Status")
+	out, err := p.execute(ctx, "Status")
 	if err != nil {
 		return sts, err
 	}
@@ -267,7 +560,7 @@ func (p *PDU) Status(detailed bool) (*pdu.Status, error) {
 	}
 
 	if detailed {
-		if sts.Outlets, err = p.statusOutlets(); err != nil {
+		if sts.Outlets, err = p.statusOutlets(ctx); err != nil {
 			return nil, err
 		}
 	}
@@ -276,7 +569,11 @@ func (p *PDU) Status(detailed bool) (*pdu.Status, error) {
 }
 
 func (p *PDU) WhoAmI() (string, error) {
-	out, err := p.execute("Whoami")
+	return p.WhoAmIContext(context.Background())
+}
+
+func (p *PDU) WhoAmIContext(ctx context.Context) (string, error) {
+	out, err := p.execute(ctx, "Whoami")
 	if err != nil {
 		return "", err
 	}
@@ -289,17 +586,37 @@ func (p *PDU) WhoAmI() (string, error) {
 	return strings.TrimSpace(m[1]), err
 }
 
-func (p *PDU) WithLogin(username, password string, cb func()) (err error) {
+// WithLogin runs the callback while logged in as another user.
+// The previous session is restored afterwards.
+func (p *PDU) WithLogin(username, password string, cb func()) error {
+	return p.WithLoginContext(context.Background(), username, password, cb)
+}
+
+// WithLoginContext is like WithLogin but aborts the login and logout when the context is done.
+// The previous session is also restored if the context is done.
+func (p *PDU) WithLoginContext(ctx context.Context, username, password string, cb func()) (err error) {
 	p.muLogin.Lock()
 	defer p.muLogin.Unlock()
 
-	if err := p.Login(username, password); err != nil {
+	p.muState.Lock()
+	prevUsername, prevPassword := p.username, p.password
+	p.muState.Unlock()
+
+	defer func() {
+		if prevUsername == "" {
+			p.setCredentials("", "")
+		} else if lerr := p.LoginContext(context.WithoutCancel(ctx), prevUsername, prevPassword); lerr != nil && err == nil {
+			err = fmt.Errorf("failed to restore login: %w", lerr)
+		}
+	}()
+
+	if err := p.LoginContext(ctx, username, password); err != nil {
 		return fmt.Errorf("failed to login: %w", err)
 	}
 
 	cb()
 
-	if err := p.Logout(); err != nil {
+	if err := p.LogoutContext(ctx); err != nil {
 		return fmt.Errorf("failed to logout: %w", err)
 	}
 
@@ -307,27 +624,56 @@ func (p *PDU) WithLogin(username, password string, cb func()) (err error) {
 }
 
 func (p *PDU) Login(username, password string) error {
-	if user, err := p.WhoAmI(); err != nil {
+	return p.LoginContext(context.Background(), username, password)
+}
+
+func (p *PDU) LoginContext(ctx context.Context, username, password string) error {
+	// Remember credentials for recovering the session after a reconnect
+	p.setCredentials(username, password)
+
+	if user, err := p.WhoAmIContext(ctx); err != nil {
 		if !errors.Is(err, pdu.ErrLoginRequired) {
 			return err
 		}
 	} else if user != username {
 		slog.Debug("Already logged in with wrong user. Logging out...", slog.String("username", user))
-		if err := p.Logout(); err != nil {
+		if _, err := p.execute(ctx, "Logout"); err != nil {
 			return err
 		}
 	} else {
 		slog.Debug("Already logged in", slog.String("username", username))
+		p.setLoggedIn(true)
 		return nil // Already logged in
 	}
 
 	slog.Debug("Logging in", slog.String("username", username))
 
+	if _, err := p.communicate(ctx, p.loginDialog(username, password)); err != nil {
+		if errors.Is(err, pdu.ErrInvalidPassword) {
+			p.setCredentials("", "")
+		}
+
+		return err
+	}
+
+	user, err := p.WhoAmIContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Logged in", slog.String("username", user))
+
+	p.setLoggedIn(true)
+
+	return err
+}
+
+func (p *PDU) loginDialog(username, password string) func(string) (bool, string, error) {
 	str := ""
 	sentUsername := false
 	sentPassword := false
 
-	if _, err := p.communicate(func(buf string) (bool, string, error) {
+	return func(buf string) (bool, string, error) {
 		str += buf
 
 		switch {
@@ -355,29 +701,25 @@ func (p *PDU) Login(username, password string) error {
 		}
 
 		return false, "", nil
-	}); err != nil {
-		return err
-	}
-
-	user, err := p.WhoAmI()
-	if err != nil {
-		return err
 	}
-
-	slog.Debug("Logged in", slog.String("username", user))
-
-	return err
 }
 
 func (p *PDU) Logout() error {
-	_, err := p.execute("Logout")
+	return p.LogoutContext(context.Background())
+}
+
+func (p *PDU) LogoutContext(ctx context.Context) error {
+	p.setCredentials("", "")
+	p.setLoggedIn(false)
+
+	_, err := p.execute(ctx, "Logout")
 	return err
 }
 
-func (p *PDU) statusOutlets() ([]pdu.OutletStatus, error) {
+func (p *PDU) statusOutlets(ctx context.Context) ([]pdu.OutletStatus, error) {
 	outlets := []pdu.OutletStatus{}
 
-	out, err := p.execute("Ostatus")
+	out, err := p.execute(ctx, "Ostatus")
 	if err != nil {
 		return nil, err
 	}
@@ -444,16 +786,30 @@ func (p *PDU) statusOutlets() ([]pdu.OutletStatus, error) {
 		outlets = append(outlets, outlet)
 	}
 
+	// Remember outlet names for resolving outlet expressions
+	// The returned outlets are owned by the caller which may modify them
+	p.muState.Lock()
+	p.outlets = slices.Clone(outlets)
+	p.muState.Unlock()
+
 	return outlets, nil
 }
 
 func (p *PDU) ClearMaximumCurrents() error {
-	_, err := p.execute("Clear")
+	return p.ClearMaximumCurrentsContext(context.Background())
+}
+
+func (p *PDU) ClearMaximumCurrentsContext(ctx context.Context) error {
+	_, err := p.execute(ctx, "Clear")
 	return err
 }
 
 func (p *PDU) Temperature() (float64, error) {
-	out, err := p.execute("Temp")
+	return p.TemperatureContext(context.Background())
+}
+
+func (p *PDU) TemperatureContext(ctx context.Context) (float64, error) {
+	out, err := p.execute(ctx, "Temp")
 	if err != nil {
 		return 0, err
 	}
@@ -474,18 +830,56 @@ func (p *PDU) Temperature() (float64, error) {
 }
 
 func (p *PDU) send(cmd string) error {
-	_, err := p.conn.Write([]byte(cmd + "\r\n"))
-	return err
+	if _, err := p.conn.Write([]byte(cmd + "\r\n")); err != nil {
+		return fmt.Errorf("%w: %w", ErrDisconnected, err)
+	}
+
+	return nil
 }
 
-func (p *PDU) execute(cmd string, args ...any) (string, error) {
+func (p *PDU) execute(ctx context.Context, cmd string, args ...any) (string, error) {
 	cmd = fmt.Sprintf(cmd, args...)
+	started := time.Now()
+
+	res, sent, err := p.executeOnce(ctx, cmd)
+
+	// Retry once after a reconnect if the command has not been sent yet
+	if errors.Is(err, ErrDisconnected) && !sent && cmd != "Logout" {
+		res, _, err = p.executeOnce(ctx, cmd)
+	}
+
+	finished := time.Now()
+
+	p.muState.Lock()
+	p.commands++
+	p.latency += finished.Sub(started)
+
+	if errors.Is(err, pdu.ErrLoginRequired) {
+		p.loggedIn = false
+	}
+	p.muState.Unlock()
+
+	opts := []any{slog.String("command", cmd), slog.Duration("took", finished.Sub(started))}
+	if len(args) > 0 {
+		opts = append(opts, slog.Any("args", args))
+	}
+
+	if err != nil {
+		opts = append(opts, slog.Any("error", err))
+	} else if res != "" {
+		opts = append(opts, slog.Int("#result", len(res)))
+	}
+
+	slog.Debug("Executed PDU command", opts...)
+
+	return res, err
+}
+
+func (p *PDU) executeOnce(ctx context.Context, cmd string) (string, bool, error) {
 	str := ""
 	sent := false
 
-	started := time.Now()
-
-	res, err := p.communicate(func(buf string) (bool, string, error) {
+	res, err := p.communicate(ctx, func(buf string) (bool, string, error) {
 		str += buf
 
 		// There is no prompt after logout
@@ -517,67 +911,138 @@ func (p *PDU) execute(cmd string, args ...any) (string, error) {
 
 		case strings.HasSuffix(str, promptUsername), strings.HasSuffix(str, promptPassword):
 			return false, "", pdu.ErrLoginRequired
+
+		// An aborted dialog may have left the configuration menu open
+		case !sent && strings.HasSuffix(str, promptMenu):
+			if err := p.send(menuExit); err != nil {
+				return false, "", err
+			}
+
+			str = ""
 		}
 
 		return false, "", nil
 	})
 
-	finished := time.Now()
+	return res, sent, err
+}
 
-	opts := []any{slog.String("command", cmd), slog.Duration("took", finished.Sub(started))}
-	if len(args) > 0 {
-		opts = append(opts, slog.Any("args", args))
+// communicate exchanges data with the PDU until the callback signals completion.
+// The connection is established on first use.
+// A lost connection is re-established and the session is recovered
+// with the last credentials passed to Login.
+// The exchange is aborted when the context is done or the command timeout expires.
+func (p *PDU) communicate(ctx context.Context, cb func(out string) (bool, string, error)) (string, error) {
+	select {
+	case p.lock <- nil:
+		defer func() { <-p.lock }()
+
+	case <-ctx.Done():
+		return "", contextError(ctx)
 	}
 
-	if err != nil {
-		opts = append(opts, slog.Any("error", err))
-	} else if res != "" {
-		opts = append(opts, slog.Int("#result", len(res)))
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(p.commandTimeout.Load()))
+	defer cancel()
+
+	if p.conn == nil {
+		if err := p.reconnect(ctx); err != nil {
+			return "", err
+		}
 	}
 
-	slog.Debug("Executed PDU command", opts...)
+	out, err := p.interact(ctx, cb)
+	if errors.Is(err, ErrDisconnected) {
+		p.disconnect(err)
+	}
 
-	return res, err
+	return out, err
 }
 
-func (p *PDU) communicate(cb func(out string) (bool, string, error)) (string, error) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
+// reconnect establishes the connection and recovers the session.
+// The caller must hold p.lock.
+func (p *PDU) reconnect(ctx context.Context) (err error) {
+	p.muState.Lock()
+	closed := p.closed
+	nextAttempt := p.nextAttempt
+	username, password := p.username, p.password
+	p.muState.Unlock()
+
+	if closed {
+		return ErrClosed
+	}
+
+	if wait := time.Until(nextAttempt); wait > 0 {
+		return fmt.Errorf("%w: next reconnect attempt in %s", ErrDisconnected, wait.Round(time.Second))
+	}
+
+	p.setState(pdu.StateConnecting, nil)
+
+	if p.conn, err = p.dial(ctx); err != nil {
+		p.disconnect(err)
+		return fmt.Errorf("%w: %w", ErrDisconnected, err)
+	}
+
+	if username != "" {
+		slog.Debug("Recovering session", slog.String("username", username))
+
+		if _, err := p.interact(ctx, p.loginDialog(username, password)); err != nil {
+			p.disconnect(err)
+			return fmt.Errorf("%w: failed to login: %w", ErrDisconnected, err)
+		}
+	}
+
+	p.setState(pdu.StateConnected, nil)
+	p.setLoggedIn(username != "")
+
+	return nil
+}
+
+// disconnect closes a dead connection so that it gets re-established by the next command.
+// The caller must hold p.lock.
+func (p *PDU) disconnect(err error) {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+	}
+
+	p.setState(pdu.StateDisconnected, err)
+}
+
+// interact sends an empty line and passes received data to the callback.
+// The caller must hold p.lock.
+func (p *PDU) interact(ctx context.Context, cb func(out string) (bool, string, error)) (string, error) {
+	if p.aborted {
+		if err := p.drain(ctx); err != nil {
+			return "", err
+		}
+	}
 
 	if err := p.send(""); err != nil {
 		return "", err
 	}
 
-	for {
-		switch c := p.conn.(type) {
-		case *net.TCPConn:
-			if err := c.SetReadDeadline(time.Now().Add(p.timeout)); err != nil {
-				return "", fmt.Errorf("failed to set read deadline: %w", err)
-			}
-
-		case serial.Port:
-			if err := c.SetReadTimeout(p.timeout); err != nil {
-				return "", fmt.Errorf("failed to set read deadline: %w", err)
-			}
+	lastActivity := time.Now()
 
-		default:
-			return "", fmt.Errorf("unsupported connection type: %T", p.conn)
+	for {
+		if ctx.Err() != nil {
+			p.aborted = true
+			return "", contextError(ctx)
 		}
 
-		buf := make([]byte, 2048)
-		n, err := p.conn.Read(buf)
+		n, buf, err := p.read(ctx)
 		if err != nil {
-			if errors.Is(err, os.ErrDeadlineExceeded) {
-				continue // Timeout
-			}
-
 			return "", err
 		} else if n == 0 {
+			if time.Since(lastActivity) > p.idleTimeout {
+				return "", fmt.Errorf("%w: %w", ErrDisconnected, ErrTimeout)
+			}
+
 			continue // Timeout
 		}
 
-		sbuf := string(buf[:n])
-		if done, out, err := cb(sbuf); err != nil {
+		lastActivity = time.Now()
+
+		if done, out, err := cb(string(buf[:n])); err != nil {
 			return "", err
 		} else if done {
 			return out, nil
@@ -585,18 +1050,91 @@ func (p *PDU) communicate(cb func(out string) (bool, string, error)) (string, er
 	}
 }
 
-func (p *PDU) lookupID(idStr string) (int, error) {
-	if idStr == "all" {
-		return 0, nil
+// drain discards the remaining output of an aborted command until the PDU is idle.
+// The caller must hold p.lock.
+func (p *PDU) drain(ctx context.Context) error {
+	for {
+		if ctx.Err() != nil {
+			return contextError(ctx)
+		}
+
+		n, _, err := p.read(ctx)
+		if err != nil {
+			return err
+		} else if n == 0 {
+			p.aborted = false
+			return nil
+		}
+	}
+}
+
+// read waits for data until the read timeout or the deadline of the context expires.
+// It returns no data on timeouts.
+// The caller must hold p.lock.
+func (p *PDU) read(ctx context.Context) (int, []byte, error) {
+	switch c := p.conn.(type) {
+	case *net.TCPConn:
+		deadline := time.Now().Add(p.timeout)
+		if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+			deadline = d
+		}
+
+		if err := c.SetReadDeadline(deadline); err != nil {
+			return 0, nil, fmt.Errorf("failed to set read deadline: %w", err)
+		}
+
+	case serial.Port:
+		if err := c.SetReadTimeout(p.timeout); err != nil {
+			return 0, nil, fmt.Errorf("failed to set read deadline: %w", err)
+		}
+
+	default:
+		return 0, nil, fmt.Errorf("unsupported connection type: %T", p.conn)
+	}
+
+	buf := make([]byte, 2048)
+	n, err := p.conn.Read(buf)
+	if err != nil && !errors.Is(err, os.ErrDeadlineExceeded) {
+		return 0, nil, fmt.Errorf("%w: %w", ErrDisconnected, err)
+	}
+
+	return n, buf, nil
+}
+
+// contextError returns the reason why the context is done.
+// An expired deadline is reported as a timeout.
+func contextError(ctx context.Context) error {
+	err := context.Cause(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+
+	return err
+}
+
+// lookupOutlets resolves an outlet expression.
+// The outlet names are only queried from the PDU if they are not known yet or have changed.
+func (p *PDU) lookupOutlets(ctx context.Context, id string) ([]pdu.OutletStatus, error) {
+	p.muState.Lock()
+	outlets := p.outlets
+	p.muState.Unlock()
+
+	if outlets == nil {
+		for i := range NumOutlets {
+			outlets = append(outlets, pdu.OutletStatus{
+				ID: i + 1,
+			})
+		}
 	}
 
-	if id, err := strconv.ParseInt(idStr, 0, 64); err == nil {
-		if id < 0 || id > NumOutlets {
-			return -1, pdu.ErrInvalidOutletID
+	resolved, err := pdu.ResolveOutlets(id, outlets)
+	if errors.Is(err, pdu.ErrNotFound) {
+		if outlets, err = p.statusOutlets(ctx); err != nil {
+			return nil, err
 		}
 
-		return int(id), nil
+		return pdu.ResolveOutlets(id, outlets)
 	}
 
-	return -1, fmt.Errorf("%w: %s", pdu.ErrNotFound, idStr)
+	return resolved, err
 }
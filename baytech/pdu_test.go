@@ -0,0 +1,465 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package baytech_test
+
+import (
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"testing"
+	"time"
+
+	pdu "github.com/stv0g/pductl"
+	"github.com/stv0g/pductl/baytech"
+)
+
+const (
+	testUsername = "admin"
+	testPassword = "secret"
+)
+
+// newTestPDU connects the driver to a simulator and logs in.
+func newTestPDU(t *testing.T) (*baytech.PDU, *baytech.Simulator) {
+	t.Helper()
+
+	sim := baytech.NewSimulator(testUsername, testPassword)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	go sim.Serve(ln) //nolint:errcheck
+
+	t.Cleanup(func() {
+		ln.Close()
+	})
+
+	p, err := baytech.NewPDU("tcp://" + ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	t.Cleanup(func() {
+		p.Close()
+	})
+
+	if err := p.Login(testUsername, testPassword); err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+
+	return p, sim
+}
+
+// outlet returns the status of a single outlet.
+func outlet(t *testing.T, p *baytech.PDU, id string) pdu.OutletStatus {
+	t.Helper()
+
+	outlets, err := p.StatusOutlets(id)
+	if err != nil {
+		t.Fatalf("Failed to get status of outlet %s: %v", id, err)
+	} else if len(outlets) != 1 {
+		t.Fatalf("Expected a single outlet, got %d", len(outlets))
+	}
+
+	return outlets[0]
+}
+
+// checkResults fails if the results do not cover exactly the outlets or contain errors.
+func checkResults(t *testing.T, results []pdu.OutletResult, err error, ids ...int) {
+	t.Helper()
+
+	if err != nil {
+		t.Fatalf("Command failed: %v", err)
+	}
+
+	got := []int{}
+	for _, r := range results {
+		if r.Error != nil {
+			t.Errorf("Outlet %d failed: %s", r.ID, *r.Error)
+		}
+
+		got = append(got, r.ID)
+	}
+
+	if !slices.Equal(got, ids) {
+		t.Fatalf("Expected results for outlets %v, got %v", ids, got)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	p, _ := newTestPDU(t)
+
+	sts, err := p.Status(true)
+	if err != nil {
+		t.Fatalf("Failed to get status: %v", err)
+	}
+
+	if len(sts.Outlets) != baytech.NumOutlets {
+		t.Errorf("Expected %d outlets, got %d", baytech.NumOutlets, len(sts.Outlets))
+	}
+
+	if len(sts.Groups) != baytech.NumGroups {
+		t.Errorf("Expected %d groups, got %d", baytech.NumGroups, len(sts.Groups))
+	}
+
+	if len(sts.Breakers) != baytech.NumBreakers {
+		t.Errorf("Expected %d breakers, got %d", baytech.NumBreakers, len(sts.Breakers))
+	}
+
+	for i, o := range sts.Outlets {
+		if o.ID != i+1 || o.Name == "" {
+			t.Errorf("Unexpected outlet %d: %+v", i+1, o)
+		}
+
+		if !o.State {
+			t.Errorf("Outlet %d is off", o.ID)
+		}
+
+		if o.TrueRMSVoltage <= 0 {
+			t.Errorf("Outlet %d has no voltage", o.ID)
+		}
+	}
+
+	if temp, err := p.Temperature(); err != nil {
+		t.Errorf("Failed to get temperature: %v", err)
+	} else if temp < 20 || temp > 30 {
+		t.Errorf("Expected about 25 °C, got %f", temp)
+	}
+}
+
+func TestStatusOutlets(t *testing.T) {
+	p, sim := newTestPDU(t)
+
+	if err := sim.SetOutlet(3, "server-3", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	outlets, err := p.StatusOutlets("1,3-4")
+	if err != nil {
+		t.Fatalf("Failed to get status of outlets: %v", err)
+	}
+
+	ids := []int{}
+	for _, o := range outlets {
+		ids = append(ids, o.ID)
+	}
+
+	if !slices.Equal(ids, []int{1, 3, 4}) {
+		t.Errorf("Expected outlets 1, 3 and 4, got %v", ids)
+	}
+
+	if o := outlet(t, p, "server-3"); o.ID != 3 {
+		t.Errorf("Expected outlet 3 by its name, got %d", o.ID)
+	}
+
+	if _, err := p.StatusOutlets("unknown"); !errors.Is(err, pdu.ErrNotFound) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+
+	if _, err := p.StatusOutlets("21"); !errors.Is(err, pdu.ErrInvalidOutletID) {
+		t.Errorf("Expected invalid outlet error, got %v", err)
+	}
+}
+
+func TestSwitchOutlet(t *testing.T) {
+	p, _ := newTestPDU(t)
+
+	results, err := p.SwitchOutlet("2", false)
+	checkResults(t, results, err, 2)
+
+	if o := outlet(t, p, "2"); o.State {
+		t.Errorf("Outlet 2 is still on")
+	}
+
+	results, err = p.SwitchOutlet("1-2", true)
+	checkResults(t, results, err, 1, 2)
+
+	if o := outlet(t, p, "2"); !o.State {
+		t.Errorf("Outlet 2 is still off")
+	}
+}
+
+func TestLockOutlet(t *testing.T) {
+	p, _ := newTestPDU(t)
+
+	results, err := p.LockOutlet("5", true)
+	checkResults(t, results, err, 5)
+
+	if o := outlet(t, p, "5"); !o.Locked {
+		t.Errorf("Outlet 5 is not locked")
+	}
+
+	results, err = p.LockOutlet("5", false)
+	checkResults(t, results, err, 5)
+
+	if o := outlet(t, p, "5"); o.Locked {
+		t.Errorf("Outlet 5 is still locked")
+	}
+}
+
+func TestSwitchLockedOutlet(t *testing.T) {
+	p, _ := newTestPDU(t)
+
+	results, err := p.LockOutlet("5", true)
+	checkResults(t, results, err, 5)
+
+	results, err = p.SwitchOutlet("4-6", false)
+	if !errors.Is(err, pdu.ErrOutletLocked) {
+		t.Errorf("Expected locked error, got %v", err)
+	}
+
+	for _, r := range results {
+		if failed := r.Error != nil; failed != (r.ID == 5) {
+			t.Errorf("Unexpected result for outlet %d: %v", r.ID, r.Error)
+		}
+	}
+
+	if o := outlet(t, p, "5"); !o.State {
+		t.Errorf("Locked outlet 5 has been switched off")
+	}
+
+	// All outlets are switched by a single command
+	results, err = p.SwitchOutlet("all", false)
+	if !errors.Is(err, pdu.ErrOutletLocked) {
+		t.Errorf("Expected locked error, got %v", err)
+	} else if len(results) != baytech.NumOutlets || results[4].Error == nil || results[3].Error != nil {
+		t.Errorf("Expected only outlet 5 to fail, got %+v", results)
+	}
+}
+
+func TestSwitchUnassignedOutlet(t *testing.T) {
+	p, _ := newTestPDU(t)
+
+	if err := p.AddUser("operator", "pw"); err != nil {
+		t.Fatalf("Failed to add user: %v", err)
+	}
+
+	if err := p.SetUserOutlets("operator", "1-3"); err != nil {
+		t.Fatalf("Failed to assign outlets: %v", err)
+	}
+
+	if err := p.WithLogin("operator", "pw", func() {
+		results, err := p.SwitchOutlet("3-4", false)
+		if !errors.Is(err, pdu.ErrAccessDenied) {
+			t.Errorf("Expected access denied error, got %v", err)
+		} else if len(results) != 2 || results[0].Error != nil || results[1].Error == nil {
+			t.Errorf("Expected only outlet 4 to fail, got %+v", results)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to login temporarily: %v", err)
+	}
+
+	if o := outlet(t, p, "4"); !o.State {
+		t.Errorf("Unassigned outlet 4 has been switched off")
+	}
+}
+
+func TestLogin(t *testing.T) {
+	p, _ := newTestPDU(t)
+
+	if user, err := p.WhoAmI(); err != nil {
+		t.Fatalf("Failed to get user: %v", err)
+	} else if user != testUsername {
+		t.Errorf("Expected user %s, got %s", testUsername, user)
+	}
+
+	if err := p.Logout(); err != nil {
+		t.Fatalf("Failed to logout: %v", err)
+	}
+
+	if _, err := p.WhoAmI(); !errors.Is(err, pdu.ErrLoginRequired) {
+		t.Errorf("Expected login required error, got %v", err)
+	}
+
+	if err := p.Login(testUsername, "wrong"); !errors.Is(err, pdu.ErrInvalidPassword) {
+		t.Errorf("Expected invalid password error, got %v", err)
+	}
+
+	if err := p.Login(testUsername, testPassword); err != nil {
+		t.Fatalf("Failed to login again: %v", err)
+	}
+}
+
+func TestWithLogin(t *testing.T) {
+	p, _ := newTestPDU(t)
+
+	// User names may contain underscores
+	if err := p.AddUser("ops_1", "pw"); err != nil {
+		t.Fatalf("Failed to add user: %v", err)
+	}
+
+	if err := p.WithLogin("ops_1", "pw", func() {
+		if user, err := p.WhoAmI(); err != nil || user != "ops_1" {
+			t.Errorf("Expected user ops_1, got %s: %v", user, err)
+		}
+	}); err != nil {
+		t.Fatalf("Failed to login temporarily: %v", err)
+	}
+
+	// The previous session is restored
+	if user, err := p.WhoAmI(); err != nil || user != testUsername {
+		t.Errorf("Expected user %s, got %s: %v", testUsername, user, err)
+	}
+
+	if err := p.WithLogin("ops_1", "wrong", func() {
+		t.Error("Callback invoked despite failed login")
+	}); !errors.Is(err, pdu.ErrInvalidPassword) {
+		t.Errorf("Expected invalid password error, got %v", err)
+	}
+
+	if user, err := p.WhoAmI(); err != nil || user != testUsername {
+		t.Errorf("Expected user %s after failed login, got %s: %v", testUsername, user, err)
+	}
+}
+
+func TestUsers(t *testing.T) {
+	p, _ := newTestPDU(t)
+
+	if err := p.AddUser("bob", "secret1"); err != nil {
+		t.Fatalf("Failed to add user: %v", err)
+	}
+
+	if err := p.SetUserOutlets("bob", "1-3,7"); err != nil {
+		t.Fatalf("Failed to assign outlets: %v", err)
+	}
+
+	if err := p.ChangePassword("bob", "secret2"); err != nil {
+		t.Fatalf("Failed to change password: %v", err)
+	}
+
+	users, err := p.Users()
+	if err != nil {
+		t.Fatalf("Failed to list users: %v", err)
+	}
+
+	i := slices.IndexFunc(users, func(u pdu.User) bool { return u.Name == "bob" })
+	if i < 0 {
+		t.Fatalf("Added user is missing: %+v", users)
+	} else if !slices.Equal(users[i].Outlets, []int{1, 2, 3, 7}) {
+		t.Errorf("Expected outlets 1-3 and 7, got %v", users[i].Outlets)
+	}
+
+	if err := p.WithLogin("bob", "secret2", func() {}); err != nil {
+		t.Errorf("Failed to login with changed password: %v", err)
+	}
+
+	if err := p.DeleteUser("bob"); err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+
+	if err := p.DeleteUser("bob"); !errors.Is(err, pdu.ErrUserNotFound) {
+		t.Errorf("Expected user not found error, got %v", err)
+	}
+}
+
+func TestConnectLater(t *testing.T) {
+	// Reserve an address on which nothing is listening yet
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	addr := ln.Addr().String()
+	ln.Close()
+
+	p, err := baytech.NewPDU("tcp://" + addr)
+	if err != nil {
+		t.Fatalf("Failed to create PDU: %v", err)
+	}
+
+	t.Cleanup(func() {
+		p.Close()
+	})
+
+	if err := p.Login(testUsername, testPassword); !errors.Is(err, baytech.ErrDisconnected) {
+		t.Fatalf("Expected disconnected error, got %v", err)
+	}
+
+	if c := p.Connection(); c.State != pdu.StateDisconnected || c.LastError == nil {
+		t.Errorf("Expected disconnected state with error, got %+v", c)
+	}
+
+	sim := baytech.NewSimulator(testUsername, testPassword)
+
+	if ln, err = net.Listen("tcp", addr); err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	go sim.Serve(ln) //nolint:errcheck
+
+	t.Cleanup(func() {
+		ln.Close()
+	})
+
+	// Commands are rejected until the backoff expired
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(100 * time.Millisecond) {
+		if _, err = p.Temperature(); err == nil {
+			break
+		} else if !errors.Is(err, baytech.ErrDisconnected) || time.Now().After(deadline) {
+			t.Fatalf("Failed to connect: %v", err)
+		}
+	}
+
+	if c := p.Connection(); c.State != pdu.StateConnected || c.Reconnects != 0 {
+		t.Errorf("Expected connected state without reconnects, got %+v", c)
+	}
+
+	// The session is established with the credentials of the failed login
+	if user, err := p.WhoAmI(); err != nil || user != testUsername {
+		t.Errorf("Expected user %s, got %s: %v", testUsername, user, err)
+	}
+}
+
+func TestCloseDeadSession(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	t.Cleanup(func() {
+		ln.Close()
+	})
+
+	// The peer accepts the connection but never responds
+	closed := make(chan error, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			closed <- err
+			return
+		}
+
+		defer conn.Close()
+
+		_, err = io.Copy(io.Discard, conn)
+		closed <- err
+	}()
+
+	p, err := baytech.NewPDU("tcp://" + ln.Addr().String())
+	if err != nil {
+		t.Fatalf("Failed to create PDU: %v", err)
+	}
+
+	p.SetCommandTimeout(200 * time.Millisecond)
+
+	if _, err := p.Temperature(); !errors.Is(err, baytech.ErrTimeout) {
+		t.Fatalf("Expected timeout error, got %v", err)
+	}
+
+	if err := p.Close(); !errors.Is(err, baytech.ErrTimeout) {
+		t.Errorf("Expected logout to time out, got %v", err)
+	}
+
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Errorf("Connection failed: %v", err)
+		}
+
+	case <-time.After(2 * time.Second):
+		t.Error("Connection has not been closed")
+	}
+}
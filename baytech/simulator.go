@@ -0,0 +1,794 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package baytech
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand/v2"
+	"net"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	simVoltage     = 120.0 // V
+	simPowerFactor = 0.95
+)
+
+var (
+	reSimUsername = regexp.MustCompile(`^` + usernamePattern + `$`)
+
+	simBreakerNames = []string{"Input A", "CKT1", "CKT2"}
+	simGroupNames   = []string{"Circuit M1", "Circuit M2", "Circuit M3", "Circuit M4"}
+)
+
+type simOutlet struct {
+	Name    string
+	State   bool
+	Locked  bool
+	Load    float64 // Current drawn when switched on [A]
+	Current float64 // [A]
+	Peak    float64 // [A]
+
+	reboot *time.Timer
+}
+
+// Simulator emulates the serial console of a Baytech MMP-14 PDU.
+// It keeps the state of the outlets as well as simulated currents,
+// energy and temperature which are shared between all sessions.
+type Simulator struct {
+	Users       map[string]string // Username -> password
+	RebootDelay time.Duration
+
+	access map[string][]int // Username -> IDs of assigned outlets
+
+	outlets     [NumOutlets]simOutlet
+	groupPeak   [NumGroups]float64
+	breakerPeak [NumBreakers]float64
+	temperature float64 // [F]
+	totalEnergy float64 // [kWh]
+	switches    [NumSwitches]bool
+	lastUpdate  time.Time
+	mu          sync.Mutex
+}
+
+func NewSimulator(username, password string) *Simulator {
+	s := &Simulator{
+		Users: map[string]string{
+			username: password,
+		},
+		RebootDelay: 5 * time.Second,
+		temperature: 77,
+		lastUpdate:  time.Now(),
+		access:      map[string][]int{},
+	}
+
+	for i := range s.outlets {
+		s.access[username] = append(s.access[username], i+1)
+		s.outlets[i] = simOutlet{
+			Name:  fmt.Sprintf("Outlet %d", i+1),
+			State: true,
+			Load:  0.1 + rand.Float64()*0.9,
+		}
+	}
+
+	s.update()
+
+	return s
+}
+
+// SetOutlet changes the name and load of a simulated outlet.
+func (s *Simulator) SetOutlet(id int, name string, load float64) error {
+	if id < 1 || id > NumOutlets {
+		return fmt.Errorf("invalid outlet: %d", id)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o := &s.outlets[id-1]
+	o.Name = name
+	o.Load = load
+
+	return nil
+}
+
+// Serve accepts connections on the listener and handles each of them in a separate session.
+func (s *Simulator) Serve(ln net.Listener) error {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+
+			return err
+		}
+
+		slog.Debug("Accepted connection", slog.Any("remote", conn.RemoteAddr()))
+
+		go func() {
+			defer conn.Close()
+
+			if err := s.ServeConn(conn); err != nil {
+				slog.Error("Session failed", slog.Any("error", err))
+			}
+		}()
+	}
+}
+
+// ServeConn handles a single console session until the connection is closed.
+func (s *Simulator) ServeConn(conn io.ReadWriter) error {
+	ss := &simSession{
+		sim: s,
+		w:   conn,
+	}
+
+	rd := bufio.NewReader(conn)
+
+	for {
+		line, err := readLine(rd)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		if err := ss.handle(line); err != nil {
+			return err
+		}
+	}
+}
+
+// readLine reads a line terminated by either CR, LF or CRLF.
+func readLine(rd *bufio.Reader) (string, error) {
+	var sb strings.Builder
+
+	for {
+		c, err := rd.ReadByte()
+		if err != nil {
+			return "", err
+		}
+
+		switch c {
+		case '\r':
+			if n, err := rd.Peek(1); err == nil && n[0] == '\n' {
+				rd.ReadByte()
+			}
+
+			return sb.String(), nil
+
+		case '\n':
+			return sb.String(), nil
+
+		default:
+			sb.WriteByte(c)
+		}
+	}
+}
+
+// update advances the simulated measurements to the current time.
+// The caller must hold s.mu.
+func (s *Simulator) update() {
+	now := time.Now()
+	deltaT := now.Sub(s.lastUpdate).Hours()
+	s.lastUpdate = now
+
+	total := 0.0
+	for i := range s.outlets {
+		o := &s.outlets[i]
+
+		if o.State {
+			o.Current = o.Load * (0.95 + 0.1*rand.Float64())
+		} else {
+			o.Current = 0
+		}
+
+		o.Peak = max(o.Peak, o.Current)
+		total += o.Current
+	}
+
+	for g := range s.groupPeak {
+		s.groupPeak[g] = max(s.groupPeak[g], s.groupCurrent(g))
+	}
+
+	for b := range s.breakerPeak {
+		s.breakerPeak[b] = max(s.breakerPeak[b], s.breakerCurrent(b))
+	}
+
+	s.totalEnergy += 1e-3 * deltaT * total * simVoltage * simPowerFactor
+	s.temperature = 77 + 5*total/(2*20) + rand.Float64() - 0.5
+}
+
+func (s *Simulator) groupCurrent(g int) (cur float64) {
+	for _, o := range s.outlets[g*5 : (g+1)*5] {
+		cur += o.Current
+	}
+
+	return cur
+}
+
+// breakerCurrent returns the current of the main input (0) or one of the two circuits (1, 2).
+func (s *Simulator) breakerCurrent(b int) (cur float64) {
+	switch b {
+	case 0:
+		return s.groupCurrent(0) + s.groupCurrent(1) + s.groupCurrent(2) + s.groupCurrent(3)
+	default:
+		return s.groupCurrent(2*(b-1)) + s.groupCurrent(2*(b-1)+1)
+	}
+}
+
+func (s *Simulator) parseOutlets(arg string) ([]int, error) {
+	if strings.EqualFold(arg, "all") || arg == "0" {
+		ids := make([]int, NumOutlets)
+		for i := range ids {
+			ids[i] = i + 1
+		}
+
+		return ids, nil
+	}
+
+	ids := []int{}
+	for _, a := range strings.Split(arg, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(a))
+		if err != nil || id < 1 || id > NumOutlets {
+			return nil, fmt.Errorf("invalid outlet: %s", a)
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+type simSessionState int
+
+const (
+	simLoggedOut simSessionState = iota
+	simUsername
+	simPassword
+	simReady
+)
+
+type simMenu int
+
+const (
+	simMenuNone simMenu = iota
+	simMenuConfig
+	simMenuUsers
+)
+
+type simSession struct {
+	sim      *Simulator
+	w        io.Writer
+	state    simSessionState
+	username string
+	pending  string
+
+	// Configuration menu
+	menu   simMenu
+	input  func(line string) string // Handles the reply to a prompt
+	secret bool                     // Do not echo the reply
+}
+
+func (ss *simSession) write(format string, args ...any) error {
+	_, err := fmt.Fprintf(ss.w, format, args...)
+	return err
+}
+
+func (ss *simSession) handle(line string) error {
+	switch ss.state {
+	case simLoggedOut:
+		ss.state = simUsername
+		return ss.write(promptUsername)
+
+	case simUsername:
+		if line == "" {
+			return ss.write("\r\n" + promptUsername)
+		}
+
+		ss.pending = line
+		ss.state = simPassword
+
+		return ss.write("%s\r\r\n%s", line, promptPassword)
+
+	case simPassword:
+		ss.sim.mu.Lock()
+		password, ok := ss.sim.Users[ss.pending]
+		ss.sim.mu.Unlock()
+
+		if !ok || password != line {
+			ss.state = simLoggedOut
+			return ss.write("\r\n" + promptInvalidPassword)
+		}
+
+		ss.username = ss.pending
+		ss.state = simReady
+
+		slog.Debug("Simulator session logged in", slog.String("username", ss.username))
+
+		return ss.write("\r\n\r\nMMP-14 Simulator\r\n\r\n%s", promptReady)
+
+	case simReady:
+		if input := ss.input; input != nil {
+			return ss.handleInput(input, line)
+		} else if ss.menu != simMenuNone {
+			return ss.handleMenu(line)
+		}
+
+		if err := ss.write("%s\r\r\n", line); err != nil {
+			return err
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			return ss.write(promptReady)
+		}
+
+		cmd, args := strings.ToLower(fields[0]), fields[1:]
+		switch cmd {
+		case "logout":
+			ss.state = simLoggedOut
+			ss.username = ""
+
+			return nil
+
+		case "config":
+			ss.menu = simMenuConfig
+
+			return ss.write(ss.menuText())
+		}
+
+		out := ss.execute(cmd, args)
+		if out != "" {
+			out = strings.ReplaceAll(out, "\n", "\r\n") + "\r\n"
+		}
+
+		return ss.write("%s\r\n%s", out, promptReady)
+	}
+
+	return nil
+}
+
+func (ss *simSession) execute(cmd string, args []string) string {
+	s := ss.sim
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.update()
+
+	switch cmd {
+	case "status":
+		return s.status()
+
+	case "ostatus":
+		return s.ostatus()
+
+	case "temp":
+		return fmt.Sprintf("Int. Temp:  %.1f F", s.temperature)
+
+	case "whoami":
+		return fmt.Sprintf("Current User: %s", ss.username)
+
+	case "clear":
+		for i := range s.outlets {
+			s.outlets[i].Peak = s.outlets[i].Current
+		}
+
+		for g := range s.groupPeak {
+			s.groupPeak[g] = s.groupCurrent(g)
+		}
+
+		for b := range s.breakerPeak {
+			s.breakerPeak[b] = s.breakerCurrent(b)
+		}
+
+		return "Maximum detected current cleared"
+
+	case "on", "off", "reboot", "lock", "unlock":
+		if len(args) != 1 {
+			return "Input error"
+		}
+
+		ids, err := s.parseOutlets(args[0])
+		if err != nil {
+			return "Input error"
+		}
+
+		return s.control(cmd, ss.username, ids)
+	}
+
+	return "Input error"
+}
+
+// control applies an outlet command. The caller must hold s.mu.
+func (s *Simulator) control(cmd, username string, ids []int) string {
+	lines := []string{}
+
+	for _, id := range ids {
+		o := &s.outlets[id-1]
+
+		if !slices.Contains(s.access[username], id) {
+			lines = append(lines, fmt.Sprintf("Outlet %d is not assigned to user", id))
+			continue
+		}
+
+		switch cmd {
+		case "lock":
+			o.Locked = true
+			continue
+
+		case "unlock":
+			o.Locked = false
+			continue
+		}
+
+		if o.Locked {
+			lines = append(lines, fmt.Sprintf("Outlet %d is locked", id))
+			continue
+		}
+
+		if o.reboot != nil {
+			o.reboot.Stop()
+			o.reboot = nil
+		}
+
+		switch cmd {
+		case "on":
+			o.State = true
+
+		case "off":
+			o.State = false
+
+		case "reboot":
+			o.State = false
+			o.reboot = time.AfterFunc(s.RebootDelay, func() {
+				s.mu.Lock()
+				defer s.mu.Unlock()
+
+				o.State = true
+				o.reboot = nil
+			})
+		}
+	}
+
+	s.update()
+
+	return strings.Join(lines, "\n")
+}
+
+func (s *Simulator) status() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Average Power: %4.0f Watts   Apparent Power: %4.0f VA\n",
+		s.breakerCurrent(0)*simVoltage*simPowerFactor, s.breakerCurrent(0)*simVoltage)
+	fmt.Fprintf(&sb, "\n")
+	fmt.Fprintf(&sb, "Int. Temp:  %.1f F\n", s.temperature)
+	fmt.Fprintf(&sb, "\n")
+	fmt.Fprintf(&sb, "Switch 1: %s 2: %s\n", switchState(s.switches[0]), switchState(s.switches[1]))
+	fmt.Fprintf(&sb, "\n")
+	fmt.Fprintf(&sb, "Total kW-h: %d\n", int(s.totalEnergy))
+	fmt.Fprintf(&sb, "\n")
+	fmt.Fprintf(&sb, "+---------------+-------------+-------------+\n")
+	fmt.Fprintf(&sb, "|               |  True RMS   |  Peak RMS   |\n")
+	fmt.Fprintf(&sb, "|    Breaker    |   Current   |   Current   |\n")
+	fmt.Fprintf(&sb, "+---------------+-------------+-------------+\n")
+
+	for b, name := range simBreakerNames {
+		fmt.Fprintf(&sb, "| %-13s | %6.1f Amps | %6.1f Amps |\n", name, s.breakerCurrent(b), s.breakerPeak[b])
+	}
+
+	fmt.Fprintf(&sb, "+---------------+-------------+-------------+-------------+-------------+-----------+\n")
+	fmt.Fprintf(&sb, "|               |  True RMS   |  Peak RMS   |  True RMS   |   Average   |   Volt-   |\n")
+	fmt.Fprintf(&sb, "|    Group      |   Current   |   Current   |   Voltage   |    Power    |   Amps    |\n")
+	fmt.Fprintf(&sb, "+---------------+-------------+-------------+-------------+-------------+-----------+\n")
+
+	for g, name := range simGroupNames {
+		cur := s.groupCurrent(g)
+		fmt.Fprintf(&sb, "| %-13s | %6.1f Amps | %6.1f Amps | %5.1f Volts | %5.0f Watts | %6.0f VA |\n",
+			name, cur, s.groupPeak[g], simVoltage, cur*simVoltage*simPowerFactor, cur*simVoltage)
+	}
+
+	fmt.Fprintf(&sb, "+---------------+-------------+-------------+-------------+-------------+-----------+")
+
+	return sb.String()
+}
+
+func (s *Simulator) ostatus() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "+----------------------+----------+----------+----------+---------+---------+------------+\n")
+	fmt.Fprintf(&sb, "|                      | True RMS | Peak RMS | True RMS | Average |  Volt-  |            |\n")
+	fmt.Fprintf(&sb, "|     Outlet Name      | Current  | Current  | Voltage  |  Power  |  Amps   |   State    |\n")
+	fmt.Fprintf(&sb, "+----------------------+----------+----------+----------+---------+---------+------------+\n")
+
+	for _, o := range s.outlets {
+		state := "Off"
+		if o.State {
+			state = "On"
+		}
+
+		if o.Locked {
+			state += " Locked"
+		}
+
+		fmt.Fprintf(&sb, "| %-20s | %6.1f A | %6.1f A | %6.1f V | %5.0f W | %4.0f VA | %-10s |\n",
+			o.Name, o.Current, o.Peak, simVoltage, o.Current*simVoltage*simPowerFactor, o.Current*simVoltage, state)
+	}
+
+	fmt.Fprintf(&sb, "+----------------------+----------+----------+----------+---------+---------+------------+")
+
+	return sb.String()
+}
+
+func switchState(closed bool) string {
+	if closed {
+		return "Closed"
+	}
+
+	return "Open"
+}
+
+func (ss *simSession) menuText() string {
+	var sb strings.Builder
+
+	switch ss.menu {
+	case simMenuConfig:
+		fmt.Fprintf(&sb, "\n   Configuration Menu\n\n")
+		fmt.Fprintf(&sb, "   %s)...Manage Users\n", menuUsers)
+
+	case simMenuUsers:
+		fmt.Fprintf(&sb, "\n   User Management Menu\n\n")
+		fmt.Fprintf(&sb, "   %s)...View Users\n", menuViewUsers)
+		fmt.Fprintf(&sb, "   %s)...Add User\n", menuAddUser)
+		fmt.Fprintf(&sb, "   %s)...Delete User\n", menuDeleteUser)
+		fmt.Fprintf(&sb, "   %s)...Change Password\n", menuChangePassword)
+		fmt.Fprintf(&sb, "   %s)...Assign Outlets\n", menuAssignOutlets)
+	}
+
+	fmt.Fprintf(&sb, "   %s)...Exit\n\n", menuExit)
+
+	return strings.ReplaceAll(sb.String(), "\n", "\r\n") + promptMenu
+}
+
+// ask shows a prompt and passes the reply to the callback.
+func (ss *simSession) ask(prompt string, secret bool, cb func(line string) string) string {
+	ss.input = cb
+	ss.secret = secret
+
+	return prompt
+}
+
+func (ss *simSession) handleInput(input func(string) string, line string) error {
+	echo := line
+	if ss.secret {
+		echo = ""
+	}
+
+	ss.input = nil
+
+	if err := ss.write("%s\r\r\n", echo); err != nil {
+		return err
+	}
+
+	out := input(line)
+
+	// Another prompt follows
+	if ss.input != nil {
+		return ss.write("%s", out)
+	}
+
+	if out != "" {
+		out = strings.ReplaceAll(out, "\n", "\r\n") + "\r\n"
+	}
+
+	return ss.write("%s%s", out, ss.menuText())
+}
+
+func (ss *simSession) handleMenu(line string) error {
+	if err := ss.write("%s\r\r\n", line); err != nil {
+		return err
+	}
+
+	key := strings.ToUpper(strings.TrimSpace(line))
+
+	// Escape and X leave the current menu
+	if key == menuExit || key == "\x1b" {
+		switch ss.menu {
+		case simMenuUsers:
+			ss.menu = simMenuConfig
+
+		case simMenuConfig:
+			ss.menu = simMenuNone
+
+			return ss.write("\r\n%s", promptReady)
+		}
+
+		return ss.write(ss.menuText())
+	}
+
+	out := ""
+
+	switch ss.menu {
+	case simMenuConfig:
+		switch key {
+		case "":
+		case menuUsers:
+			ss.menu = simMenuUsers
+		default:
+			out = "Input error"
+		}
+
+	case simMenuUsers:
+		switch key {
+		case "":
+		case menuViewUsers:
+			out = ss.sim.viewUsers()
+		case menuAddUser:
+			return ss.write(ss.ask(promptNewUsername, false, ss.addUser))
+		case menuDeleteUser:
+			return ss.write(ss.ask(promptDeleteUser, false, ss.deleteUser))
+		case menuChangePassword:
+			return ss.write(ss.ask(promptModifyUser, false, ss.changePassword))
+		case menuAssignOutlets:
+			return ss.write(ss.ask(promptModifyUser, false, ss.assignOutlets))
+		default:
+			out = "Input error"
+		}
+	}
+
+	if out != "" {
+		out = strings.ReplaceAll(out, "\n", "\r\n") + "\r\n"
+	}
+
+	return ss.write("%s%s", out, ss.menuText())
+}
+
+func (ss *simSession) addUser(name string) string {
+	s := ss.sim
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !reSimUsername.MatchString(name) {
+		return "Invalid user name"
+	} else if _, ok := s.Users[name]; ok {
+		return "User already exists"
+	}
+
+	return ss.ask(promptNewPassword, true, func(password string) string {
+		if password == "" {
+			return "Invalid password"
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.Users[name] = password
+		s.access[name] = nil
+
+		return "User added"
+	})
+}
+
+func (ss *simSession) deleteUser(name string) string {
+	s := ss.sim
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.Users[name]; !ok {
+		return msgUserNotFound
+	} else if name == ss.username {
+		return "Cannot delete current user"
+	}
+
+	delete(s.Users, name)
+	delete(s.access, name)
+
+	return "User deleted"
+}
+
+func (ss *simSession) changePassword(name string) string {
+	s := ss.sim
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.Users[name]; !ok {
+		return msgUserNotFound
+	}
+
+	return ss.ask(promptNewPassword, true, func(password string) string {
+		if password == "" {
+			return "Invalid password"
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.Users[name] = password
+
+		return "Password changed"
+	})
+}
+
+func (ss *simSession) assignOutlets(name string) string {
+	s := ss.sim
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.Users[name]; !ok {
+		return msgUserNotFound
+	}
+
+	return ss.ask(promptAssignOutlet, false, func(arg string) string {
+		ids := []int{}
+
+		if !strings.EqualFold(arg, "none") {
+			var err error
+			if ids, err = s.parseOutlets(arg); err != nil {
+				return "Invalid outlet list"
+			}
+		}
+
+		slices.Sort(ids)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.access[name] = slices.Compact(ids)
+
+		return "Outlets assigned"
+	})
+}
+
+// viewUsers lists all users and their assigned outlets. The caller must not hold s.mu.
+func (s *Simulator) viewUsers() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := []string{}
+	for name := range s.Users {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "+------------------+------------------------------------------------------------+\n")
+	fmt.Fprintf(&sb, "| User Name        | Outlets                                                    |\n")
+	fmt.Fprintf(&sb, "+------------------+------------------------------------------------------------+\n")
+
+	for _, name := range names {
+		outlets := "None"
+		if ids := s.access[name]; len(ids) > 0 {
+			strs := []string{}
+			for _, id := range ids {
+				strs = append(strs, strconv.Itoa(id))
+			}
+
+			outlets = strings.Join(strs, ",")
+		}
+
+		fmt.Fprintf(&sb, "| %-16s | %-58s |\n", name, outlets)
+	}
+
+	fmt.Fprintf(&sb, "+------------------+------------------------------------------------------------+")
+
+	return sb.String()
+}
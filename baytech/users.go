@@ -0,0 +1,262 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package baytech
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pdu "github.com/stv0g/pductl"
+)
+
+const (
+	promptMenu         = "Enter request, <ESC> to exit menu: "
+	promptNewUsername  = "Enter new user name: "
+	promptNewPassword  = "Enter new password: "
+	promptDeleteUser   = "Enter user name to delete: "
+	promptModifyUser   = "Enter user name to modify: "
+	promptAssignOutlet = "Enter outlets: "
+
+	// Keys of the configuration menu
+	menuUsers          = "1"
+	menuViewUsers      = "1"
+	menuAddUser        = "2"
+	menuDeleteUser     = "3"
+	menuChangePassword = "4"
+	menuAssignOutlets  = "5"
+	menuExit           = "X"
+
+	msgUserNotFound = "User not found"
+)
+
+var (
+	reUser      = regexp.MustCompile(`(?m)^\|\s*(` + usernamePattern + `)\s*\|\s*([0-9,]+|None)\s*\|\s*$`)
+	reMenuError = regexp.MustCompile(`(?m)^(User not found|User already exists|Invalid [A-Za-z ]+|Cannot [A-Za-z ]+|Input error)\s*$`)
+)
+
+// dialogStep is the reply to an expected prompt of the configuration menu.
+type dialogStep struct {
+	prompt string
+	reply  string
+}
+
+func (p *PDU) Users() ([]pdu.User, error) {
+	return p.UsersContext(context.Background())
+}
+
+func (p *PDU) UsersContext(ctx context.Context) ([]pdu.User, error) {
+	out, err := p.configure(ctx,
+		dialogStep{promptMenu, menuUsers},
+		dialogStep{promptMenu, menuViewUsers},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	users := []pdu.User{}
+
+	for _, m := range reUser.FindAllStringSubmatch(out, -1) {
+		user := pdu.User{
+			Name:    m[1],
+			Outlets: []int{},
+		}
+
+		if m[2] != "None" {
+			for _, id := range strings.Split(m[2], ",") {
+				i, err := strconv.Atoi(id)
+				if err != nil {
+					return nil, fmt.Errorf("%w user outlets: %w", ErrDecode, err)
+				}
+
+				user.Outlets = append(user.Outlets, i)
+			}
+		}
+
+		users = append(users, user)
+	}
+
+	if len(users) == 0 {
+		return nil, fmt.Errorf("%w: users", ErrDecode)
+	}
+
+	return users, nil
+}
+
+func (p *PDU) AddUser(name, password string) error {
+	return p.AddUserContext(context.Background(), name, password)
+}
+
+func (p *PDU) AddUserContext(ctx context.Context, name, password string) error {
+	_, err := p.configure(ctx,
+		dialogStep{promptMenu, menuUsers},
+		dialogStep{promptMenu, menuAddUser},
+		dialogStep{promptNewUsername, name},
+		dialogStep{promptNewPassword, password},
+	)
+
+	return err
+}
+
+func (p *PDU) DeleteUser(name string) error {
+	return p.DeleteUserContext(context.Background(), name)
+}
+
+func (p *PDU) DeleteUserContext(ctx context.Context, name string) error {
+	_, err := p.configure(ctx,
+		dialogStep{promptMenu, menuUsers},
+		dialogStep{promptMenu, menuDeleteUser},
+		dialogStep{promptDeleteUser, name},
+	)
+
+	return err
+}
+
+func (p *PDU) ChangePassword(name, password string) error {
+	return p.ChangePasswordContext(context.Background(), name, password)
+}
+
+func (p *PDU) ChangePasswordContext(ctx context.Context, name, password string) error {
+	_, err := p.configure(ctx,
+		dialogStep{promptMenu, menuUsers},
+		dialogStep{promptMenu, menuChangePassword},
+		dialogStep{promptModifyUser, name},
+		dialogStep{promptNewPassword, password},
+	)
+
+	return err
+}
+
+// SetUserOutlets sets the outlets which a user is allowed to control.
+// The outlets are selected by an outlet expression or "none".
+func (p *PDU) SetUserOutlets(name, id string) error {
+	return p.SetUserOutletsContext(context.Background(), name, id)
+}
+
+func (p *PDU) SetUserOutletsContext(ctx context.Context, name, id string) error {
+	reply := "None"
+
+	if !strings.EqualFold(id, pdu.None) {
+		outlets, err := p.lookupOutlets(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		ids := []string{}
+		for _, o := range outlets {
+			ids = append(ids, fmt.Sprint(o.ID))
+		}
+
+		reply = strings.Join(ids, ",")
+	}
+
+	_, err := p.configure(ctx,
+		dialogStep{promptMenu, menuUsers},
+		dialogStep{promptMenu, menuAssignOutlets},
+		dialogStep{promptModifyUser, name},
+		dialogStep{promptAssignOutlet, reply},
+	)
+
+	return err
+}
+
+// configure enters the configuration menu and replies to the expected prompts one after another.
+// It always leaves the menu again and returns the output following the last reply.
+// A menu prompt where another prompt was expected signals that the PDU rejected the previous reply.
+//
+// The prompts and menu keys follow pdusim and have not been verified against an MMP-14 firmware yet.
+// The received output is logged at debug level to record the dialogue of a real PDU.
+func (p *PDU) configure(ctx context.Context, steps ...dialogStep) (string, error) {
+	str := ""
+	out := ""
+	transcript := ""
+	step := -1 // Waiting for the command prompt to enter the menu
+	exiting := false
+	started := time.Now()
+
+	var errMenu error
+
+	_, err := p.communicate(ctx, func(buf string) (bool, string, error) {
+		str += buf
+		transcript += buf
+
+		switch {
+		case strings.HasSuffix(str, promptReady):
+			if step < 0 {
+				if err := p.send("Config"); err != nil {
+					return false, "", err
+				}
+
+				step = 0
+				str = ""
+
+				return false, "", nil
+			}
+
+			return true, "", nil
+
+		case step < 0 && (strings.HasSuffix(str, promptUsername) || strings.HasSuffix(str, promptPassword)):
+			return false, "", pdu.ErrLoginRequired
+
+		case !exiting && step < len(steps) && strings.HasSuffix(str, steps[step].prompt):
+			if err := p.send(steps[step].reply); err != nil {
+				return false, "", err
+			}
+
+			step++
+			str = ""
+
+		case strings.HasSuffix(str, promptMenu):
+			if !exiting {
+				out = str
+				errMenu = menuError(str, step < len(steps))
+				exiting = true
+			}
+
+			if err := p.send(menuExit); err != nil {
+				return false, "", err
+			}
+
+			str = ""
+		}
+
+		return false, "", nil
+	})
+
+	// Passwords are not echoed by the PDU but are redacted in case they are
+	for _, s := range steps {
+		if s.prompt == promptNewPassword && s.reply != "" {
+			transcript = strings.ReplaceAll(transcript, s.reply, "********")
+		}
+	}
+
+	slog.Debug("Configured PDU", slog.Int("steps", len(steps)), slog.Duration("took", time.Since(started)), slog.Any("error", err), slog.Any("menu_error", errMenu), slog.String("transcript", transcript))
+
+	if err != nil {
+		return "", err
+	}
+
+	return out, errMenu
+}
+
+// menuError extracts an error message from the output of the configuration menu.
+func menuError(out string, incomplete bool) error {
+	m := reMenuError.FindStringSubmatch(out)
+	switch {
+	case m != nil && m[1] == msgUserNotFound:
+		return pdu.ErrUserNotFound
+
+	case m != nil:
+		return fmt.Errorf("%w: %s", pdu.ErrRejected, m[1])
+
+	case incomplete:
+		return fmt.Errorf("%w: unexpected menu prompt", pdu.ErrRejected)
+	}
+
+	return nil
+}
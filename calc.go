@@ -3,8 +3,22 @@
 
 package pductl
 
-func CalcEnergy(prevSts, newSts *Status) {
-	deltaT := float32(newSts.Timestamp.Sub(prevSts.Timestamp).Hours())
+import (
+	"log/slog"
+	"time"
+)
+
+// CalcEnergy integrates the energy of groups and outlets between two status updates.
+// The energy is carried over without integration if the clock jumped backwards
+// or the time between both updates exceeds maxGap, e.g. after a lost connection.
+func CalcEnergy(prevSts, newSts *Status, maxGap time.Duration) {
+	gap := newSts.Timestamp.Sub(prevSts.Timestamp)
+	if gap < 0 || gap > maxGap {
+		slog.Warn("Skipping energy integration", slog.Duration("gap", gap), slog.Duration("max_gap", maxGap))
+		gap = 0
+	}
+
+	deltaT := float32(gap.Hours())
 
 	for i := range newSts.Groups {
 		prevGroup := prevSts.Groups[i]
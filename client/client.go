@@ -4,153 +4,576 @@
 package client
 
 import (
+	"bufio"
+	"bytes"
 	"context"
-	"errors"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	pdu "github.com/stv0g/pductl"
 	"github.com/stv0g/pductl/internal/api"
 )
 
-var _ pdu.PDU = (*Client)(nil)
+var (
+	_ pdu.PDU            = (*Client)(nil)
+	_ pdu.EventSource    = (*Client)(nil)
+	_ pdu.HistoryPDU     = (*Client)(nil)
+	_ pdu.SchedulePDU    = (*Client)(nil)
+	_ pdu.SequencePDU    = (*Client)(nil)
+	_ pdu.AlertPDU       = (*Client)(nil)
+	_ pdu.AuditPDU       = (*Client)(nil)
+	_ pdu.DiagnosticsPDU = (*Client)(nil)
+	_ pdu.ContextPDU     = (*Client)(nil)
+)
+
+const maxEventSize = 1 << 20
 
 type Client struct {
 	client *api.ClientWithResponses
-	ctx    context.Context
 }
 
-func NewPDU(address string, opts ...api.ClientOption) (c *Client, err error) {
+// NewPDU returns a client for the REST API of pdud.
+// The name selects one of multiple PDUs managed by pdud.
+// The first PDU is used if the name is empty.
+func NewPDU(address, name string, opts ...api.ClientOption) (c *Client, err error) {
 	c = &Client{}
 
-	if c.client, err = api.NewClientWithResponses(address+"/api/v1", opts...); err != nil {
-		return nil, err
+	baseURL := address + "/api/v1"
+	if name != "" {
+		baseURL += "/pdus/" + url.PathEscape(name)
 	}
 
-	c.ctx = context.Background()
+	if c.client, err = api.NewClientWithResponses(baseURL, opts...); err != nil {
+		return nil, err
+	}
 
 	return c, nil
 }
 
+// responseError reconstructs the error of an unsuccessful response.
+func responseError(r *http.Response, body []byte) error {
+	if r.StatusCode < 300 {
+		return nil
+	}
+
+	var p api.Error
+	if err := json.Unmarshal(body, &p); err != nil || (p.Message == "" && p.Error == "") {
+		return fmt.Errorf("unexpected response: %s", r.Status)
+	}
+
+	return pdu.ErrorFromAPI(r.StatusCode, &p)
+}
+
 func (c *Client) Close() error {
 	return nil
 }
 
-func (c *Client) SwitchOutlet(id string, state bool) (err error) {
-	r, err := c.client.SwitchOutletWithResponse(c.ctx, id, state)
+func (c *Client) SwitchOutlet(id string, state bool) ([]pdu.OutletResult, error) {
+	return c.SwitchOutletContext(context.Background(), id, state)
+}
+
+func (c *Client) SwitchOutletContext(ctx context.Context, id string, state bool) ([]pdu.OutletResult, error) {
+	r, err := c.client.SwitchOutletWithResponse(ctx, id, state)
 	if err != nil {
-		return err
-	} else if p := r.JSON400; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON401; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON403; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON404; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON500; p != nil {
-		return errors.New(p.Error)
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
 	}
 
-	return nil
+	return *r.JSON200, pdu.OutletResultsError(*r.JSON200)
+}
+
+func (c *Client) LockOutlet(id string, state bool) ([]pdu.OutletResult, error) {
+	return c.LockOutletContext(context.Background(), id, state)
 }
 
-func (c *Client) LockOutlet(id string, state bool) (err error) {
-	r, err := c.client.LockOutletWithResponse(c.ctx, id, state)
+func (c *Client) LockOutletContext(ctx context.Context, id string, state bool) ([]pdu.OutletResult, error) {
+	r, err := c.client.LockOutletWithResponse(ctx, id, state)
 	if err != nil {
-		return err
-	} else if p := r.JSON400; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON401; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON403; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON404; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON500; p != nil {
-		return errors.New(p.Error)
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
 	}
 
-	return nil
+	return *r.JSON200, pdu.OutletResultsError(*r.JSON200)
 }
 
-func (c *Client) RebootOutlet(id string) error {
-	r, err := c.client.RebootOutletWithResponse(c.ctx, id)
+func (c *Client) RebootOutlet(id string) ([]pdu.OutletResult, error) {
+	return c.RebootOutletContext(context.Background(), id)
+}
+
+func (c *Client) RebootOutletContext(ctx context.Context, id string) ([]pdu.OutletResult, error) {
+	r, err := c.client.RebootOutletWithResponse(ctx, id)
 	if err != nil {
-		return err
-	} else if p := r.JSON400; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON401; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON403; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON404; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON500; p != nil {
-		return errors.New(p.Error)
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
 	}
 
-	return nil
+	return *r.JSON200, pdu.OutletResultsError(*r.JSON200)
 }
 
 func (c *Client) Status(detailed bool) (*pdu.Status, error) {
-	r, err := c.client.StatusWithResponse(c.ctx, &api.StatusParams{
+	return c.StatusContext(context.Background(), detailed)
+}
+
+func (c *Client) StatusContext(ctx context.Context, detailed bool) (*pdu.Status, error) {
+	r, err := c.client.StatusWithResponse(ctx, &api.StatusParams{
 		Detailed: &detailed,
 	})
 	if err != nil {
 		return nil, err
-	} else if p := r.JSON401; p != nil {
-		return nil, errors.New(p.Error)
-	} else if p := r.JSON403; p != nil {
-		return nil, errors.New(p.Error)
-	} else if p := r.JSON500; p != nil {
-		return nil, errors.New(p.Error)
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
 	}
 
 	return r.JSON200, nil
 }
 
+func (c *Client) StatusOutlets(id string) ([]pdu.OutletStatus, error) {
+	return c.StatusOutletsContext(context.Background(), id)
+}
+
+func (c *Client) StatusOutletsContext(ctx context.Context, id string) ([]pdu.OutletStatus, error) {
+	r, err := c.client.StatusOutletWithResponse(ctx, id)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return *r.JSON200, nil
+}
+
 func (c *Client) ClearMaximumCurrents() error {
-	r, err := c.client.ClearMaximumCurrentsWithResponse(c.ctx)
+	return c.ClearMaximumCurrentsContext(context.Background())
+}
+
+func (c *Client) ClearMaximumCurrentsContext(ctx context.Context) error {
+	r, err := c.client.ClearMaximumCurrentsWithResponse(ctx)
 	if err != nil {
 		return err
-	} else if p := r.JSON400; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON401; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON403; p != nil {
-		return errors.New(p.Error)
-	} else if p := r.JSON500; p != nil {
-		return errors.New(p.Error)
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return err
 	}
 
 	return nil
 }
 
 func (c *Client) Temperature() (float64, error) {
-	r, err := c.client.TemperatureWithResponse(c.ctx)
+	return c.TemperatureContext(context.Background())
+}
+
+func (c *Client) TemperatureContext(ctx context.Context) (float64, error) {
+	r, err := c.client.TemperatureWithResponse(ctx)
 	if err != nil {
 		return -1, err
-	} else if p := r.JSON401; p != nil {
-		return -1, errors.New(p.Error)
-	} else if p := r.JSON403; p != nil {
-		return -1, errors.New(p.Error)
-	} else if p := r.JSON500; p != nil {
-		return -1, errors.New(p.Error)
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return -1, err
+	} else if r.JSON200 == nil {
+		return -1, fmt.Errorf("unexpected response: %s", r.Status())
 	}
 
 	return float64(r.JSON200.Temperature), nil
 }
 
+func (c *Client) Diagnostics() (*pdu.Diagnostics, error) {
+	return c.DiagnosticsContext(context.Background())
+}
+
+func (c *Client) DiagnosticsContext(ctx context.Context) (*pdu.Diagnostics, error) {
+	r, err := c.client.DiagnosticsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return r.JSON200, nil
+}
+
 func (c *Client) WhoAmI() (string, error) {
-	r, err := c.client.WhoAmIWithResponse(c.ctx)
+	return c.WhoAmIContext(context.Background())
+}
+
+func (c *Client) WhoAmIContext(ctx context.Context) (string, error) {
+	r, err := c.client.WhoAmIWithResponse(ctx)
 	if err != nil {
 		return "", err
-	} else if p := r.JSON401; p != nil {
-		return "", errors.New(p.Error)
-	} else if p := r.JSON403; p != nil {
-		return "", errors.New(p.Error)
-	} else if p := r.JSON500; p != nil {
-		return "", errors.New(p.Error)
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return "", err
+	} else if r.JSON200 == nil {
+		return "", fmt.Errorf("unexpected response: %s", r.Status())
 	}
 
 	return r.JSON200.Username, nil
 }
+
+func (c *Client) Users() ([]pdu.User, error) {
+	return c.UsersContext(context.Background())
+}
+
+func (c *Client) UsersContext(ctx context.Context) ([]pdu.User, error) {
+	r, err := c.client.ListUsersWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return *r.JSON200, nil
+}
+
+func (c *Client) AddUser(name, password string) error {
+	return c.AddUserContext(context.Background(), name, password)
+}
+
+func (c *Client) AddUserContext(ctx context.Context, name, password string) error {
+	r, err := c.client.AddUserWithResponse(ctx, api.NewUser{
+		Name:     name,
+		Password: password,
+	})
+	if err != nil {
+		return err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) DeleteUser(name string) error {
+	return c.DeleteUserContext(context.Background(), name)
+}
+
+func (c *Client) DeleteUserContext(ctx context.Context, name string) error {
+	r, err := c.client.DeleteUserWithResponse(ctx, name)
+	if err != nil {
+		return err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) ChangePassword(name, password string) error {
+	return c.ChangePasswordContext(context.Background(), name, password)
+}
+
+func (c *Client) ChangePasswordContext(ctx context.Context, name, password string) error {
+	r, err := c.client.ChangePasswordWithResponse(ctx, name, password)
+	if err != nil {
+		return err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) SetUserOutlets(name, id string) error {
+	return c.SetUserOutletsContext(context.Background(), name, id)
+}
+
+func (c *Client) SetUserOutletsContext(ctx context.Context, name, id string) error {
+	r, err := c.client.SetUserOutletsWithResponse(ctx, name, id)
+	if err != nil {
+		return err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// History returns past status samples retained by the server.
+func (c *Client) History(from, to time.Time, step time.Duration, id string) ([]pdu.Status, error) {
+	return c.HistoryContext(context.Background(), from, to, step, id)
+}
+
+func (c *Client) HistoryContext(ctx context.Context, from, to time.Time, step time.Duration, id string) ([]pdu.Status, error) {
+	params := &api.HistoryParams{
+		From: &from,
+		To:   &to,
+	}
+
+	if step > 0 {
+		s := step.String()
+		params.Step = &s
+	}
+
+	if id != "" {
+		params.Outlet = &id
+	}
+
+	r, err := c.client.HistoryWithResponse(ctx, params)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return *r.JSON200, nil
+}
+
+// Audit returns the recorded state-changing operations between from and to.
+func (c *Client) Audit(from, to time.Time, id, operation, client string, limit int) ([]pdu.AuditEntry, error) {
+	return c.AuditContext(context.Background(), from, to, id, operation, client, limit)
+}
+
+func (c *Client) AuditContext(ctx context.Context, from, to time.Time, id, operation, client string, limit int) ([]pdu.AuditEntry, error) {
+	params := &api.AuditParams{
+		From: &from,
+		To:   &to,
+	}
+
+	if id != "" {
+		params.Outlet = &id
+	}
+
+	if operation != "" {
+		params.Operation = &operation
+	}
+
+	if client != "" {
+		params.Client = &client
+	}
+
+	if limit > 0 {
+		params.Limit = &limit
+	}
+
+	r, err := c.client.AuditWithResponse(ctx, params)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return *r.JSON200, nil
+}
+
+// Schedules returns the scheduled outlet actions of the server.
+func (c *Client) Schedules() ([]pdu.Schedule, error) {
+	return c.SchedulesContext(context.Background())
+}
+
+func (c *Client) SchedulesContext(ctx context.Context) ([]pdu.Schedule, error) {
+	r, err := c.client.ListSchedulesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return *r.JSON200, nil
+}
+
+// AddSchedule adds a scheduled outlet action to the server.
+func (c *Client) AddSchedule(s pdu.NewSchedule) (*pdu.Schedule, error) {
+	return c.AddScheduleContext(context.Background(), s)
+}
+
+func (c *Client) AddScheduleContext(ctx context.Context, s pdu.NewSchedule) (*pdu.Schedule, error) {
+	r, err := c.client.AddScheduleWithResponse(ctx, s)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return r.JSON200, nil
+}
+
+// DeleteSchedule removes a scheduled outlet action from the server.
+func (c *Client) DeleteSchedule(id string) error {
+	return c.DeleteScheduleContext(context.Background(), id)
+}
+
+func (c *Client) DeleteScheduleContext(ctx context.Context, id string) error {
+	r, err := c.client.DeleteScheduleWithResponse(ctx, id)
+	if err != nil {
+		return err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ScheduleRuns returns the recent runs of scheduled outlet actions.
+func (c *Client) ScheduleRuns() ([]pdu.ScheduleRun, error) {
+	return c.ScheduleRunsContext(context.Background())
+}
+
+func (c *Client) ScheduleRunsContext(ctx context.Context) ([]pdu.ScheduleRun, error) {
+	r, err := c.client.ListScheduleRunsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return *r.JSON200, nil
+}
+
+// Sequences returns the power sequences of the server.
+func (c *Client) Sequences() ([]pdu.Sequence, error) {
+	return c.SequencesContext(context.Background())
+}
+
+func (c *Client) SequencesContext(ctx context.Context) ([]pdu.Sequence, error) {
+	r, err := c.client.ListSequencesWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return *r.JSON200, nil
+}
+
+// Sequence returns a power sequence and the progress of its last run.
+func (c *Client) Sequence(name string) (*pdu.Sequence, error) {
+	return c.SequenceContext(context.Background(), name)
+}
+
+func (c *Client) SequenceContext(ctx context.Context, name string) (*pdu.Sequence, error) {
+	r, err := c.client.GetSequenceWithResponse(ctx, name)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return r.JSON200, nil
+}
+
+// RunSequence starts a power sequence on the server.
+func (c *Client) RunSequence(name string, state bool) (*pdu.SequenceRun, error) {
+	return c.RunSequenceContext(context.Background(), name, state)
+}
+
+func (c *Client) RunSequenceContext(ctx context.Context, name string, state bool) (*pdu.SequenceRun, error) {
+	r, err := c.client.RunSequenceWithResponse(ctx, name, state)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return r.JSON200, nil
+}
+
+// Events subscribes to the event stream of the server and
+// invokes the callback for each received event until the context is cancelled.
+func (c *Client) Events(ctx context.Context, cb func(*pdu.Event) error) error {
+	r, err := c.client.Events(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return err
+		}
+
+		return responseError(r, body)
+	}
+
+	scanner := bufio.NewScanner(r.Body)
+	scanner.Buffer(nil, maxEventSize)
+
+	data := bytes.Buffer{}
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if data.Len() == 0 {
+				continue
+			}
+
+			e := &pdu.Event{}
+			if err := json.Unmarshal(data.Bytes(), e); err != nil {
+				return fmt.Errorf("failed to decode event: %w", err)
+			}
+
+			data.Reset()
+
+			if err := cb(e); err != nil {
+				return err
+			}
+
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+
+	if err := scanner.Err(); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return nil
+}
+
+// Alerts returns the active alerts of the server.
+func (c *Client) Alerts() ([]pdu.Alert, error) {
+	return c.AlertsContext(context.Background())
+}
+
+func (c *Client) AlertsContext(ctx context.Context) ([]pdu.Alert, error) {
+	r, err := c.client.ListAlertsWithResponse(ctx)
+	if err != nil {
+		return nil, err
+	} else if err := responseError(r.HTTPResponse, r.Body); err != nil {
+		return nil, err
+	} else if r.JSON200 == nil {
+		return nil, fmt.Errorf("unexpected response: %s", r.Status())
+	}
+
+	return *r.JSON200, nil
+}
@@ -0,0 +1,465 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+	pdu "github.com/stv0g/pductl"
+)
+
+// Exit codes of fence agents
+const (
+	fenceSuccess   = 0
+	fenceFailure   = 1
+	fenceStatusOff = 2
+)
+
+const fenceAgentName = "fence_pductl"
+
+var (
+	errMissingPlug       = errors.New("missing plug")
+	errUnknownAction     = errors.New("unknown action")
+	errUnknownMethod     = errors.New("unknown method")
+	errFenceTimeout      = errors.New("timeout waiting for outlet state")
+	errInvalidFenceInput = errors.New("invalid line")
+)
+
+// fenceExitCode is returned by the fence command to terminate pductl with the exit code expected by the fence daemon.
+type fenceExitCode int
+
+func (c fenceExitCode) Error() string {
+	return fmt.Sprintf("fence agent exited with code %d", int(c))
+}
+
+// fenceParameter maps an option of the fence agent protocol to a flag of pductl.
+type fenceParameter struct {
+	Name        string
+	Obsoletes   string
+	Flag        string
+	Type        string
+	Required    bool
+	Description string
+}
+
+var fenceParameters = []fenceParameter{
+	{"action", "option", "action", "string", true, "Fencing action"},
+	{"plug", "port", "plug", "string", true, "Outlets to fence (IDs, ranges, names or aliases)"},
+	{"ip", "ipaddr", "address", "string", false, "Address of pdud (http://, https://) or the PDU (tcp://, serial path)"},
+	{"username", "login", "username", "string", false, "Login name of the PDU"},
+	{"password", "passwd", "password", "string", false, "Login password of the PDU"},
+	{"pdu", "", "pdu", "string", false, "Name of the PDU if multiple PDUs are configured"},
+	{"config", "", "config", "string", false, "Path to YAML-formatted configuration file"},
+	{"tls_cacert", "", "tls-cacert", "string", false, "Certificate Authority to validate the server certificate against"},
+	{"tls_cert", "", "tls-cert", "string", false, "Client certificate"},
+	{"tls_key", "", "tls-key", "string", false, "Client key"},
+	{"tls_insecure", "", "tls-insecure", "boolean", false, "Skip verification of server certificate"},
+	{"method", "", "method", "select", false, "Method to fence (onoff or cycle)"},
+	{"power_timeout", "", "power-timeout", "second", false, "Time to wait for outlets to change their state"},
+}
+
+var fenceActions = []string{"on", "off", "reboot", "status", "list", "list-status", "monitor", "metadata", "validate-all"}
+
+type fenceMetadata struct {
+	XMLName    xml.Name                 `xml:"resource-agent"`
+	Name       string                   `xml:"name,attr"`
+	ShortDesc  string                   `xml:"shortdesc,attr"`
+	LongDesc   string                   `xml:"longdesc"`
+	VendorURL  string                   `xml:"vendor-url"`
+	Parameters []fenceMetadataParameter `xml:"parameters>parameter"`
+	Actions    []fenceMetadataAction    `xml:"actions>action"`
+}
+
+type fenceMetadataParameter struct {
+	Name       string `xml:"name,attr"`
+	Unique     string `xml:"unique,attr"`
+	Required   string `xml:"required,attr"`
+	Obsoletes  string `xml:"obsoletes,attr,omitempty"`
+	Deprecated string `xml:"deprecated,attr,omitempty"`
+	Getopt     struct {
+		Mixed string `xml:"mixed,attr"`
+	} `xml:"getopt"`
+	Content struct {
+		Type    string `xml:"type,attr"`
+		Default string `xml:"default,attr,omitempty"`
+		Options []struct {
+			Value string `xml:"value,attr"`
+		} `xml:"option,omitempty"`
+	} `xml:"content"`
+	ShortDesc struct {
+		Lang string `xml:"lang,attr"`
+		Text string `xml:",chardata"`
+	} `xml:"shortdesc"`
+}
+
+type fenceMetadataAction struct {
+	Name      string `xml:"name,attr"`
+	Automatic string `xml:"automatic,attr,omitempty"`
+}
+
+func fence(cmd *cobra.Command, _ []string) error {
+	code, err := runFence(cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed: %s\n", err)
+	}
+
+	if code != fenceSuccess {
+		return fenceExitCode(code)
+	}
+
+	return nil
+}
+
+func runFence(cmd *cobra.Command) (int, error) {
+	// The fence daemon passes all options via stdin
+	if cmd.Flags().NFlag() == 0 && !isTerminal(os.Stdin) {
+		if err := parseFenceOptions(cmd, os.Stdin); err != nil {
+			return fenceFailure, err
+		}
+	}
+
+	if err := validateFenceOptions(); err != nil {
+		return fenceFailure, err
+	}
+
+	switch fenceAction {
+	case "metadata":
+		return fenceSuccess, printFenceMetadata(cmd, os.Stdout)
+
+	case "validate-all":
+		if _, err := pdu.ParseConfig(rootCmd.PersistentFlags()); err != nil {
+			return fenceFailure, err
+		}
+
+		return fenceSuccess, nil
+	}
+
+	if err := preRun(cmd, nil); err != nil {
+		return fenceFailure, err
+	}
+
+	defer func() {
+		if err := postRun(cmd, nil); err != nil {
+			slog.Error("Failed to close PDU", slog.Any("error", err))
+		}
+	}()
+
+	switch fenceAction {
+	case "monitor":
+		if _, err := cp.StatusContext(ctx, false); err != nil {
+			return fenceFailure, err
+		}
+
+		return fenceSuccess, nil
+
+	case "list", "list-status":
+		sts, err := cp.StatusContext(ctx, true)
+		if err != nil {
+			return fenceFailure, err
+		}
+
+		for _, o := range sts.Outlets {
+			if fenceAction == "list" {
+				fmt.Printf("%d,%s\n", o.ID, o.Name)
+			} else {
+				fmt.Printf("%d,%s,%s\n", o.ID, o.Name, strings.ToUpper(onOff(o.State)))
+			}
+		}
+
+		return fenceSuccess, nil
+	}
+
+	if fencePlug == "" {
+		return fenceFailure, errMissingPlug
+	}
+
+	id, err := pdu.ExpandAliases(fencePlug, aliases())
+	if err != nil {
+		return fenceFailure, err
+	}
+
+	switch fenceAction {
+	case "status":
+		state, err := fenceStatus(id)
+		if err != nil {
+			return fenceFailure, err
+		}
+
+		fmt.Printf("Status: %s\n", strings.ToUpper(onOff(state)))
+
+		if !state {
+			return fenceStatusOff, nil
+		}
+
+		return fenceSuccess, nil
+
+	case "on", "off":
+		if err := fenceSwitch(id, fenceAction == "on"); err != nil {
+			return fenceFailure, err
+		}
+
+		fmt.Printf("Success: Powered %s\n", strings.ToUpper(fenceAction))
+
+		return fenceSuccess, nil
+
+	case "reboot":
+		if err := fenceReboot(id); err != nil {
+			return fenceFailure, err
+		}
+
+		fmt.Println("Success: Rebooted")
+
+		return fenceSuccess, nil
+	}
+
+	return fenceFailure, nil
+}
+
+// parseFenceOptions sets the flags of the command from "name=value" lines.
+func parseFenceOptions(cmd *cobra.Command, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("%w: %s", errInvalidFenceInput, line)
+		}
+
+		prm := lookupFenceParameter(name)
+		if prm == nil {
+			// The fence daemon passes additional options like nodename
+			slog.Debug("Ignoring unknown fence option", slog.String("name", name))
+			continue
+		}
+
+		if prm.Type == "boolean" {
+			state, err := parseState(value)
+			if err != nil {
+				return err
+			}
+
+			value = strconv.FormatBool(state)
+		}
+
+		if err := cmd.Flags().Set(prm.Flag, value); err != nil {
+			return fmt.Errorf("invalid value for %s: %w", name, err)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func lookupFenceParameter(name string) *fenceParameter {
+	for i, prm := range fenceParameters {
+		if prm.Name == name || (prm.Obsoletes != "" && prm.Obsoletes == name) {
+			return &fenceParameters[i]
+		}
+	}
+
+	return nil
+}
+
+func validateFenceOptions() error {
+	if !slices.Contains(fenceActions, fenceAction) {
+		return fmt.Errorf("%w: %s", errUnknownAction, fenceAction)
+	}
+
+	if fenceMethod != "onoff" && fenceMethod != "cycle" {
+		return fmt.Errorf("%w: %s", errUnknownMethod, fenceMethod)
+	}
+
+	return nil
+}
+
+// fenceStatus returns true if any of the outlets is switched on.
+func fenceStatus(id string) (bool, error) {
+	outlets, err := cp.StatusOutletsContext(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	for _, o := range outlets {
+		if o.State {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func fenceSwitch(id string, state bool) error {
+	if _, err := cp.SwitchOutletContext(ctx, id, state); err != nil {
+		return err
+	}
+
+	return waitOutlets(id, state)
+}
+
+func fenceReboot(id string) error {
+	if fenceMethod == "cycle" {
+		_, err := cp.RebootOutletContext(ctx, id)
+		return err
+	}
+
+	if err := fenceSwitch(id, false); err != nil {
+		return err
+	}
+
+	// The node has been fenced successfully even if it does not power on again
+	if err := fenceSwitch(id, true); err != nil {
+		slog.Error("Failed to power on outlets", slog.Any("error", err))
+	}
+
+	return nil
+}
+
+// waitOutlets waits until all outlets have reached the state.
+func waitOutlets(id string, state bool) error {
+	deadline := time.Now().Add(time.Duration(fencePowerTimeout) * time.Second)
+
+	for {
+		outlets, err := cp.StatusOutletsContext(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		done := true
+		for _, o := range outlets {
+			if o.State != state {
+				done = false
+			}
+		}
+
+		if done {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: %s", errFenceTimeout, onOff(state))
+		}
+
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func printFenceMetadata(cmd *cobra.Command, w io.Writer) error {
+	md := fenceMetadata{
+		Name:      fenceAgentName,
+		ShortDesc: "Fence agent for Baytech PDUs",
+		LongDesc: "fence_pductl switches outlets of Baytech PDUs either via the REST API of pdud " +
+			"or directly via the serial console of the PDU.",
+		VendorURL: "https://github.com/stv0g/pductl",
+	}
+
+	for _, prm := range fenceParameters {
+		md.Parameters = append(md.Parameters, prm.metadata(cmd.Flags().Lookup(prm.Flag), prm.Name, false))
+
+		if prm.Obsoletes != "" {
+			md.Parameters = append(md.Parameters, prm.metadata(cmd.Flags().Lookup(prm.Flag), prm.Obsoletes, true))
+		}
+	}
+
+	for _, action := range fenceActions {
+		a := fenceMetadataAction{
+			Name: action,
+		}
+
+		if action == "on" {
+			a.Automatic = "0"
+		}
+
+		md.Actions = append(md.Actions, a)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "\t")
+
+	if err := enc.Encode(md); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func (prm fenceParameter) metadata(f *flag.Flag, name string, deprecated bool) fenceMetadataParameter {
+	m := fenceMetadataParameter{
+		Name:     name,
+		Unique:   "0",
+		Required: "0",
+	}
+
+	if deprecated {
+		m.Deprecated = "1"
+	} else {
+		m.Obsoletes = prm.Obsoletes
+
+		if prm.Required {
+			m.Required = "1"
+		}
+	}
+
+	m.Getopt.Mixed = "--" + f.Name
+	if f.Shorthand != "" {
+		m.Getopt.Mixed = "-" + f.Shorthand + ", " + m.Getopt.Mixed
+	}
+
+	if f.Value.Type() != "bool" {
+		m.Getopt.Mixed += "=[" + prm.Name + "]"
+	}
+
+	m.Content.Type = prm.Type
+	m.Content.Default = f.DefValue
+
+	switch prm.Type {
+	case "boolean":
+		m.Content.Default = ""
+	case "select":
+		for _, v := range []string{"onoff", "cycle"} {
+			m.Content.Options = append(m.Content.Options, struct {
+				Value string `xml:"value,attr"`
+			}{v})
+		}
+	}
+
+	m.ShortDesc.Lang = "en"
+	m.ShortDesc.Text = prm.Description
+
+	return m
+}
+
+func isTerminal(f *os.File) bool {
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
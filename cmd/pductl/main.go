@@ -4,14 +4,23 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
-	"strconv"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/cobra/doc"
@@ -21,12 +30,50 @@ import (
 	"github.com/stv0g/pductl/internal/api"
 )
 
+const sequenceWatchInterval = time.Second
+
+const outletsHelp = `OUTLETS is a comma-separated list of outlet IDs, ranges (e.g. 1-5),
+outlet names, glob patterns (e.g. web*), aliases or "all".`
+
 var (
-	p pdu.PDU
+	p  pdu.PDU
+	cp pdu.ContextPDU
 
 	cfg *pdu.Config
 
-	detailed = false
+	// ctx is canceled on interrupts or once the timeout expired.
+	// signalCtx is only canceled on interrupts and used for watching.
+	ctx       context.Context
+	signalCtx context.Context
+	cancel    context.CancelFunc
+	timeout   = time.Minute
+
+	detailed      = false
+	watch         = false
+	watchInterval = 10 * time.Second
+
+	userAddOutlets = ""
+
+	historySince  = time.Hour
+	historyStep   time.Duration
+	historyValue  = "power"
+	historyGroups = false
+
+	auditSince     = 24 * time.Hour
+	auditOperation = ""
+	auditClient    = ""
+	auditLimit     = 0
+
+	scheduleName = ""
+	scheduleCron = ""
+	scheduleAt   = ""
+
+	sequenceDetach = false
+
+	fenceAction       = "reboot"
+	fencePlug         = ""
+	fenceMethod       = "onoff"
+	fencePowerTimeout = 20
 
 	// Commands
 	rootCmd = &cobra.Command{
@@ -75,6 +122,167 @@ var (
 		PersistentPostRunE: postRun,
 	}
 
+	userListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List users and their assigned outlets",
+		RunE:  userList,
+		Args:  cobra.NoArgs,
+	}
+
+	userAddCmd = &cobra.Command{
+		Use:   "add NAME [PASSWORD]",
+		Short: "Add a user",
+		Long:  "The password is read from stdin if omitted.",
+		RunE:  userAdd,
+		Args:  cobra.RangeArgs(1, 2),
+	}
+
+	userDeleteCmd = &cobra.Command{
+		Use:     "delete NAME",
+		Aliases: []string{"remove", "rm"},
+		Short:   "Remove a user",
+		RunE:    userDelete,
+		Args:    cobra.ExactArgs(1),
+	}
+
+	userPasswordCmd = &cobra.Command{
+		Use:     "password NAME [PASSWORD]",
+		Aliases: []string{"passwd"},
+		Short:   "Change the password of a user",
+		Long:    "The password is read from stdin if omitted.",
+		RunE:    userPassword,
+		Args:    cobra.RangeArgs(1, 2),
+	}
+
+	userOutletsCmd = &cobra.Command{
+		Use:               "outlets NAME OUTLETS",
+		Short:             "Set the outlets which a user is allowed to control",
+		Long:              outletsHelp + "\n\nUse \"none\" to revoke access to all outlets.",
+		RunE:              userOutlets,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: userOutletsCompletion,
+	}
+
+	historyCmd = &cobra.Command{
+		Use:   "history [OUTLETS]",
+		Short: "Show past measurements of outlets or groups",
+		Long: outletsHelp + `
+
+The history is retained by pdud. Use --format sparkline to render a sparkline per outlet or group.`,
+		RunE:               history,
+		Args:               cobra.MaximumNArgs(1),
+		ValidArgsFunction:  outletCompletion,
+		PersistentPreRunE:  preRun,
+		PersistentPostRunE: postRun,
+	}
+
+	scheduleCmd = &cobra.Command{
+		Use:                "schedule",
+		Short:              "Manage scheduled outlet actions",
+		Long:               "Schedules are executed by pdud.",
+		PersistentPreRunE:  preRun,
+		PersistentPostRunE: postRun,
+	}
+
+	scheduleListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List scheduled outlet actions",
+		RunE:  scheduleList,
+		Args:  cobra.NoArgs,
+	}
+
+	scheduleAddCmd = &cobra.Command{
+		Use:   "add OUTLETS ACTION",
+		Short: "Schedule an action for outlets",
+		Long: outletsHelp + `
+
+ACTION is one of on, off, reboot, lock or unlock.
+
+Either --cron or --at is required. --cron accepts a cron expression
+(minute hour day-of-month month day-of-week) or a descriptor like @daily.
+--at accepts a time in RFC 3339 format or a duration relative to now.`,
+		Example: `  pductl schedule add lab* reboot --cron "0 3 * * *"
+  pductl schedule add bench1-4 off --cron "0 20 * * fri" --name weekend
+  pductl schedule add 5 on --at 30m`,
+		RunE:              scheduleAdd,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: scheduleAddCompletion,
+	}
+
+	scheduleDeleteCmd = &cobra.Command{
+		Use:     "delete ID",
+		Aliases: []string{"remove", "rm"},
+		Short:   "Remove a scheduled outlet action",
+		RunE:    scheduleDelete,
+		Args:    cobra.ExactArgs(1),
+	}
+
+	scheduleRunsCmd = &cobra.Command{
+		Use:   "runs",
+		Short: "Show recent runs of scheduled outlet actions",
+		RunE:  scheduleRuns,
+		Args:  cobra.NoArgs,
+	}
+
+	sequenceCmd = &cobra.Command{
+		Use:                "sequence",
+		Aliases:            []string{"seq"},
+		Short:              "Run power sequences",
+		Long:               "Sequences are defined in the configuration of pdud.",
+		PersistentPreRunE:  preRun,
+		PersistentPostRunE: postRun,
+	}
+
+	sequenceListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List power sequences",
+		RunE:  sequenceList,
+		Args:  cobra.NoArgs,
+	}
+
+	sequenceShowCmd = &cobra.Command{
+		Use:               "show NAME",
+		Short:             "Show the progress of the last run of a power sequence",
+		RunE:              sequenceShow,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: sequenceCompletion,
+	}
+
+	sequenceRunCmd = &cobra.Command{
+		Use:   "run NAME [STATE]",
+		Short: "Switch the outlets of a power sequence on in order or off in reverse order",
+		Long: `STATE is either on (default) or off.
+
+The progress is shown until the sequence has finished unless --detach is given.
+Interrupting pductl does not abort the sequence.`,
+		RunE:              sequenceRun,
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: sequenceCompletion,
+	}
+
+	alertsCmd = &cobra.Command{
+		Use:                "alerts",
+		Short:              "List active alerts",
+		Long:               "Alert rules are evaluated by pdud.",
+		RunE:               alerts,
+		Args:               cobra.NoArgs,
+		PersistentPreRunE:  preRun,
+		PersistentPostRunE: postRun,
+	}
+
+	auditCmd = &cobra.Command{
+		Use:   "audit [OUTLETS]",
+		Short: "Show the audit log of state-changing operations",
+		Long: outletsHelp + `
+
+The audit log is recorded by pdud.`,
+		RunE:               audit,
+		Args:               cobra.MaximumNArgs(1),
+		ValidArgsFunction:  outletCompletion,
+		PersistentPreRunE:  preRun,
+		PersistentPostRunE: postRun,
+	}
+
 	tempCmd = &cobra.Command{
 		Use:                "temperature",
 		Aliases:            []string{"temp"},
@@ -84,6 +292,15 @@ var (
 		PersistentPostRunE: postRun,
 	}
 
+	diagnosticsCmd = &cobra.Command{
+		Use:                "diagnostics",
+		Short:              "Show the health of the connection to the PDU and its polling",
+		RunE:               diagnostics,
+		Args:               cobra.NoArgs,
+		PersistentPreRunE:  preRun,
+		PersistentPostRunE: postRun,
+	}
+
 	clearCmd = &cobra.Command{
 		Use:                "clear",
 		Short:              "Reset the maximum detected current",
@@ -100,32 +317,52 @@ var (
 	}
 
 	outletRebootCmd = &cobra.Command{
-		Use:               "reboot OUTLET",
-		Short:             "Reboot an outlet",
+		Use:               "reboot OUTLETS",
+		Short:             "Reboot outlets",
+		Long:              outletsHelp,
 		RunE:              outletReboot,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: outletCompletion,
 	}
 
 	outletSwitchCmd = &cobra.Command{
-		Use:               "switch OUTLET STATE",
-		Short:             "Switch an outlet on/off",
+		Use:               "switch OUTLETS STATE",
+		Short:             "Switch outlets on/off",
+		Long:              outletsHelp,
 		RunE:              outletSwitch,
 		Args:              cobra.ExactArgs(2),
 		ValidArgsFunction: outletCompletionSwitch,
 	}
 
 	outletLockCmd = &cobra.Command{
-		Use:               "lock OUTLET STATE",
-		Short:             "Lock or unlock an outlet",
+		Use:               "lock OUTLETS STATE",
+		Short:             "Lock or unlock outlets",
+		Long:              outletsHelp,
 		RunE:              outletLock,
 		Args:              cobra.ExactArgs(2),
 		ValidArgsFunction: outletCompletionSwitch,
 	}
 
+	fenceCmd = &cobra.Command{
+		Use:   "fence",
+		Short: "Run as fence agent for Pacemaker",
+		Long: `Implements the fence agent protocol of Pacemaker and the fence-agents project.
+
+Options are read as "name=value" lines from stdin if no flags are given.
+The exit code is 0 on success, 1 on failure and 2 if the status action found the outlets switched off.
+pductl runs in this mode if it is invoked as ` + fenceAgentName + `.`,
+		Example: `  pductl fence --action status --plug 3
+  echo -e "action=reboot\nplug=web1\nip=http://pdud:8080" | pductl fence
+  ln -s /usr/bin/pductl /usr/sbin/` + fenceAgentName,
+		RunE:          fence,
+		Args:          cobra.NoArgs,
+		SilenceErrors: true,
+	}
+
 	outletStatusCmd = &cobra.Command{
-		Use:               "status OUTLET",
-		Short:             "Get status of outlet",
+		Use:               "status OUTLETS",
+		Short:             "Get status of outlets",
+		Long:              outletsHelp,
 		RunE:              outletStatus,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: outletCompletion,
@@ -133,23 +370,60 @@ var (
 )
 
 func init() {
-	rootCmd.AddCommand(statusCmd, tempCmd, clearCmd, outletCmd, userCmd, genDocs)
-	userCmd.AddCommand(whoAmICmd)
+	rootCmd.AddCommand(statusCmd, historyCmd, tempCmd, diagnosticsCmd, clearCmd, outletCmd, userCmd, scheduleCmd, sequenceCmd, alertsCmd, auditCmd, fenceCmd, genDocs)
+	userCmd.AddCommand(whoAmICmd, userListCmd, userAddCmd, userDeleteCmd, userPasswordCmd, userOutletsCmd)
+	scheduleCmd.AddCommand(scheduleListCmd, scheduleAddCmd, scheduleDeleteCmd, scheduleRunsCmd)
+	sequenceCmd.AddCommand(sequenceListCmd, sequenceShowCmd, sequenceRunCmd)
 	outletCmd.AddCommand(outletLockCmd, outletRebootCmd, outletSwitchCmd, outletStatusCmd)
 
 	pf := rootCmd.PersistentFlags()
 	pf.String("config", "", "Path to YAML-formatted configuration file")
 	pf.String("address", "http://localhost:8080", "Address for PDU communication")
+	pf.String("pdu", "", "Name of the PDU to use if multiple PDUs are configured")
 	pf.String("format", "pretty-rounded", "Output format")
 	pf.String("username", "admin", "Username")
 	pf.String("password", "admin", "password")
+	pf.String("api-key", "", "API key for authenticating against pdud")
+	pf.String("token", "", "Bearer token (JWT) for authenticating against pdud")
 	pf.String("tls-cacert", "", "Certificate Authority to validate client certificates against")
 	pf.String("tls-cert", "", "Server certificate")
 	pf.String("tls-key", "", "Server key")
 	pf.Bool("tls-insecure", false, "Skip verification of server certificate")
+	pf.DurationVar(&timeout, "timeout", time.Minute, "Timeout for the command (0 disables the timeout)")
+
+	userAddCmd.Flags().StringVar(&userAddOutlets, "outlets", "", "Outlets which the new user is allowed to control")
+
+	pf = historyCmd.Flags()
+	pf.DurationVar(&historySince, "since", time.Hour, "Start of the time range relative to now")
+	pf.DurationVar(&historyStep, "step", 0, "Interval for averaging samples (0 returns all samples)")
+	pf.StringVar(&historyValue, "value", "power", "Value to show (power, current, voltage or energy)")
+	pf.BoolVar(&historyGroups, "groups", false, "Show groups instead of outlets")
+
+	pf = auditCmd.Flags()
+	pf.DurationVar(&auditSince, "since", 24*time.Hour, "Start of the time range relative to now")
+	pf.StringVar(&auditOperation, "operation", "", "Only show operations with this ID (e.g. switch-outlet)")
+	pf.StringVar(&auditClient, "client", "", "Only show operations of the client with this common name")
+	pf.IntVar(&auditLimit, "limit", 0, "Maximum number of most recent entries to show (0 shows all)")
+
+	pf = scheduleAddCmd.Flags()
+	pf.StringVar(&scheduleName, "name", "", "Name of the schedule")
+	pf.StringVar(&scheduleCron, "cron", "", "Cron expression for recurring actions")
+	pf.StringVar(&scheduleAt, "at", "", "Time of a one-shot action")
+	scheduleAddCmd.MarkFlagsOneRequired("cron", "at")
+	scheduleAddCmd.MarkFlagsMutuallyExclusive("cron", "at")
+
+	sequenceRunCmd.Flags().BoolVar(&sequenceDetach, "detach", false, "Do not wait for the sequence to finish")
+
+	pf = fenceCmd.Flags()
+	pf.StringVarP(&fenceAction, "action", "o", "reboot", "Fencing action ("+strings.Join(fenceActions, ", ")+")")
+	pf.StringVarP(&fencePlug, "plug", "n", "", "Outlets to fence")
+	pf.StringVarP(&fenceMethod, "method", "m", "onoff", "Method to fence (onoff or cycle)")
+	pf.IntVar(&fencePowerTimeout, "power-timeout", 20, "Time in seconds to wait for outlets to change their state")
 
 	pf = statusCmd.PersistentFlags()
 	pf.BoolVar(&detailed, "detailed", false, "Show detailed status")
+	pf.BoolVarP(&watch, "watch", "w", false, "Continuously show status updates and change events")
+	pf.DurationVar(&watchInterval, "watch-interval", 10*time.Second, "Polling interval for watching PDUs which do not stream events")
 }
 
 func outletCompletionSwitch(cmd *cobra.Command, args []string, toComplete string) (comps []string, _ cobra.ShellCompDirective) {
@@ -168,6 +442,56 @@ func outletCompletion(cmd *cobra.Command, args []string, toComplete string) ([]s
 	return nil, cobra.ShellCompDirectiveNoFileComp
 }
 
+func scheduleAddCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 1 {
+		return []string{"on", "off", "reboot", "lock", "unlock"}, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return outletCompletion(cmd, args, toComplete)
+}
+
+func sequenceCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 1 && cmd.Name() == "run" {
+		return []string{"on", "off"}, cobra.ShellCompDirectiveNoFileComp
+	} else if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var err error
+	if cfg, err = pdu.ParseConfig(nil); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	if p, err = newPDU(context.Background(), cfg); err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	sp, ok := p.(pdu.SequencePDU)
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	seqs, err := sp.Sequences()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names := []string{}
+	for _, seq := range seqs {
+		names = append(names, seq.Name)
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func userOutletsCompletion(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 1 {
+		return append(outletIDs(), pdu.None), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
 func outletIDs() (ids []string) {
 	var err error
 
@@ -175,7 +499,7 @@ func outletIDs() (ids []string) {
 		return nil
 	}
 
-	if p, err = newPDU(cfg); err != nil {
+	if p, err = newPDU(context.Background(), cfg); err != nil {
 		return nil
 	}
 
@@ -188,6 +512,10 @@ func outletIDs() (ids []string) {
 		ids = append(ids, outlet.Name, fmt.Sprint(outlet.ID))
 	}
 
+	for alias := range aliases() {
+		ids = append(ids, alias)
+	}
+
 	return ids
 }
 
@@ -197,14 +525,32 @@ func preRun(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("failed to parse config: %w", err)
 	}
 
-	if p, err = newPDU(cfg); err != nil {
+	var stop context.CancelFunc
+	signalCtx, stop = signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	ctx, cancel = signalCtx, stop
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(signalCtx, timeout)
+
+		cancel = func() {
+			cancelTimeout()
+			stop()
+		}
+	}
+
+	if p, err = newPDU(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to setup PDU: %w", err)
 	}
 
+	cp = pdu.AsContextPDU(p)
+
 	return err
 }
 
 func postRun(cmd *cobra.Command, args []string) error {
+	defer cancel()
+
 	if err := p.Close(); err != nil {
 		return fmt.Errorf("Failed to close PDU: %w", err)
 	}
@@ -250,7 +596,7 @@ func newHTTPClient(cfg *pdu.Config) (c *http.Client, err error) {
 	}, err
 }
 
-func newPDU(cfg *pdu.Config) (p pdu.PDU, err error) {
+func newPDU(ctx context.Context, cfg *pdu.Config) (p pdu.PDU, err error) {
 	u, err := url.Parse(cfg.Address)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse URL: %w", err)
@@ -263,17 +609,49 @@ func newPDU(cfg *pdu.Config) (p pdu.PDU, err error) {
 			return nil, err
 		}
 
-		if p, err = client.NewPDU(cfg.Address, api.WithHTTPClient(c)); err != nil {
+		opts := []api.ClientOption{api.WithHTTPClient(c)}
+
+		if cfg.APIKey != "" || cfg.Token != "" {
+			opts = append(opts, api.WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+				if cfg.APIKey != "" {
+					req.Header.Set("X-API-KEY", cfg.APIKey)
+				}
+
+				if cfg.Token != "" {
+					req.Header.Set("Authorization", "Bearer "+cfg.Token)
+				}
+
+				return nil
+			}))
+		}
+
+		if p, err = client.NewPDU(cfg.Address, cfg.PDU, opts...); err != nil {
 			return nil, err
 		}
 
 	default:
-		q, err := baytech.NewPDU(cfg.Address)
+		pc := &pdu.PDUConfig{
+			Address:        cfg.Address,
+			Username:       cfg.Username,
+			Password:       cfg.Password,
+			CommandTimeout: cfg.CommandTimeout,
+		}
+
+		// Select the PDU from the list in the configuration file
+		if cfg.PDU != "" {
+			if pc, err = cfg.LookupPDU(cfg.PDU); err != nil {
+				return nil, err
+			}
+		}
+
+		q, err := baytech.NewPDU(pc.Address)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := q.Login(cfg.Username, cfg.Password); err != nil {
+		q.SetCommandTimeout(pc.CommandTimeout)
+
+		if err := q.LoginContext(ctx, pc.Username, pc.Password); err != nil {
 			return nil, fmt.Errorf("failed to login to PDU: %w", err)
 		}
 
@@ -283,6 +661,15 @@ func newPDU(cfg *pdu.Config) (p pdu.PDU, err error) {
 	return p, err
 }
 
+// aliases returns the outlet aliases of the selected PDU.
+func aliases() map[string]string {
+	if pc, err := cfg.LookupPDU(cfg.PDU); err == nil {
+		return pc.Aliases
+	}
+
+	return cfg.Aliases
+}
+
 func parseState(s string) (state bool, err error) {
 	switch s {
 	case "off", "false", "0":
@@ -308,11 +695,21 @@ func status(cmd *cobra.Command, args []string) error {
 		detailed = true
 	}
 
-	sts, err := p.Status(detailed)
+	if watch {
+		return watchStatus(use)
+	}
+
+	sts, err := cp.StatusContext(ctx, detailed)
 	if err != nil {
 		return fmt.Errorf("Failed to get status: %w", err)
 	}
 
+	printStatus(sts, use)
+
+	return nil
+}
+
+func printStatus(sts *pdu.Status, use string) {
 	switch use {
 	case "all":
 		sts.Print(os.Stdout, cfg.Format)
@@ -323,12 +720,121 @@ func status(cmd *cobra.Command, args []string) error {
 	case "breakers", "breaker", "brk":
 		sts.PrintBreakers(os.Stdout, cfg.Format)
 	}
+}
 
-	return nil
+func watchStatus(use string) error {
+	const maxRecentEvents = 10
+	recentEvents := []string{}
+
+	handle := func(e *pdu.Event) error {
+		if cfg.Format == "json" {
+			return json.NewEncoder(os.Stdout).Encode(e)
+		}
+
+		switch e.Type {
+		case pdu.EventStatus:
+			sts := *e.Status
+			if !detailed {
+				sts.Outlets = nil
+			}
+
+			fmt.Print("\033[H\033[2J") // Clear screen
+			printStatus(&sts, use)
+
+			if len(recentEvents) > 0 {
+				fmt.Println()
+				fmt.Println("Recent events:")
+
+				for _, line := range recentEvents {
+					fmt.Println(line)
+				}
+			}
+
+		case pdu.EventOutletState:
+			recentEvents = append(recentEvents, fmt.Sprintf("%s Outlet %s switched %s", e.Timestamp.Format(time.TimeOnly), e.Outlet.Name, onOff(e.Outlet.State)))
+
+		case pdu.EventOutletLock:
+			recentEvents = append(recentEvents, fmt.Sprintf("%s Outlet %s locked: %t", e.Timestamp.Format(time.TimeOnly), e.Outlet.Name, e.Outlet.Locked))
+
+		case pdu.EventSequence:
+			r := e.Sequence
+			for i, st := range r.Steps {
+				if st.Status == pdu.StepSwitching || st.Status == pdu.StepWaiting || i == len(r.Steps)-1 {
+					recentEvents = append(recentEvents, fmt.Sprintf("%s Sequence %s %s: step %d/%d (%s) %s", e.Timestamp.Format(time.TimeOnly), r.Sequence, onOff(r.State), i+1, len(r.Steps), st.Outlets, st.Status))
+					break
+				}
+			}
+
+		case pdu.EventBreakerThreshold:
+			direction := "fell below"
+			if *e.Exceeded {
+				direction = "exceeded"
+			}
+
+			recentEvents = append(recentEvents, fmt.Sprintf("%s Breaker %s %s threshold of %.1f A: %.1f A", e.Timestamp.Format(time.TimeOnly), e.Breaker.Name, direction, *e.Threshold, e.Breaker.TrueRMSCurrent))
+
+		case pdu.EventAlert:
+			recentEvents = append(recentEvents, fmt.Sprintf("%s Alert %s %s: %s", e.Timestamp.Format(time.TimeOnly), e.Alert.Rule, e.Alert.State, e.Alert.Message))
+
+		case pdu.EventLoadShedding:
+			a := e.LoadShedding
+			recentEvents = append(recentEvents, fmt.Sprintf("%s Load shedding %s: %s outlet %s at %.1f A of %.1f A", e.Timestamp.Format(time.TimeOnly), a.Policy, a.Action, a.Outlet.Name, a.Current, a.Limit))
+
+		case pdu.EventWatchdog:
+			w := e.Watchdog
+			recentEvents = append(recentEvents, fmt.Sprintf("%s Watchdog %s rebooted outlets %s (%d reboots within 24h)", e.Timestamp.Format(time.TimeOnly), w.Name, w.Outlets, w.Reboots))
+		}
+
+		if len(recentEvents) > maxRecentEvents {
+			recentEvents = recentEvents[len(recentEvents)-maxRecentEvents:]
+		}
+
+		return nil
+	}
+
+	if es, ok := p.(pdu.EventSource); ok {
+		return es.Events(signalCtx, handle)
+	}
+
+	// Fallback to polling for PDUs which do not stream events
+	tmr := time.NewTicker(watchInterval)
+	defer tmr.Stop()
+
+	var prevSts *pdu.Status
+	for {
+		newSts, err := cp.StatusContext(signalCtx, true)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("Failed to get status: %w", err)
+		}
+
+		for _, e := range pdu.StatusEvents(prevSts, newSts, cfg.Events.BreakerThresholds) {
+			if err := handle(e); err != nil {
+				return err
+			}
+		}
+
+		prevSts = newSts
+
+		select {
+		case <-signalCtx.Done():
+			return nil
+		case <-tmr.C:
+		}
+	}
+}
+
+func onOff(state bool) string {
+	if state {
+		return "on"
+	}
+
+	return "off"
 }
 
 func whoAmI(_ *cobra.Command, _ []string) error {
-	user, err := p.WhoAmI()
+	user, err := cp.WhoAmIContext(ctx)
 	if err != nil {
 		return fmt.Errorf("Failed to send command: %w", err)
 	}
@@ -338,8 +844,393 @@ func whoAmI(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+func userList(_ *cobra.Command, _ []string) error {
+	users, err := cp.UsersContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to list users: %w", err)
+	}
+
+	api.PrintUsers(os.Stdout, cfg.Format, users)
+
+	return nil
+}
+
+func userAdd(_ *cobra.Command, args []string) error {
+	password, err := passwordFromArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if err := cp.AddUserContext(ctx, args[0], password); err != nil {
+		return fmt.Errorf("Failed to add user: %w", err)
+	}
+
+	if userAddOutlets != "" {
+		id, err := pdu.ExpandAliases(userAddOutlets, aliases())
+		if err != nil {
+			return err
+		}
+
+		if err := cp.SetUserOutletsContext(ctx, args[0], id); err != nil {
+			return fmt.Errorf("Failed to assign outlets: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func userDelete(_ *cobra.Command, args []string) error {
+	if err := cp.DeleteUserContext(ctx, args[0]); err != nil {
+		return fmt.Errorf("Failed to remove user: %w", err)
+	}
+
+	return nil
+}
+
+func userPassword(_ *cobra.Command, args []string) error {
+	password, err := passwordFromArgs(args)
+	if err != nil {
+		return err
+	}
+
+	if err := cp.ChangePasswordContext(ctx, args[0], password); err != nil {
+		return fmt.Errorf("Failed to change password: %w", err)
+	}
+
+	return nil
+}
+
+func userOutlets(_ *cobra.Command, args []string) error {
+	id, err := pdu.ExpandAliases(args[1], aliases())
+	if err != nil {
+		return err
+	}
+
+	if err := cp.SetUserOutletsContext(ctx, args[0], id); err != nil {
+		return fmt.Errorf("Failed to assign outlets: %w", err)
+	}
+
+	return nil
+}
+
+// passwordFromArgs returns the password passed as second argument or reads it from stdin.
+func passwordFromArgs(args []string) (string, error) {
+	if len(args) > 1 {
+		return args[1], nil
+	}
+
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", errors.New("empty password")
+	}
+
+	return password, nil
+}
+
+func history(_ *cobra.Command, args []string) error {
+	hp, ok := p.(pdu.HistoryPDU)
+	if !ok {
+		return errors.New("history is only available via pdud")
+	}
+
+	id := ""
+	if len(args) > 0 {
+		var err error
+		if id, err = pdu.ExpandAliases(args[0], aliases()); err != nil {
+			return err
+		}
+	}
+
+	to := time.Now()
+	from := to.Add(-historySince)
+
+	samples, err := hp.HistoryContext(ctx, from, to, historyStep, id)
+	if err != nil {
+		return fmt.Errorf("Failed to get history: %w", err)
+	}
+
+	series, err := api.NewHistorySeries(samples, historyValue, historyGroups)
+	if err != nil {
+		return err
+	}
+
+	api.PrintHistory(os.Stdout, cfg.Format, samples, series)
+
+	return nil
+}
+
+func schedulePDU() (pdu.SchedulePDU, error) {
+	sp, ok := p.(pdu.SchedulePDU)
+	if !ok {
+		return nil, errors.New("schedules are only available via pdud")
+	}
+
+	return sp, nil
+}
+
+func scheduleList(_ *cobra.Command, _ []string) error {
+	sp, err := schedulePDU()
+	if err != nil {
+		return err
+	}
+
+	schedules, err := sp.SchedulesContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to list schedules: %w", err)
+	}
+
+	api.PrintSchedules(os.Stdout, cfg.Format, schedules)
+
+	return nil
+}
+
+func scheduleAdd(_ *cobra.Command, args []string) error {
+	sp, err := schedulePDU()
+	if err != nil {
+		return err
+	}
+
+	ns := pdu.NewSchedule{
+		Outlets: args[0],
+		Action:  pdu.ScheduleAction(args[1]),
+	}
+
+	if scheduleName != "" {
+		ns.Name = &scheduleName
+	}
+
+	if scheduleCron != "" {
+		ns.Cron = &scheduleCron
+	}
+
+	if scheduleAt != "" {
+		at, err := parseTime(scheduleAt)
+		if err != nil {
+			return err
+		}
+
+		ns.At = &at
+	}
+
+	s, err := sp.AddScheduleContext(ctx, ns)
+	if err != nil {
+		return fmt.Errorf("Failed to add schedule: %w", err)
+	}
+
+	api.PrintSchedules(os.Stdout, cfg.Format, []pdu.Schedule{*s})
+
+	return nil
+}
+
+func scheduleDelete(_ *cobra.Command, args []string) error {
+	sp, err := schedulePDU()
+	if err != nil {
+		return err
+	}
+
+	if err := sp.DeleteScheduleContext(ctx, args[0]); err != nil {
+		return fmt.Errorf("Failed to remove schedule: %w", err)
+	}
+
+	return nil
+}
+
+func scheduleRuns(_ *cobra.Command, _ []string) error {
+	sp, err := schedulePDU()
+	if err != nil {
+		return err
+	}
+
+	runs, err := sp.ScheduleRunsContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to list schedule runs: %w", err)
+	}
+
+	api.PrintScheduleRuns(os.Stdout, cfg.Format, runs)
+
+	return nil
+}
+
+// parseTime parses a time in RFC 3339 format or a duration relative to now.
+func parseTime(s string) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse time: %w", err)
+	}
+
+	return t, nil
+}
+
+func sequencePDU() (pdu.SequencePDU, error) {
+	sp, ok := p.(pdu.SequencePDU)
+	if !ok {
+		return nil, errors.New("sequences are only available via pdud")
+	}
+
+	return sp, nil
+}
+
+func sequenceList(_ *cobra.Command, _ []string) error {
+	sp, err := sequencePDU()
+	if err != nil {
+		return err
+	}
+
+	seqs, err := sp.SequencesContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to list sequences: %w", err)
+	}
+
+	api.PrintSequences(os.Stdout, cfg.Format, seqs)
+
+	return nil
+}
+
+func sequenceShow(_ *cobra.Command, args []string) error {
+	sp, err := sequencePDU()
+	if err != nil {
+		return err
+	}
+
+	seq, err := sp.SequenceContext(ctx, args[0])
+	if err != nil {
+		return fmt.Errorf("Failed to get sequence: %w", err)
+	}
+
+	if seq.LastRun == nil {
+		return errors.New("sequence has not been run yet")
+	}
+
+	api.PrintSequenceRun(os.Stdout, cfg.Format, seq.LastRun)
+
+	return nil
+}
+
+func sequenceRun(_ *cobra.Command, args []string) error {
+	sp, err := sequencePDU()
+	if err != nil {
+		return err
+	}
+
+	state := true
+	if len(args) > 1 {
+		if state, err = parseState(args[1]); err != nil {
+			return err
+		}
+	}
+
+	run, err := sp.RunSequenceContext(ctx, args[0], state)
+	if err != nil {
+		return fmt.Errorf("Failed to run sequence: %w", err)
+	}
+
+	if !sequenceDetach {
+		if run, err = followSequence(sp, run); err != nil {
+			return err
+		}
+	}
+
+	api.PrintSequenceRun(os.Stdout, cfg.Format, run)
+
+	if run.Status == pdu.SequenceFailed {
+		return fmt.Errorf("Sequence failed: %s", *run.Error)
+	}
+
+	return nil
+}
+
+// followSequence reports the progress of each step until the run has finished.
+func followSequence(sp pdu.SequencePDU, run *pdu.SequenceRun) (*pdu.SequenceRun, error) {
+	tmr := time.NewTicker(sequenceWatchInterval)
+	defer tmr.Stop()
+
+	reported := make([]api.SequenceStepRunStatus, len(run.Steps))
+
+	for {
+		for i, st := range run.Steps {
+			if st.Status != reported[i] && st.Status != pdu.StepPending {
+				fmt.Fprintf(os.Stderr, "[%d/%d] %s: %s\n", i+1, len(run.Steps), st.Outlets, st.Status)
+				reported[i] = st.Status
+			}
+		}
+
+		if run.Status != pdu.SequenceRunning {
+			return run, nil
+		}
+
+		select {
+		case <-signalCtx.Done():
+			return run, nil
+		case <-tmr.C:
+		}
+
+		seq, err := sp.SequenceContext(signalCtx, run.Sequence)
+		if errors.Is(err, context.Canceled) {
+			return run, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("Failed to get sequence: %w", err)
+		} else if seq.LastRun == nil || !seq.LastRun.Started.Equal(run.Started) {
+			return nil, errors.New("sequence has been restarted")
+		}
+
+		run = seq.LastRun
+	}
+}
+
+func alerts(_ *cobra.Command, _ []string) error {
+	ap, ok := p.(pdu.AlertPDU)
+	if !ok {
+		return errors.New("alerts are only available via pdud")
+	}
+
+	alerts, err := ap.AlertsContext(ctx)
+	if err != nil {
+		return fmt.Errorf("Failed to list alerts: %w", err)
+	}
+
+	api.PrintAlerts(os.Stdout, cfg.Format, alerts)
+
+	return nil
+}
+
+func audit(_ *cobra.Command, args []string) error {
+	ap, ok := p.(pdu.AuditPDU)
+	if !ok {
+		return errors.New("audit log is only available via pdud")
+	}
+
+	id := ""
+	if len(args) > 0 {
+		var err error
+		if id, err = pdu.ExpandAliases(args[0], aliases()); err != nil {
+			return err
+		}
+	}
+
+	to := time.Now()
+	from := to.Add(-auditSince)
+
+	entries, err := ap.AuditContext(ctx, from, to, id, auditOperation, auditClient, auditLimit)
+	if err != nil {
+		return fmt.Errorf("Failed to get audit log: %w", err)
+	}
+
+	api.PrintAudit(os.Stdout, cfg.Format, entries)
+
+	return nil
+}
+
 func temp(_ *cobra.Command, _ []string) error {
-	temp, err := p.Temperature()
+	temp, err := cp.TemperatureContext(ctx)
 	if err != nil {
 		return fmt.Errorf("Failed to send command: %w", err)
 	}
@@ -349,8 +1240,34 @@ func temp(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+func diagnostics(_ *cobra.Command, _ []string) error {
+	var (
+		d   *pdu.Diagnostics
+		err error
+	)
+
+	switch dp := p.(type) {
+	case interface {
+		DiagnosticsContext(ctx context.Context) (*pdu.Diagnostics, error)
+	}:
+		d, err = dp.DiagnosticsContext(ctx)
+	case pdu.DiagnosticsPDU:
+		d, err = dp.Diagnostics()
+	default:
+		return errors.New("diagnostics are not supported by this PDU")
+	}
+
+	if err != nil {
+		return fmt.Errorf("Failed to get diagnostics: %w", err)
+	}
+
+	d.Print(os.Stdout, cfg.Format)
+
+	return nil
+}
+
 func clearMaximumCurrent(_ *cobra.Command, _ []string) error {
-	if err := p.ClearMaximumCurrents(); err != nil {
+	if err := cp.ClearMaximumCurrentsContext(ctx); err != nil {
 		return fmt.Errorf("Failed to clear maximum current: %w", err)
 	}
 
@@ -358,36 +1275,54 @@ func clearMaximumCurrent(_ *cobra.Command, _ []string) error {
 }
 
 func outletReboot(_ *cobra.Command, args []string) error {
-	id := args[0]
-	if err := p.RebootOutlet(id); err != nil {
-		return fmt.Errorf("Failed to control outlet: %w", err)
+	id, err := pdu.ExpandAliases(args[0], aliases())
+	if err != nil {
+		return err
 	}
 
-	return nil
+	results, err := cp.RebootOutletContext(ctx, id)
+
+	return printOutletResults(results, err)
 }
 
 func outletSwitch(_ *cobra.Command, args []string) error {
-	id := args[0]
-	state, err := parseState(args[1])
+	id, err := pdu.ExpandAliases(args[0], aliases())
 	if err != nil {
 		return err
 	}
 
-	if err := p.SwitchOutlet(id, state); err != nil {
-		return fmt.Errorf("Failed to control outlet: %w", err)
+	state, err := parseState(args[1])
+	if err != nil {
+		return err
 	}
 
-	return nil
+	results, err := cp.SwitchOutletContext(ctx, id, state)
+
+	return printOutletResults(results, err)
 }
 
 func outletLock(_ *cobra.Command, args []string) error {
-	id := args[0]
+	id, err := pdu.ExpandAliases(args[0], aliases())
+	if err != nil {
+		return err
+	}
+
 	state, err := parseState(args[1])
 	if err != nil {
 		return err
 	}
 
-	if err := p.LockOutlet(id, state); err != nil {
+	results, err := cp.LockOutletContext(ctx, id, state)
+
+	return printOutletResults(results, err)
+}
+
+func printOutletResults(results []pdu.OutletResult, err error) error {
+	if len(results) > 0 {
+		api.PrintOutletResults(os.Stdout, cfg.Format, results)
+	}
+
+	if err != nil {
 		return fmt.Errorf("Failed to control outlet: %w", err)
 	}
 
@@ -395,33 +1330,21 @@ func outletLock(_ *cobra.Command, args []string) error {
 }
 
 func outletStatus(_ *cobra.Command, args []string) error {
-	arg := args[0]
-	sts, err := p.Status(true)
+	id, err := pdu.ExpandAliases(args[0], aliases())
 	if err != nil {
 		return err
 	}
 
-	id := -1
-	if i, err := strconv.ParseInt(arg, 0, 64); err == nil {
-		id = int(i)
-	}
-
-	idx := -1
-	for i, o := range sts.Outlets {
-		if o.Name == arg {
-			idx = i
-		}
-
-		if id >= 0 && o.ID == id {
-			idx = i
-		}
+	outlets, err := cp.StatusOutletsContext(ctx, id)
+	if err != nil {
+		return err
 	}
 
-	if idx < 0 {
-		return pdu.ErrInvalidOutletID
+	sts := pdu.Status{
+		Outlets: outlets,
 	}
 
-	sts.Outlets[idx].Print(os.Stdout, cfg.Format)
+	sts.PrintOutlets(os.Stdout, cfg.Format)
 
 	return nil
 }
@@ -429,7 +1352,17 @@ func outletStatus(_ *cobra.Command, args []string) error {
 func main() {
 	slog.SetLogLoggerLevel(slog.LevelDebug)
 
+	// Run as fence agent if invoked via a symlink like fence_pductl
+	if strings.HasPrefix(filepath.Base(os.Args[0]), "fence_") {
+		rootCmd.SetArgs(append([]string{fenceCmd.Name()}, os.Args[1:]...))
+	}
+
 	if err := rootCmd.Execute(); err != nil {
+		var code fenceExitCode
+		if errors.As(err, &code) {
+			os.Exit(int(code))
+		}
+
 		os.Exit(-1)
 	}
 }
@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	daemonx "github.com/coreos/go-systemd/v22/daemon"
+)
+
+// alive returns an error for each PDU whose status is not polled regularly.
+func alive() error {
+	errs := []error{}
+
+	for _, i := range instances {
+		if err := i.pdu.Alive(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", i.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// readiness returns an error for each PDU without a recent status.
+func readiness() error {
+	errs := []error{}
+
+	for _, i := range instances {
+		if err := i.pdu.Ready(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", i.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// healthHandler responds with 503 Service Unavailable and the reasons if the check fails.
+func healthHandler(check func() error) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+
+		if err := check(); err != nil {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, err)
+
+			return
+		}
+
+		fmt.Fprintln(w, "ok")
+	}
+}
+
+// notifyWatchdog keeps the systemd watchdog alive as long as all PDUs are polled regularly.
+// systemd restarts pdud if the notifications stop.
+func notifyWatchdog(ctx context.Context) {
+	interval, err := daemonx.SdWatchdogEnabled(false)
+	if err != nil {
+		slog.Error("Failed to get systemd watchdog interval", slog.Any("error", err))
+		return
+	} else if interval == 0 {
+		return
+	}
+
+	tick := time.NewTicker(interval / 2)
+	defer tick.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-tick.C:
+			if err := alive(); err != nil {
+				slog.Warn("Withholding systemd watchdog notification", slog.Any("error", err))
+				continue
+			}
+
+			if _, err := daemonx.SdNotify(false, daemonx.SdNotifyWatchdog); err != nil {
+				slog.Error("Failed to notify systemd watchdog", slog.Any("error", err))
+			}
+		}
+	}
+}
@@ -4,13 +4,17 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
-	"crypto/x509"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
 	"time"
 
 	"github.com/coreos/go-systemd/v22/activation"
@@ -22,11 +26,37 @@ import (
 	"github.com/stv0g/pductl/baytech"
 )
 
+const (
+	// Energy is not integrated over gaps longer than this number of poll intervals
+	maxEnergyGapPolls = 5
+
+	shutdownTimeout = 5 * time.Second
+)
+
+// instance is a single PDU managed by pdud.
+type instance struct {
+	*pdux.PDUConfig
+
+	pdu       *pdux.PolledPDU
+	driver    *baytech.PDU // Connection whose command timeout is changed by reloads
+	sts       *pdux.Status
+	metrics   *pdux.Metrics
+	events    *pdux.EventBroker
+	energy    *pdux.EnergyStore
+	history   *pdux.History
+	scheduler *pdux.Scheduler
+	sequencer *pdux.Sequencer
+	alerter   *pdux.Alerter
+	shedder   *pdux.LoadShedder
+	watchdog  *pdux.Watchdog
+	mqtt      *pdux.MQTTPublisher
+}
+
 var (
-	pdu     pdux.PDU
-	cfg     *pdux.Config
-	sts     *pdux.Status
-	metrics *pdux.Metrics
+	cfg       *pdux.Config
+	instances []*instance
+	snmp      *pdux.SNMPAgent
+	audit     *pdux.AuditLog
 
 	// Commands
 	rootCmd = &cobra.Command{
@@ -60,6 +90,7 @@ func init() {
 	pf.String("username", "admin", "Username")
 	pf.String("password", "admin", "password")
 	pf.String("listen", ":8080", "Address for HTTP listener")
+	pf.String("state-dir", "", "Directory for persisting energy counters, history and schedules (defaults to $STATE_DIRECTORY)")
 	pf.String("tls-cacert", "", "Certificate Authority to validate client certificates against")
 	pf.String("tls-cert", "", "Server certificate")
 	pf.String("tls-key", "", "Server key")
@@ -76,90 +107,265 @@ func preRun(cmd *cobra.Command, args []string) (err error) {
 		return fmt.Errorf("failed to parse configuration: %w", err)
 	}
 
-	if pdu, err = baytech.NewPDU(cfg.Address); err != nil {
-		return err
+	if cfg.StateDir == "" {
+		slog.Warn("No state directory provided. Energy counters will be reset on restart!")
 	}
 
-	pdu = pdux.NewPolledPDU(pdu, cfg.PollInterval, cfg.Username, cfg.Password, onStatus)
+	auditPath := cfg.Audit.File
+	if auditPath == "" && cfg.StateDir != "" {
+		auditPath = filepath.Join(cfg.StateDir, "audit.jsonl")
+	}
 
-	return err
+	if auditPath != "" || cfg.Audit.Syslog {
+		if audit, err = pdux.NewAuditLog(&cfg.Audit, auditPath); err != nil {
+			return fmt.Errorf("failed to create audit log: %w", err)
+		}
+	}
+
+	for i := range cfg.PDUs {
+		pc := &cfg.PDUs[i]
+
+		p, err := baytech.NewPDU(pc.Address)
+		if err != nil {
+			return fmt.Errorf("failed to create PDU %s: %w", pc.Name, err)
+		}
+
+		p.SetCommandTimeout(pc.CommandTimeout)
+
+		inst := &instance{
+			PDUConfig: pc,
+			driver:    p,
+			events:    pdux.NewEventBroker(),
+		}
+
+		if cfg.StateDir != "" {
+			if inst.energy, err = pdux.NewEnergyStore(filepath.Join(cfg.StateDir, "energy-"+pc.Name+".json")); err != nil {
+				return err
+			}
+		}
+
+		historyPath := ""
+		if cfg.History.Persist && cfg.StateDir != "" {
+			historyPath = filepath.Join(cfg.StateDir, "history-"+pc.Name+".jsonl")
+		}
+
+		if inst.history, err = pdux.NewHistory(cfg.History, pc.PollInterval, historyPath); err != nil {
+			return err
+		}
+
+		inst.pdu = pdux.NewPolledPDU(p, pc.PollInterval, pc.Username, pc.Password, inst.onStatus)
+
+		schedulesPath := ""
+		if cfg.StateDir != "" {
+			schedulesPath = filepath.Join(cfg.StateDir, "schedules-"+pc.Name+".json")
+		}
+
+		if inst.scheduler, err = pdux.NewScheduler(pc, pdux.NewAuditedPDU(audit, pc.Name, inst.pdu, pdux.AuditSourceScheduler), cfg.SchedulesFor(pc.Name), schedulesPath); err != nil {
+			return err
+		}
+
+		inst.scheduler.Start()
+
+		inst.sequencer = pdux.NewSequencer(pc, pdux.NewAuditedPDU(audit, pc.Name, inst.pdu, pdux.AuditSourceSequencer), cfg.SequencesFor(pc.Name), inst.events)
+
+		inst.shedder = pdux.NewLoadShedder(pc, pdux.NewAuditedPDU(audit, pc.Name, inst.pdu, pdux.AuditSourceLoadShedding), cfg.LoadSheddingFor(pc.Name), inst.events)
+
+		inst.watchdog = pdux.NewWatchdog(pc, pdux.NewAuditedPDU(audit, pc.Name, inst.pdu, pdux.AuditSourceWatchdog), cfg.WatchdogsFor(pc.Name), inst.events)
+
+		if inst.alerter, err = pdux.NewAlerter(pc, &cfg.Alerts, cfg.AlertRulesFor(pc.Name), inst.events); err != nil {
+			return fmt.Errorf("failed to create alerter for PDU %s: %w", pc.Name, err)
+		}
+
+		if cfg.MQTT.Broker != "" {
+			if inst.mqtt, err = pdux.NewMQTTPublisher(&cfg.MQTT, pc, pdux.NewAuditedPDU(audit, pc.Name, inst.pdu, pdux.AuditSourceMQTT)); err != nil {
+				return fmt.Errorf("failed to create MQTT publisher for PDU %s: %w", pc.Name, err)
+			}
+		}
+
+		instances = append(instances, inst)
+
+		// The status callback uses the components of the instance
+		inst.pdu.Start(context.Background())
+	}
+
+	if cfg.SNMP.Listen != "" {
+		if snmp, err = pdux.NewSNMPAgent(&cfg.SNMP); err != nil {
+			return fmt.Errorf("failed to create SNMP agent: %w", err)
+		}
+
+		for _, i := range instances {
+			snmp.AddPDU(i.PDUConfig, i.pdu, audit)
+		}
+
+		if err := snmp.Start(); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-func onStatus(newSts *pdux.Status) {
-	prevSts := sts
+func (i *instance) onStatus(newSts *pdux.Status) {
+	prevSts := i.sts
+
+	newSts.Watchdogs = i.watchdog.Status()
 
 	if isFirst := prevSts == nil; isFirst {
-		if cfg.Metrics {
-			metrics = pdux.NewMetrics(newSts)
+		if i.energy != nil {
+			i.energy.Restore(newSts)
 		}
 
-		if _, err := daemonx.SdNotify(false, daemonx.SdNotifyReady); err != nil {
-			slog.Error("Failed to notify SystemD", slog.Any("error", err))
+		if cfg.Metrics {
+			i.metrics = pdux.NewMetrics(i.Name, newSts)
 		}
 	} else {
-		pdux.CalcEnergy(prevSts, newSts)
+		pdux.CalcEnergy(prevSts, newSts, maxEnergyGapPolls*i.pdu.PollInterval())
+	}
+
+	if i.energy != nil {
+		if err := i.energy.Update(newSts); err != nil {
+			slog.Error("Failed to save energy counters", slog.String("pdu", i.Name), slog.Any("error", err))
+		}
 	}
 
 	if cfg.Metrics {
-		metrics.Update(prevSts, newSts)
+		i.metrics.Update(prevSts, newSts)
+	}
+
+	if err := i.history.Add(newSts); err != nil {
+		slog.Error("Failed to record history", slog.String("pdu", i.Name), slog.Any("error", err))
+	}
+
+	if i.mqtt != nil {
+		i.mqtt.Publish(newSts)
 	}
 
-	sts = newSts
+	i.alerter.Update(newSts)
+	i.shedder.Update(newSts)
+
+	for _, e := range pdux.StatusEvents(prevSts, newSts, cfg.Events.BreakerThresholds) {
+		i.events.Publish(e)
+	}
+
+	i.sts = newSts
 }
 
 func postRun(cmd *cobra.Command, args []string) error {
-	if err := pdu.Close(); err != nil {
-		return fmt.Errorf("Failed to close PDF: %w", err)
+	errs := []error{}
+
+	if snmp != nil {
+		if err := snmp.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop SNMP agent: %w", err))
+		}
 	}
 
-	return nil
+	// Pending entries are recorded without waiting for the status after the operation
+	if audit != nil {
+		if err := audit.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close audit log: %w", err))
+		}
+	}
+
+	for _, i := range instances {
+		if err := i.scheduler.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop scheduler of PDU %s: %w", i.Name, err))
+		}
+
+		if err := i.sequencer.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop sequencer of PDU %s: %w", i.Name, err))
+		}
+
+		if err := i.shedder.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop load shedder of PDU %s: %w", i.Name, err))
+		}
+
+		if err := i.watchdog.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop watchdog of PDU %s: %w", i.Name, err))
+		}
+
+		if i.mqtt != nil {
+			if err := i.mqtt.Close(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to close MQTT publisher of PDU %s: %w", i.Name, err))
+			}
+		}
+
+		if err := i.pdu.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close PDU %s: %w", i.Name, err))
+		}
+
+		if err := i.alerter.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop alerter of PDU %s: %w", i.Name, err))
+		}
+
+		if i.energy != nil {
+			if err := i.energy.Save(); err != nil {
+				errs = append(errs, fmt.Errorf("failed to save energy counters of PDU %s: %w", i.Name, err))
+			}
+		}
+
+		if err := i.history.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close history of PDU %s: %w", i.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
-func daemon(_ *cobra.Command, _ []string) error {
+func daemon(cmd *cobra.Command, _ []string) error {
 	r := http.NewServeMux()
 
+	r.Handle("/healthz", healthHandler(alive))
+	r.Handle("/readyz", healthHandler(readiness))
+
 	if cfg.Metrics {
 		r.Handle("/metrics", promhttp.Handler())
+
+		reloadMetrics = pdux.NewReloadMetrics()
+		reloadMetrics.Update(nil)
 	}
 
 	if len(cfg.ACL) == 0 {
 		slog.Warn("No ACL provided. No access control checks will be performed!")
-	} else if err := cfg.ACL.Init(); err != nil {
-		return fmt.Errorf("failed to initialize ACL: %w", err)
 	}
 
-	h := pdux.Handler(r, pdu, cfg)
+	if err := cfg.InitAccess(); err != nil {
+		return err
+	}
 
-	var tc *tls.Config
-	if cfg.TLS.Cert == "" || cfg.TLS.Key == "" {
-		slog.Warn("No TLS configuration provided. API will be exposed unencrypted and unauthenticated!")
-	} else {
-		cer, err := tls.LoadX509KeyPair(cfg.TLS.Cert, cfg.TLS.Key)
-		if err != nil {
-			return fmt.Errorf("failed to load server key pair: %w", err)
+	if cfg.Redfish {
+		rf := pdux.NewRedfishService(cfg)
+		for _, i := range instances {
+			rf.AddPDU(i.PDUConfig, i.pdu, audit)
 		}
 
-		tc = &tls.Config{
-			Certificates: []tls.Certificate{cer},
-			ClientAuth:   tls.RequireAndVerifyClientCert,
-			CipherSuites: []uint16{
-				tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256,
-				tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
-			},
-			MinVersion: tls.VersionTLS13,
-		}
+		rf.Handler(r)
+	}
 
-		if cfg.TLS.CACert != "" {
-			caContents, err := os.ReadFile(cfg.TLS.CACert)
-			if err != nil {
-				return fmt.Errorf("failed to read CA: %w", err)
-			}
+	var h http.Handler
+	for n, i := range instances {
+		h = pdux.Handler(r, "/api/v1/pdus/"+i.Name, i.PDUConfig, i.pdu, cfg, i.events, i.history, i.scheduler, i.sequencer, i.alerter, audit)
 
-			tc.ClientCAs = x509.NewCertPool()
-			tc.ClientCAs.AppendCertsFromPEM(caContents)
+		// The first PDU is also served at the top-level for backwards compatibility
+		if n == 0 {
+			h = pdux.Handler(r, "/api/v1", i.PDUConfig, i.pdu, cfg, i.events, i.history, i.scheduler, i.sequencer, i.alerter, audit)
 		}
 	}
 
+	var tc *tls.Config
+	if cfg.TLS.Cert == "" || cfg.TLS.Key == "" {
+		if cfg.Auth.Enabled() {
+			slog.Warn("No TLS configuration provided. API will be exposed unencrypted and credentials are transmitted in plain text!")
+		} else {
+			slog.Warn("No TLS configuration provided. API will be exposed unencrypted and unauthenticated!")
+		}
+	} else {
+		if err := tlsConfig.load(cfg); err != nil {
+			return err
+		}
+
+		tc = tlsConfig.server()
+	}
+
 	s := &http.Server{
 		Handler:   h,
 		Addr:      cfg.Listen,
@@ -168,7 +374,31 @@ func daemon(_ *cobra.Command, _ []string) error {
 
 	slog.Info("Listening", slog.String("address", cfg.Listen))
 
-	return listenAndServe(s)
+	// Shutdown gracefully to persist state in postRun
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go watchConfig(ctx, cmd.Flags())
+	go notifyWatchdog(ctx)
+
+	go func() {
+		<-ctx.Done()
+
+		slog.Info("Shutting down")
+
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := s.Shutdown(ctx); err != nil {
+			s.Close()
+		}
+	}()
+
+	if err := listenAndServe(s); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
 }
 
 func listenAndServe(s *http.Server) error {
@@ -192,6 +422,11 @@ func listenAndServe(s *http.Server) error {
 		listeners = append(listeners, ln)
 	}
 
+	// Unreachable PDUs are reported by /readyz instead of delaying the start of the daemon
+	if _, err := daemonx.SdNotify(false, daemonx.SdNotifyReady); err != nil {
+		slog.Error("Failed to notify SystemD", slog.Any("error", err))
+	}
+
 	if s.TLSConfig != nil {
 		err = s.ServeTLS(listeners[0], "", "")
 	} else {
@@ -0,0 +1,365 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	daemonx "github.com/coreos/go-systemd/v22/daemon"
+	"github.com/fsnotify/fsnotify"
+	flag "github.com/spf13/pflag"
+	pdux "github.com/stv0g/pductl"
+)
+
+// Changes of watched files are collected for this duration before reloading
+const reloadDelay = time.Second
+
+var (
+	tlsConfig     reloadableTLSConfig
+	reloadMetrics *pdux.ReloadMetrics
+	reloadMutex   sync.Mutex
+)
+
+// reloadableTLSConfig serves the server certificate and client CAs which are replaced on reloads.
+// Established connections keep using the previous ones.
+type reloadableTLSConfig struct {
+	current atomic.Pointer[tls.Config]
+}
+
+// load reads the key pair and CA of the configuration.
+func (t *reloadableTLSConfig) load(c *pdux.Config) error {
+	tc, err := newTLSConfig(c)
+	if err != nil {
+		return err
+	}
+
+	t.current.Store(tc)
+
+	return nil
+}
+
+// server returns the configuration of the listener which looks up the current one for each handshake.
+func (t *reloadableTLSConfig) server() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS13,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return &t.current.Load().Certificates[0], nil
+		},
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return t.current.Load(), nil
+		},
+	}
+}
+
+func newTLSConfig(c *pdux.Config) (*tls.Config, error) {
+	cer, err := tls.LoadX509KeyPair(c.TLS.Cert, c.TLS.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server key pair: %w", err)
+	}
+
+	tc := &tls.Config{
+		Certificates: []tls.Certificate{cer},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+		},
+		MinVersion: tls.VersionTLS13,
+	}
+
+	// Clients without certificates authenticate by other methods
+	if c.Auth.Enabled() {
+		tc.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	if c.TLS.CACert != "" {
+		caContents, err := os.ReadFile(c.TLS.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA: %w", err)
+		}
+
+		tc.ClientCAs = x509.NewCertPool()
+		tc.ClientCAs.AppendCertsFromPEM(caContents)
+	}
+
+	return tc, nil
+}
+
+// reload parses and validates the configuration again and applies the ACL,
+// authentication, TLS certificates and poll intervals and command timeouts of the PDUs.
+// Nothing is changed if the new configuration is invalid.
+// Other changes are compared to the configuration at startup and logged as they require a restart.
+func reload(flags *flag.FlagSet) (*pdux.Config, error) {
+	reloadMutex.Lock()
+	defer reloadMutex.Unlock()
+
+	n, err := pdux.ParseConfig(flags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse configuration: %w", err)
+	}
+
+	tlsEnabled := cfg.TLS.Cert != "" && cfg.TLS.Key != ""
+
+	var tc *tls.Config
+	if n.TLS.Cert != "" && n.TLS.Key != "" {
+		if !tlsEnabled {
+			return nil, errors.New("enabling TLS requires a restart")
+		}
+
+		if tc, err = newTLSConfig(n); err != nil {
+			return nil, err
+		}
+	} else if tlsEnabled {
+		return nil, errors.New("disabling TLS requires a restart")
+	}
+
+	if err := cfg.ReloadAccess(n); err != nil {
+		return nil, err
+	}
+
+	if tc != nil {
+		tlsConfig.current.Store(tc)
+	}
+
+	for _, i := range instances {
+		pc, err := n.LookupPDU(i.Name)
+		if err != nil {
+			slog.Warn("Removing a PDU requires a restart", slog.String("pdu", i.Name))
+			continue
+		}
+
+		if pc.Address != i.Address {
+			slog.Warn("Changing the address of a PDU requires a restart", slog.String("pdu", i.Name))
+		}
+
+		if pc.Username != i.Username || pc.Password != i.Password {
+			slog.Warn("Changing the credentials of a PDU requires a restart", slog.String("pdu", i.Name))
+		}
+
+		if !maps.Equal(pc.Aliases, i.Aliases) {
+			slog.Warn("Changing the aliases of a PDU requires a restart", slog.String("pdu", i.Name))
+		}
+
+		if pc.Critical != i.Critical {
+			slog.Warn("Changing the critical outlets of a PDU requires a restart", slog.String("pdu", i.Name))
+		}
+
+		if interval := i.pdu.PollInterval(); pc.PollInterval != interval {
+			slog.Info("Changing poll interval", slog.String("pdu", i.Name), slog.Duration("old", interval), slog.Duration("new", pc.PollInterval))
+
+			if err := i.pdu.SetPollInterval(pc.PollInterval); err != nil {
+				slog.Error("Failed to change poll interval", slog.String("pdu", i.Name), slog.Any("error", err))
+			}
+		}
+
+		if pc.CommandTimeout != i.CommandTimeout {
+			slog.Info("Changing command timeout", slog.String("pdu", i.Name), slog.Duration("old", i.CommandTimeout), slog.Duration("new", pc.CommandTimeout))
+
+			i.driver.SetCommandTimeout(pc.CommandTimeout)
+		}
+
+		// Only the applied settings are stored as the others are still compared to the running ones.
+		// These fields are not read concurrently.
+		i.PollInterval = pc.PollInterval
+		i.CommandTimeout = pc.CommandTimeout
+	}
+
+	if len(n.PDUs) > len(instances) {
+		slog.Warn("Adding a PDU requires a restart")
+	}
+
+	if n.Listen != cfg.Listen {
+		slog.Warn("Changing the listen address requires a restart")
+	}
+
+	for _, c := range []struct {
+		name     string
+		old, new any
+	}{
+		{"alerts", cfg.Alerts, n.Alerts},
+		{"schedules", cfg.Schedules, n.Schedules},
+		{"sequences", cfg.Sequences, n.Sequences},
+		{"watchdogs", cfg.Watchdogs, n.Watchdogs},
+		{"load shedding policies", cfg.LoadShedding, n.LoadShedding},
+		{"MQTT settings", cfg.MQTT, n.MQTT},
+		{"SNMP settings", cfg.SNMP, n.SNMP},
+		{"history settings", cfg.History, n.History},
+		{"audit log settings", cfg.Audit, n.Audit},
+		{"event thresholds", cfg.Events, n.Events},
+		{"state directory", cfg.StateDir, n.StateDir},
+		{"metrics setting", cfg.Metrics, n.Metrics},
+		{"Redfish setting", cfg.Redfish, n.Redfish},
+	} {
+		if !configEqual(c.old, c.new) {
+			slog.Warn("Changing the " + c.name + " requires a restart")
+		}
+	}
+
+	return n, nil
+}
+
+// configEqual compares parts of two configurations by their settings.
+// Unexported fields which are derived from the settings at runtime are ignored.
+func configEqual(a, b any) bool {
+	ja, errA := json.Marshal(a)
+	jb, errB := json.Marshal(b)
+
+	return errA == nil && errB == nil && bytes.Equal(ja, jb)
+}
+
+// onReload reloads the configuration and reports the result.
+// It returns the new configuration or nil if the reload failed.
+func onReload(flags *flag.FlagSet, reason string) *pdux.Config {
+	slog.Info("Reloading configuration", slog.String("reason", reason))
+
+	daemonx.SdNotify(false, daemonx.SdNotifyReloading) //nolint:errcheck
+
+	n, err := reload(flags)
+	if err != nil {
+		slog.Error("Failed to reload configuration", slog.Any("error", err))
+	} else {
+		slog.Info("Reloaded configuration")
+	}
+
+	if reloadMetrics != nil {
+		reloadMetrics.Update(err)
+	}
+
+	daemonx.SdNotify(false, daemonx.SdNotifyReady) //nolint:errcheck
+
+	return n
+}
+
+// watchedFiles returns the configuration file and the files referenced by it which are reloaded.
+func watchedFiles(c *pdux.Config) map[string]bool {
+	files := map[string]bool{}
+
+	for _, f := range []string{
+		c.File(),
+		c.TLS.CACert,
+		c.TLS.Cert,
+		c.TLS.Key,
+		c.Auth.Htpasswd,
+		c.Auth.Htgroup,
+		c.Auth.JWT.JWKS,
+	} {
+		if f == "" {
+			continue
+		}
+
+		if abs, err := filepath.Abs(f); err == nil {
+			files[abs] = true
+		}
+	}
+
+	return files
+}
+
+// watchConfig reloads the configuration on SIGHUP or changes of the watched files until the context is canceled.
+func watchConfig(ctx context.Context, flags *flag.FlagSet) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var events <-chan fsnotify.Event
+	var errs <-chan error
+
+	// Directories are watched to catch files being replaced by editors or renamed into place
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		slog.Error("Failed to watch configuration files", slog.Any("error", err))
+	} else {
+		defer w.Close()
+
+		events = w.Events
+		errs = w.Errors
+	}
+
+	files := map[string]bool{}
+	dirs := map[string]bool{}
+
+	// The reloaded configuration may reference other files
+	watch := func(c *pdux.Config) {
+		if c == nil {
+			return
+		}
+
+		files = watchedFiles(c)
+
+		if w == nil {
+			return
+		}
+
+		newDirs := map[string]bool{}
+		for f := range files {
+			dir := filepath.Dir(f)
+			if newDirs[dir] {
+				continue
+			}
+
+			newDirs[dir] = true
+
+			if dirs[dir] {
+				continue
+			}
+
+			if err := w.Add(dir); err != nil {
+				slog.Error("Failed to watch configuration file", slog.String("file", f), slog.Any("error", err))
+				delete(newDirs, dir)
+			}
+		}
+
+		// Stop watching directories which do not contain referenced files anymore
+		for dir := range dirs {
+			if !newDirs[dir] {
+				if err := w.Remove(dir); err != nil {
+					slog.Error("Failed to stop watching directory", slog.String("dir", dir), slog.Any("error", err))
+				}
+			}
+		}
+
+		dirs = newDirs
+	}
+
+	watch(cfg)
+
+	delay := time.NewTimer(reloadDelay)
+	delay.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-hup:
+			watch(onReload(flags, "signal"))
+
+		case e := <-events:
+			if files[e.Name] && !e.Has(fsnotify.Chmod) {
+				delay.Reset(reloadDelay)
+			}
+
+		case err := <-errs:
+			slog.Error("Failed to watch configuration files", slog.Any("error", err))
+
+		case <-delay.C:
+			watch(onReload(flags, "file change"))
+		}
+	}
+}
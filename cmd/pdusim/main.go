@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+	"github.com/stv0g/pductl/baytech"
+)
+
+var (
+	listen      string
+	username    string
+	password    string
+	rebootDelay time.Duration
+	outletNames []string
+
+	// Commands
+	rootCmd = &cobra.Command{
+		Use:               "pdusim",
+		Short:             "A simulator for the serial console of Baytech MMP-14 PDUs",
+		DisableAutoGenTag: true,
+		RunE:              simulate,
+		SilenceUsage:      true,
+	}
+
+	genDocs = &cobra.Command{
+		Use:    "docs",
+		Short:  "Generate docs",
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := os.MkdirAll("./docs", 0o755); err != nil {
+				return err
+			}
+
+			return doc.GenMarkdownTree(rootCmd, "./docs")
+		},
+	}
+)
+
+func init() {
+	pf := rootCmd.PersistentFlags()
+
+	pf.StringVar(&listen, "listen", "tcp://localhost:4141", "Address of TCP socket (tcp://host:port) or path of pseudo-terminal symlink (pty:/path)")
+	pf.StringVar(&username, "username", "admin", "Username")
+	pf.StringVar(&password, "password", "admin", "Password")
+	pf.DurationVar(&rebootDelay, "reboot-delay", 5*time.Second, "Time an outlet stays off during a reboot")
+	pf.StringSliceVar(&outletNames, "outlet-names", nil, "Comma-separated list of outlet names")
+
+	rootCmd.AddCommand(genDocs)
+}
+
+func simulate(_ *cobra.Command, _ []string) error {
+	sim := baytech.NewSimulator(username, password)
+	sim.RebootDelay = rebootDelay
+
+	for i, name := range outletNames {
+		if err := sim.SetOutlet(i+1, name, 0.1+0.1*float64(i%10)); err != nil {
+			return err
+		}
+	}
+
+	u, err := url.Parse(listen)
+	if err != nil {
+		return fmt.Errorf("failed to parse listen address: %w", err)
+	}
+
+	switch u.Scheme {
+	case "tcp":
+		ln, err := net.Listen("tcp", u.Host)
+		if err != nil {
+			return fmt.Errorf("failed to listen: %w", err)
+		}
+
+		defer ln.Close()
+
+		slog.Info("Listening", slog.String("address", ln.Addr().String()))
+
+		return sim.Serve(ln)
+
+	case "pty":
+		return servePTY(sim, u.Opaque+u.Path)
+
+	default:
+		return fmt.Errorf("unsupported listen address: %s", listen)
+	}
+}
+
+func main() {
+	slog.SetLogLoggerLevel(slog.LevelDebug)
+
+	if err := rootCmd.Execute(); err != nil {
+		os.Exit(-1)
+	}
+}
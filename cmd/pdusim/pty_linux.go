@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"syscall"
+	"unsafe"
+
+	"github.com/stv0g/pductl/baytech"
+)
+
+func ioctl(fd, req, arg uintptr) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, req, arg); errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+func openPTY() (master, slave *os.File, err error) {
+	if master, err = os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0); err != nil {
+		return nil, nil, fmt.Errorf("failed to open pseudo-terminal: %w", err)
+	}
+
+	var unlock int32
+	if err := ioctl(master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock))); err != nil {
+		master.Close()
+		return nil, nil, fmt.Errorf("failed to unlock pseudo-terminal: %w", err)
+	}
+
+	var n uint32
+	if err := ioctl(master.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&n))); err != nil {
+		master.Close()
+		return nil, nil, fmt.Errorf("failed to get pseudo-terminal number: %w", err)
+	}
+
+	if slave, err = os.OpenFile(fmt.Sprintf("/dev/pts/%d", n), os.O_RDWR|syscall.O_NOCTTY, 0); err != nil {
+		master.Close()
+		return nil, nil, fmt.Errorf("failed to open pseudo-terminal: %w", err)
+	}
+
+	// Switch to raw mode so that the line discipline does not echo our output back to us
+	var t syscall.Termios
+	if err := ioctl(slave.Fd(), syscall.TCGETS, uintptr(unsafe.Pointer(&t))); err != nil {
+		return nil, nil, fmt.Errorf("failed to get terminal attributes: %w", err)
+	}
+
+	t.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK | syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL | syscall.IXON
+	t.Oflag &^= syscall.OPOST
+	t.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON | syscall.ISIG | syscall.IEXTEN
+	t.Cflag &^= syscall.CSIZE | syscall.PARENB
+	t.Cflag |= syscall.CS8
+
+	if err := ioctl(slave.Fd(), syscall.TCSETS, uintptr(unsafe.Pointer(&t))); err != nil {
+		return nil, nil, fmt.Errorf("failed to set terminal attributes: %w", err)
+	}
+
+	return master, slave, nil
+}
+
+func servePTY(sim *baytech.Simulator, link string) error {
+	master, slave, err := openPTY()
+	if err != nil {
+		return err
+	}
+
+	// We keep the slave side open ourself so that the master does not
+	// see a hangup when a client closes the port.
+	defer slave.Close()
+	defer master.Close()
+
+	if err := os.Remove(link); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove existing link: %w", err)
+	}
+
+	if err := os.Symlink(slave.Name(), link); err != nil {
+		return fmt.Errorf("failed to create link: %w", err)
+	}
+
+	defer os.Remove(link)
+
+	slog.Info("Listening", slog.String("pty", slave.Name()), slog.String("link", link))
+
+	return sim.ServeConn(master)
+}
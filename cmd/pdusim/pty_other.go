@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !linux
+
+package main
+
+import (
+	"errors"
+
+	"github.com/stv0g/pductl/baytech"
+)
+
+func servePTY(_ *baytech.Simulator, _ string) error {
+	return errors.New("pseudo-terminals are only supported on Linux")
+}
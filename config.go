@@ -4,22 +4,211 @@
 package pductl
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"regexp"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/mitchellh/mapstructure"
 	flag "github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"github.com/stv0g/pductl/internal/api"
 )
 
+var ErrUnknownPDU = errors.New("unknown PDU")
+
+var reName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// PDUConfig describes a single PDU managed by pdud.
+type PDUConfig struct {
+	Name           string            `mapstructure:"name"`
+	Address        string            `mapstructure:"address"`
+	Username       string            `mapstructure:"username"`
+	Password       string            `mapstructure:"password"`
+	PollInterval   time.Duration     `mapstructure:"poll_interval"`
+	CommandTimeout time.Duration     `mapstructure:"command_timeout"`
+	Critical       string            `mapstructure:"critical"`
+	Aliases        map[string]string `mapstructure:"aliases"`
+}
+
+// HistoryConfig configures the retention of past status samples.
+type HistoryConfig struct {
+	Retention  time.Duration `mapstructure:"retention"`
+	Resolution time.Duration `mapstructure:"resolution"`
+	Persist    bool          `mapstructure:"persist"`
+}
+
+// AuditConfig configures the audit log of state-changing API operations.
+type AuditConfig struct {
+	File          string `mapstructure:"file"`
+	Syslog        bool   `mapstructure:"syslog"`
+	SyslogAddress string `mapstructure:"syslog_address"`
+}
+
+// ScheduleConfig describes a scheduled outlet action.
+type ScheduleConfig struct {
+	Name    string    `mapstructure:"name"`
+	PDU     string    `mapstructure:"pdu"`
+	Cron    string    `mapstructure:"cron"`
+	At      time.Time `mapstructure:"at"`
+	Action  string    `mapstructure:"action"`
+	Outlets string    `mapstructure:"outlets"`
+}
+
+// Schedule converts the configuration into a schedule identified by its name.
+func (sc *ScheduleConfig) Schedule() Schedule {
+	s := Schedule{
+		ID:      sc.Name,
+		Name:    &sc.Name,
+		Action:  ScheduleAction(sc.Action),
+		Outlets: sc.Outlets,
+		Source:  api.Config,
+	}
+
+	if sc.Cron != "" {
+		s.Cron = &sc.Cron
+	}
+
+	if !sc.At.IsZero() {
+		s.At = &sc.At
+	}
+
+	return s
+}
+
+// SequenceStepConfig describes a group of outlets which are switched together within a sequence.
+type SequenceStepConfig struct {
+	Outlets     string        `mapstructure:"outlets"`
+	Delay       time.Duration `mapstructure:"delay"`
+	WaitCurrent float32       `mapstructure:"wait_current"`
+	Timeout     time.Duration `mapstructure:"timeout"`
+}
+
+// SequenceConfig describes an ordered list of steps for powering up or down outlets.
+type SequenceConfig struct {
+	Name  string               `mapstructure:"name"`
+	PDU   string               `mapstructure:"pdu"`
+	Steps []SequenceStepConfig `mapstructure:"steps"`
+}
+
+// LoadSheddingConfig describes a policy which switches off outlets in the order of their priority
+// while the current of a breaker or group exceeds the limit and restores them once the current fell.
+type LoadSheddingConfig struct {
+	Name         string        `mapstructure:"name"`
+	PDU          string        `mapstructure:"pdu"`
+	Breaker      string        `mapstructure:"breaker"`
+	Group        string        `mapstructure:"group"`
+	Limit        float32       `mapstructure:"limit"`
+	Restore      float32       `mapstructure:"restore"`
+	For          time.Duration `mapstructure:"for"`
+	RestoreAfter time.Duration `mapstructure:"restore_after"`
+	Outlets      []string      `mapstructure:"outlets"`
+}
+
+// WatchdogConfig describes a periodic check of a device whose outlets are rebooted
+// after a number of consecutive failed checks.
+type WatchdogConfig struct {
+	Name             string        `mapstructure:"name"`
+	PDU              string        `mapstructure:"pdu"`
+	Outlets          string        `mapstructure:"outlets"`
+	Type             string        `mapstructure:"type"`
+	Host             string        `mapstructure:"host"`
+	URL              string        `mapstructure:"url"`
+	Command          []string      `mapstructure:"command"`
+	Insecure         bool          `mapstructure:"insecure"`
+	Interval         time.Duration `mapstructure:"interval"`
+	Timeout          time.Duration `mapstructure:"timeout"`
+	Failures         int           `mapstructure:"failures"`
+	Cooldown         time.Duration `mapstructure:"cooldown"`
+	MaxRebootsPerDay int           `mapstructure:"max_reboots_per_day"`
+}
+
+// MQTTConfig configures the publishing of status updates to an MQTT broker.
+type MQTTConfig struct {
+	Broker          string         `mapstructure:"broker"`
+	ClientID        string         `mapstructure:"client_id"`
+	Username        string         `mapstructure:"username"`
+	Password        string         `mapstructure:"password"`
+	TopicPrefix     string         `mapstructure:"topic_prefix"`
+	Discovery       bool           `mapstructure:"discovery"`
+	DiscoveryPrefix string         `mapstructure:"discovery_prefix"`
+	Outlets         []OutletAccess `mapstructure:"outlets"`
+}
+
+// SNMPUserConfig describes an SNMPv3 user of the SNMP agent.
+type SNMPUserConfig struct {
+	Name           string `mapstructure:"name"`
+	AuthProtocol   string `mapstructure:"auth_protocol"`
+	AuthPassphrase string `mapstructure:"auth_passphrase"`
+	PrivProtocol   string `mapstructure:"priv_protocol"`
+	PrivPassphrase string `mapstructure:"priv_passphrase"`
+	Write          bool   `mapstructure:"write"`
+}
+
+// SNMPConfig configures the SNMP agent serving the status of all PDUs.
+type SNMPConfig struct {
+	Listen         string           `mapstructure:"listen"`
+	Community      string           `mapstructure:"community"`
+	WriteCommunity string           `mapstructure:"write_community"`
+	EngineID       string           `mapstructure:"engine_id"`
+	Users          []SNMPUserConfig `mapstructure:"users"`
+	Outlets        []OutletAccess   `mapstructure:"outlets"`
+}
+
+// AlertRuleConfig describes a condition on the status of a PDU which raises an alert.
+type AlertRuleConfig struct {
+	Name        string        `mapstructure:"name"`
+	PDU         string        `mapstructure:"pdu"`
+	Type        string        `mapstructure:"type"`
+	ID          string        `mapstructure:"id"`
+	Above       *float32      `mapstructure:"above"`
+	AboveRating float32       `mapstructure:"above_rating"`
+	Below       *float32      `mapstructure:"below"`
+	Hysteresis  float32       `mapstructure:"hysteresis"`
+	For         time.Duration `mapstructure:"for"`
+	ClearFor    time.Duration `mapstructure:"clear_for"`
+	Severity    string        `mapstructure:"severity"`
+}
+
+// AlertWebhookConfig describes an HTTP endpoint which receives alerts as JSON.
+type AlertWebhookConfig struct {
+	URL     string            `mapstructure:"url"`
+	Headers map[string]string `mapstructure:"headers"`
+}
+
+// AlertEmailConfig configures the delivery of alerts via an SMTP relay.
+type AlertEmailConfig struct {
+	SMTP string   `mapstructure:"smtp"`
+	From string   `mapstructure:"from"`
+	To   []string `mapstructure:"to"`
+}
+
+// AlertsConfig configures the alert rules and the delivery of alerts.
+type AlertsConfig struct {
+	Rules          []AlertRuleConfig    `mapstructure:"rules"`
+	BreakerRatings map[string]float32   `mapstructure:"breaker_ratings"`
+	Webhooks       []AlertWebhookConfig `mapstructure:"webhooks"`
+	Email          AlertEmailConfig     `mapstructure:"email"`
+}
+
 type Config struct {
-	Listen       string        `mapstructure:"listen"`
-	Address      string        `mapstructure:"address"`
-	Username     string        `mapstructure:"username"`
-	Password     string        `mapstructure:"password"`
-	PollInterval time.Duration `mapstructure:"poll_interval"`
-	Format       string        `mapstructure:"format"`
-	Metrics      bool          `mapstructure:"metrics"`
+	Listen         string        `mapstructure:"listen"`
+	PDU            string        `mapstructure:"pdu"`
+	Address        string        `mapstructure:"address"`
+	Username       string        `mapstructure:"username"`
+	Password       string        `mapstructure:"password"`
+	PollInterval   time.Duration `mapstructure:"poll_interval"`
+	CommandTimeout time.Duration `mapstructure:"command_timeout"`
+	Format         string        `mapstructure:"format"`
+	APIKey         string        `mapstructure:"api_key"`
+	Token          string        `mapstructure:"token"`
+	Critical       string        `mapstructure:"critical"`
+	Metrics        bool          `mapstructure:"metrics"`
+	Redfish        bool          `mapstructure:"redfish"`
+	StateDir       string        `mapstructure:"state_dir"`
 
 	TLS struct {
 		CACert   string `mapstructure:"cacert"`
@@ -28,7 +217,39 @@ type Config struct {
 		Insecure bool   `mapstructure:"insecure"`
 	} `mapstructure:"tls"`
 
-	ACL AccessControlList `mapstructure:"acl"`
+	Events struct {
+		BreakerThresholds map[string]float32 `mapstructure:"breaker_thresholds"`
+	} `mapstructure:"events"`
+
+	History HistoryConfig `mapstructure:"history"`
+	Audit   AuditConfig   `mapstructure:"audit"`
+	MQTT    MQTTConfig    `mapstructure:"mqtt"`
+	SNMP    SNMPConfig    `mapstructure:"snmp"`
+	Alerts  AlertsConfig  `mapstructure:"alerts"`
+
+	Auth    AuthConfig          `mapstructure:"auth"`
+	Roles   map[string][]string `mapstructure:"roles"`
+	ACL     AccessControlList   `mapstructure:"acl"`
+	Aliases map[string]string   `mapstructure:"aliases"`
+	PDUs    []PDUConfig         `mapstructure:"pdus"`
+
+	Schedules []ScheduleConfig `mapstructure:"schedules"`
+	Sequences []SequenceConfig `mapstructure:"sequences"`
+
+	LoadShedding []LoadSheddingConfig `mapstructure:"load_shedding"`
+	Watchdogs    []WatchdogConfig     `mapstructure:"watchdogs"`
+
+	file   string
+	access atomic.Pointer[Access]
+}
+
+// Access is the ACL and the authentication of API clients.
+// It is replaced atomically when the configuration is reloaded.
+type Access struct {
+	ACL  AccessControlList
+	Auth *AuthConfig
+
+	tls bool
 }
 
 func ParseConfig(flags *flag.FlagSet) (*Config, error) {
@@ -38,18 +259,46 @@ func ParseConfig(flags *flag.FlagSet) (*Config, error) {
 	v.SetDefault("password", "admin")
 	v.SetDefault("listen", ":8080")
 	v.SetDefault("format", "pretty-rounded")
+	v.SetDefault("poll_interval", 10*time.Second)
+	v.SetDefault("command_timeout", 30*time.Second)
 	v.SetDefault("metrics", true)
+	v.SetDefault("redfish", true)
+	v.SetDefault("state_dir", os.Getenv("STATE_DIRECTORY")) // Set by systemd's StateDirectory=
+	v.SetDefault("auth.jwt.claim", "sub")
+	v.SetDefault("auth.jwt.leeway", time.Minute)
+	v.SetDefault("auth.jwt.groups_claim", "groups")
+	v.SetDefault("history.retention", 24*time.Hour)
+	v.SetDefault("history.resolution", time.Minute)
+	v.SetDefault("mqtt.client_id", "pdud")
+	v.SetDefault("mqtt.topic_prefix", "pdud")
+	v.SetDefault("mqtt.discovery", true)
+	v.SetDefault("mqtt.discovery_prefix", "homeassistant")
+	v.SetDefault("snmp.community", "public")
+	v.SetDefault("alerts.email.smtp", "localhost:25")
+	v.SetDefault("alerts.email.from", "pdud@localhost")
+	v.SetDefault("alerts.breaker_ratings", map[string]float32{
+		"ckt1": 16,
+		"ckt2": 16,
+	})
+	v.SetDefault("events.breaker_thresholds", map[string]float32{
+		"ckt1": 16,
+		"ckt2": 16,
+	})
 
 	v.SetConfigType("yaml")
 
 	if flags != nil {
 		for _, key := range []string{
 			"listen",
+			"pdu",
 			"address",
 			"format",
 			"username",
 			"password",
+			"api_key",
+			"token",
 			"poll_interval",
+			"state_dir",
 			"tls.cacert",
 			"tls.cert",
 			"tls.key",
@@ -81,11 +330,456 @@ func ParseConfig(flags *flag.FlagSet) (*Config, error) {
 		}
 	}
 
-	c := &Config{}
+	c := &Config{
+		file: v.ConfigFileUsed(),
+	}
+
+	hook := viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		mapstructure.StringToSliceHookFunc(","),
+		mapstructure.StringToTimeHookFunc(time.RFC3339),
+	))
 
-	if err := v.Unmarshal(c); err != nil {
+	if err := v.Unmarshal(c, hook); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	if err := c.initPDUs(); err != nil {
+		return nil, err
+	}
+
+	if err := c.initSchedules(); err != nil {
+		return nil, err
+	}
+
+	if err := c.initSequences(); err != nil {
+		return nil, err
+	}
+
+	if err := c.initAlerts(); err != nil {
+		return nil, err
+	}
+
+	if err := c.initLoadShedding(); err != nil {
+		return nil, err
+	}
+
+	if err := c.initWatchdogs(); err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
+
+// File returns the path of the configuration file or an empty string if none was found.
+func (c *Config) File() string {
+	return c.file
+}
+
+// InitAccess compiles the ACL and loads the files of the authentication methods.
+func (c *Config) InitAccess() error {
+	if err := c.ACL.Init(c.Roles); err != nil {
+		return fmt.Errorf("failed to initialize ACL: %w", err)
+	}
+
+	if err := c.Auth.Init(); err != nil {
+		return fmt.Errorf("failed to initialize authentication: %w", err)
+	}
+
+	c.access.Store(&Access{
+		ACL:  c.ACL,
+		Auth: &c.Auth,
+		tls:  c.TLS.Cert != "" && c.TLS.Key != "",
+	})
+
+	return nil
+}
+
+// Access returns the current ACL and authentication.
+func (c *Config) Access() *Access {
+	if a := c.access.Load(); a != nil {
+		return a
+	}
+
+	return &Access{
+		ACL:  c.ACL,
+		Auth: &c.Auth,
+		tls:  c.TLS.Cert != "" && c.TLS.Key != "",
+	}
+}
+
+// ReloadAccess replaces the ACL and authentication by those of a newly parsed configuration.
+// Requests which are already being processed keep using the previous ones.
+func (c *Config) ReloadAccess(n *Config) error {
+	if err := n.InitAccess(); err != nil {
+		return err
+	}
+
+	c.access.Store(n.access.Load())
+
+	return nil
+}
+
+// Enabled returns true if clients are authenticated by certificates
+// or another authentication method and checked against the ACL.
+func (a *Access) Enabled() bool {
+	return len(a.ACL) > 0 && (a.tls || a.Auth.Enabled())
+}
+
+// initPDUs validates the list of PDUs and fills in missing settings from the top-level configuration.
+// A single PDU named "default" is used if no list is given.
+func (c *Config) initPDUs() error {
+	if len(c.PDUs) == 0 {
+		c.PDUs = []PDUConfig{
+			{
+				Name:    "default",
+				Address: c.Address,
+			},
+		}
+	}
+
+	names := map[string]bool{}
+
+	for i := range c.PDUs {
+		pc := &c.PDUs[i]
+
+		if !reName.MatchString(pc.Name) {
+			return fmt.Errorf("invalid PDU name: %q", pc.Name)
+		} else if names[pc.Name] {
+			return fmt.Errorf("duplicate PDU name: %s", pc.Name)
+		}
+
+		names[pc.Name] = true
+
+		if pc.Address == "" {
+			return fmt.Errorf("missing address for PDU: %s", pc.Name)
+		}
+
+		if pc.Username == "" {
+			pc.Username = c.Username
+		}
+
+		if pc.Password == "" {
+			pc.Password = c.Password
+		}
+
+		if pc.PollInterval == 0 {
+			pc.PollInterval = c.PollInterval
+		}
+
+		if pc.PollInterval <= 0 {
+			return fmt.Errorf("%w for PDU %s: %s", ErrInvalidPollInterval, pc.Name, pc.PollInterval)
+		}
+
+		if pc.CommandTimeout == 0 {
+			pc.CommandTimeout = c.CommandTimeout
+		}
+
+		if pc.Critical == "" {
+			pc.Critical = c.Critical
+		}
+
+		// PDU-specific aliases take precedence over global ones
+		aliases := map[string]string{}
+		for k, v := range c.Aliases {
+			aliases[k] = v
+		}
+
+		for k, v := range pc.Aliases {
+			aliases[k] = v
+		}
+
+		pc.Aliases = aliases
+	}
+
+	return nil
+}
+
+// initSchedules validates the scheduled actions.
+// Schedules without a PDU are assigned to the first PDU.
+func (c *Config) initSchedules() error {
+	names := map[string]bool{}
+
+	for i := range c.Schedules {
+		sc := &c.Schedules[i]
+
+		if !reName.MatchString(sc.Name) {
+			return fmt.Errorf("invalid schedule name: %q", sc.Name)
+		} else if names[sc.Name] {
+			return fmt.Errorf("duplicate schedule name: %s", sc.Name)
+		}
+
+		names[sc.Name] = true
+
+		pc, err := c.LookupPDU(sc.PDU)
+		if err != nil {
+			return fmt.Errorf("schedule %s: %w", sc.Name, err)
+		}
+
+		sc.PDU = pc.Name
+	}
+
+	return nil
+}
+
+// SchedulesFor returns the scheduled actions for a PDU.
+func (c *Config) SchedulesFor(name string) (scs []ScheduleConfig) {
+	for _, sc := range c.Schedules {
+		if sc.PDU == name {
+			scs = append(scs, sc)
+		}
+	}
+
+	return scs
+}
+
+// initSequences validates the power sequences.
+// Sequences without a PDU are assigned to the first PDU.
+func (c *Config) initSequences() error {
+	names := map[string]bool{}
+
+	for i := range c.Sequences {
+		sc := &c.Sequences[i]
+
+		if !reName.MatchString(sc.Name) {
+			return fmt.Errorf("invalid sequence name: %q", sc.Name)
+		} else if names[sc.Name] {
+			return fmt.Errorf("duplicate sequence name: %s", sc.Name)
+		}
+
+		names[sc.Name] = true
+
+		pc, err := c.LookupPDU(sc.PDU)
+		if err != nil {
+			return fmt.Errorf("sequence %s: %w", sc.Name, err)
+		}
+
+		sc.PDU = pc.Name
+
+		if len(sc.Steps) == 0 {
+			return fmt.Errorf("sequence %s: missing steps", sc.Name)
+		}
+
+		for j, step := range sc.Steps {
+			if step.Outlets == "" {
+				return fmt.Errorf("sequence %s: missing outlets in step %d", sc.Name, j+1)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SequencesFor returns the power sequences for a PDU.
+func (c *Config) SequencesFor(name string) (scs []SequenceConfig) {
+	for _, sc := range c.Sequences {
+		if sc.PDU == name {
+			scs = append(scs, sc)
+		}
+	}
+
+	return scs
+}
+
+// initAlerts validates the alert rules.
+// Rules without a PDU apply to all PDUs.
+func (c *Config) initAlerts() error {
+	names := map[string]bool{}
+
+	for i := range c.Alerts.Rules {
+		rc := &c.Alerts.Rules[i]
+
+		if !reName.MatchString(rc.Name) {
+			return fmt.Errorf("invalid alert rule name: %q", rc.Name)
+		} else if names[rc.Name] {
+			return fmt.Errorf("duplicate alert rule name: %s", rc.Name)
+		}
+
+		names[rc.Name] = true
+
+		if rc.PDU != "" {
+			if _, err := c.LookupPDU(rc.PDU); err != nil {
+				return fmt.Errorf("alert rule %s: %w", rc.Name, err)
+			}
+		}
+
+		if err := rc.validate(); err != nil {
+			return fmt.Errorf("alert rule %s: %w", rc.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// AlertRulesFor returns the alert rules for a PDU.
+func (c *Config) AlertRulesFor(name string) (rcs []AlertRuleConfig) {
+	for _, rc := range c.Alerts.Rules {
+		if rc.PDU == "" || rc.PDU == name {
+			rcs = append(rcs, rc)
+		}
+	}
+
+	return rcs
+}
+
+// initLoadShedding validates the load shedding policies.
+// Policies without a PDU apply to the first PDU.
+func (c *Config) initLoadShedding() error {
+	names := map[string]bool{}
+
+	for i := range c.LoadShedding {
+		lc := &c.LoadShedding[i]
+
+		if !reName.MatchString(lc.Name) {
+			return fmt.Errorf("invalid load shedding policy name: %q", lc.Name)
+		} else if names[lc.Name] {
+			return fmt.Errorf("duplicate load shedding policy name: %s", lc.Name)
+		}
+
+		names[lc.Name] = true
+
+		pc, err := c.LookupPDU(lc.PDU)
+		if err != nil {
+			return fmt.Errorf("load shedding policy %s: %w", lc.Name, err)
+		}
+
+		lc.PDU = pc.Name
+
+		if (lc.Breaker == "") == (lc.Group == "") {
+			return fmt.Errorf("load shedding policy %s: either breaker or group is required", lc.Name)
+		}
+
+		if lc.Limit <= 0 {
+			return fmt.Errorf("load shedding policy %s: missing limit", lc.Name)
+		}
+
+		if lc.Restore == 0 {
+			lc.Restore = loadSheddingDefaultRestore * lc.Limit
+		} else if lc.Restore >= lc.Limit {
+			return fmt.Errorf("load shedding policy %s: restore current must be below the limit", lc.Name)
+		}
+
+		if lc.RestoreAfter == 0 {
+			lc.RestoreAfter = loadSheddingDefaultRestoreAfter
+		}
+
+		if len(lc.Outlets) == 0 {
+			return fmt.Errorf("load shedding policy %s: missing outlets", lc.Name)
+		}
+	}
+
+	return nil
+}
+
+// LoadSheddingFor returns the load shedding policies for a PDU.
+func (c *Config) LoadSheddingFor(name string) (lcs []LoadSheddingConfig) {
+	for _, lc := range c.LoadShedding {
+		if lc.PDU == name {
+			lcs = append(lcs, lc)
+		}
+	}
+
+	return lcs
+}
+
+// LookupPDU returns the configuration of a PDU by its name.
+// The first PDU is returned if the name is empty.
+func (c *Config) LookupPDU(name string) (*PDUConfig, error) {
+	if name == "" {
+		return &c.PDUs[0], nil
+	}
+
+	for i := range c.PDUs {
+		if pc := &c.PDUs[i]; pc.Name == name {
+			return pc, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownPDU, name)
+}
+
+// initWatchdogs validates the watchdogs and fills in default settings.
+// Watchdogs without a PDU apply to the first PDU.
+func (c *Config) initWatchdogs() error {
+	names := map[string]bool{}
+
+	for i := range c.Watchdogs {
+		wc := &c.Watchdogs[i]
+
+		if !reName.MatchString(wc.Name) {
+			return fmt.Errorf("invalid watchdog name: %q", wc.Name)
+		} else if names[wc.Name] {
+			return fmt.Errorf("duplicate watchdog name: %s", wc.Name)
+		}
+
+		names[wc.Name] = true
+
+		pc, err := c.LookupPDU(wc.PDU)
+		if err != nil {
+			return fmt.Errorf("watchdog %s: %w", wc.Name, err)
+		}
+
+		wc.PDU = pc.Name
+
+		if wc.Outlets == "" {
+			return fmt.Errorf("watchdog %s: missing outlets", wc.Name)
+		}
+
+		switch wc.Type {
+		case WatchdogPing, WatchdogTCP:
+			if wc.Host == "" {
+				return fmt.Errorf("watchdog %s: missing host", wc.Name)
+			}
+
+		case WatchdogHTTP:
+			if wc.URL == "" {
+				return fmt.Errorf("watchdog %s: missing url", wc.Name)
+			}
+
+		case WatchdogExec:
+			if len(wc.Command) == 0 {
+				return fmt.Errorf("watchdog %s: missing command", wc.Name)
+			}
+
+		default:
+			return fmt.Errorf("watchdog %s: invalid type: %q", wc.Name, wc.Type)
+		}
+
+		if wc.Interval == 0 {
+			wc.Interval = watchdogDefaultInterval
+		}
+
+		if wc.Timeout == 0 {
+			wc.Timeout = min(watchdogDefaultTimeout, wc.Interval)
+		} else if wc.Timeout > wc.Interval {
+			return fmt.Errorf("watchdog %s: timeout must not exceed the interval", wc.Name)
+		}
+
+		if wc.Failures == 0 {
+			wc.Failures = watchdogDefaultFailures
+		} else if wc.Failures < 0 {
+			return fmt.Errorf("watchdog %s: invalid number of failures: %d", wc.Name, wc.Failures)
+		}
+
+		if wc.Cooldown == 0 {
+			wc.Cooldown = watchdogDefaultCooldown
+		}
+
+		if wc.MaxRebootsPerDay < 0 {
+			return fmt.Errorf("watchdog %s: invalid maximum number of reboots per day: %d", wc.Name, wc.MaxRebootsPerDay)
+		}
+	}
+
+	return nil
+}
+
+// WatchdogsFor returns the watchdogs for a PDU.
+func (c *Config) WatchdogsFor(name string) (wcs []WatchdogConfig) {
+	for _, wc := range c.Watchdogs {
+		if wc.PDU == name {
+			wcs = append(wcs, wc)
+		}
+	}
+
+	return wcs
+}
@@ -0,0 +1,182 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron expressions are not evaluated further into the future than this.
+const maxCronSearch = 5 * 366 * 24 * time.Hour
+
+var ErrInvalidCron = errors.New("invalid cron expression")
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var cronNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+// Cron is a parsed cron expression with the fields minute, hour, day of month, month and day of week.
+type Cron struct {
+	minute, hour, dom, month, dow uint64 // Bit sets of matching values
+
+	domAny, dowAny bool
+}
+
+// ParseCron parses a standard five-field cron expression or one of the descriptors @yearly,
+// @monthly, @weekly, @daily and @hourly. Fields support lists, ranges, steps as well as
+// month and weekday names.
+func ParseCron(expr string) (*Cron, error) {
+	expr = strings.TrimSpace(expr)
+	if d, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields: %s", ErrInvalidCron, expr)
+	}
+
+	c := &Cron{
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+
+	var err error
+	if c.minute, err = parseCronField(fields[0], 0, 59); err != nil {
+		return nil, err
+	} else if c.hour, err = parseCronField(fields[1], 0, 23); err != nil {
+		return nil, err
+	} else if c.dom, err = parseCronField(fields[2], 1, 31); err != nil {
+		return nil, err
+	} else if c.month, err = parseCronField(fields[3], 1, 12); err != nil {
+		return nil, err
+	} else if c.dow, err = parseCronField(fields[4], 0, 7); err != nil {
+		return nil, err
+	}
+
+	// Sunday is either 0 or 7
+	if c.dow&(1<<7) != 0 {
+		c.dow |= 1
+	}
+
+	return c, nil
+}
+
+func parseCronField(field string, lo, hi int) (bits uint64, err error) {
+	for _, term := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(term, "/")
+
+		step := 1
+		if hasStep {
+			if step, err = strconv.Atoi(stepStr); err != nil || step < 1 {
+				return 0, fmt.Errorf("%w: invalid step: %s", ErrInvalidCron, term)
+			}
+		}
+
+		first, last := lo, hi
+		if rng != "*" {
+			firstStr, lastStr, isRange := strings.Cut(rng, "-")
+
+			if first, err = parseCronValue(firstStr, lo, hi); err != nil {
+				return 0, err
+			}
+
+			last = first
+			if isRange {
+				if last, err = parseCronValue(lastStr, lo, hi); err != nil {
+					return 0, err
+				}
+			} else if hasStep {
+				last = hi
+			}
+
+			if first > last {
+				return 0, fmt.Errorf("%w: invalid range: %s", ErrInvalidCron, term)
+			}
+		}
+
+		for v := first; v <= last; v += step {
+			bits |= 1 << v
+		}
+	}
+
+	return bits, nil
+}
+
+func parseCronValue(s string, lo, hi int) (int, error) {
+	v, ok := cronNames[strings.ToLower(s)]
+	if !ok {
+		var err error
+		if v, err = strconv.Atoi(s); err != nil {
+			return 0, fmt.Errorf("%w: invalid value: %s", ErrInvalidCron, s)
+		}
+	}
+
+	if v < lo || v > hi {
+		return 0, fmt.Errorf("%w: value out of range: %s", ErrInvalidCron, s)
+	}
+
+	return v, nil
+}
+
+// Next returns the first time after t matching the expression.
+// The zero time is returned if there is no such time.
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	end := t.Add(maxCronSearch)
+
+	for t.Before(end) {
+		switch {
+		case c.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+
+		case c.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+
+		case c.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// matchDay matches either the day of month or the day of week if both are restricted.
+func (c *Cron) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<uint(t.Day())) != 0
+	dow := c.dow&(1<<uint(t.Weekday())) != 0
+
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	}
+
+	return dom || dow
+}
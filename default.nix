@@ -19,6 +19,11 @@ buildGoModule {
       --bash <($out/bin/pdud completion bash) \
       --fish <($out/bin/pdud completion fish) \
       --zsh <($out/bin/pdud completion zsh)
+
+    installShellCompletion --cmd pdusim \
+      --bash <($out/bin/pdusim completion bash) \
+      --fish <($out/bin/pdusim completion fish) \
+      --zsh <($out/bin/pdusim completion zsh)
   '';
 
   meta = {
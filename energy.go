@@ -0,0 +1,149 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const energySaveInterval = time.Minute
+
+// energyState is the persisted state of the energy counters of a PDU.
+type energyState struct {
+	Timestamp   time.Time       `json:"timestamp"`
+	TotalEnergy float32         `json:"total_energy"`
+	Groups      map[int]float32 `json:"groups"`
+	Outlets     map[int]float32 `json:"outlets"`
+}
+
+// EnergyStore persists the accumulated energy of groups and outlets
+// so that the counters continue after a restart.
+type EnergyStore struct {
+	path     string
+	state    *energyState
+	lastSave time.Time
+	mu       sync.Mutex
+}
+
+// NewEnergyStore loads the persisted energy counters from the file at path.
+// A missing file is not an error.
+func NewEnergyStore(path string) (*EnergyStore, error) {
+	s := &EnergyStore{
+		path: path,
+	}
+
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return s, nil
+		}
+
+		return nil, fmt.Errorf("failed to read energy state: %w", err)
+	}
+
+	s.state = &energyState{}
+	if err := json.Unmarshal(buf, s.state); err != nil {
+		return nil, fmt.Errorf("failed to decode energy state: %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Restore initializes the energy of groups and outlets in the first status after a start.
+// The energy consumed while pdud was not running is not accounted for.
+func (s *EnergyStore) Restore(sts *Status) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.state == nil {
+		return
+	}
+
+	for i, g := range sts.Groups {
+		sts.Groups[i].Energy = s.state.Groups[g.ID]
+	}
+
+	for i, o := range sts.Outlets {
+		sts.Outlets[i].Energy = s.state.Outlets[o.ID]
+	}
+
+	slog.Info("Restored energy counters",
+		slog.String("path", s.path),
+		slog.Time("saved", s.state.Timestamp),
+		slog.Any("total_energy_since", sts.TotalEnergy-s.state.TotalEnergy))
+}
+
+// Update records the energy counters of a new status and saves them periodically.
+func (s *EnergyStore) Update(sts *Status) error {
+	state := &energyState{
+		Timestamp:   sts.Timestamp,
+		TotalEnergy: sts.TotalEnergy,
+		Groups:      map[int]float32{},
+		Outlets:     map[int]float32{},
+	}
+
+	for _, g := range sts.Groups {
+		state.Groups[g.ID] = g.Energy
+	}
+
+	for _, o := range sts.Outlets {
+		state.Outlets[o.ID] = o.Energy
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.state = state
+
+	if time.Since(s.lastSave) < energySaveInterval {
+		return nil
+	}
+
+	return s.save()
+}
+
+// Save writes the energy counters to the state file.
+// The file is replaced atomically to survive crashes during writing.
+func (s *EnergyStore) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.save()
+}
+
+func (s *EnergyStore) save() error {
+	if s.state == nil {
+		return nil
+	}
+
+	buf, err := json.MarshalIndent(s.state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o644); err != nil {
+		return fmt.Errorf("failed to write energy state: %w", err)
+	}
+
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write energy state: %w", err)
+	}
+
+	s.lastSave = time.Now()
+
+	return nil
+}
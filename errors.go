@@ -0,0 +1,166 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/stv0g/pductl/internal/api"
+)
+
+type ErrorCode = api.ErrorCode
+
+// Error codes for the errors of PDU drivers
+const (
+	ErrorCodeDecodeFailed     = api.ErrorCodeDecodeFailed
+	ErrorCodeDisconnected     = api.ErrorCodeDisconnected
+	ErrorCodeTimeout          = api.ErrorCodeTimeout
+	ErrorCodeConnectionClosed = api.ErrorCodeConnectionClosed
+)
+
+var (
+	ErrInvalidRequest = errors.New("invalid request")
+	ErrNotEnabled     = errors.New("not enabled")
+)
+
+// Error is an error returned by the REST API.
+// It wraps the sentinel error identified by its code so that
+// clients can check it with errors.Is.
+type Error struct {
+	Code       ErrorCode
+	Message    string
+	Outlet     string
+	Retryable  bool
+	StatusCode int
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// API returns the representation of the error in the REST API.
+func (e *Error) API() api.Error {
+	ae := api.Error{
+		Code:      e.Code,
+		Message:   e.Message,
+		Retryable: e.Retryable,
+		Error:     e.Message,
+	}
+
+	if e.Outlet != "" {
+		ae.Outlet = &e.Outlet
+	}
+
+	return ae
+}
+
+// errorKind maps a sentinel error to its code and HTTP status code.
+type errorKind struct {
+	err        error
+	code       ErrorCode
+	statusCode int
+	retryable  bool
+}
+
+// Sentinel errors in the order in which they are matched.
+// Drivers add their own errors with RegisterError.
+var errorKinds = []errorKind{
+	{ErrInvalidRequest, api.ErrorCodeInvalidRequest, http.StatusBadRequest, false},
+	{ErrNotEnabled, api.ErrorCodeNotEnabled, http.StatusNotImplemented, false},
+	{ErrMissingClientCert, api.ErrorCodeMissingClientCertificate, http.StatusUnauthorized, false},
+	{ErrMissingCredentials, api.ErrorCodeMissingCredentials, http.StatusUnauthorized, false},
+	{ErrInvalidCredentials, api.ErrorCodeInvalidCredentials, http.StatusUnauthorized, false},
+	{ErrAccessDenied, api.ErrorCodeAccessDenied, http.StatusForbidden, false},
+	{ErrUnknownPDU, api.ErrorCodeUnknownPDU, http.StatusNotFound, false},
+	{ErrNotFound, api.ErrorCodeOutletNotFound, http.StatusNotFound, false},
+	{ErrInvalidOutletID, api.ErrorCodeInvalidOutletID, http.StatusBadRequest, false},
+	{ErrAliasLoop, api.ErrorCodeAliasLoop, http.StatusBadRequest, false},
+	{ErrUserNotFound, api.ErrorCodeUserNotFound, http.StatusNotFound, false},
+	{ErrRejected, api.ErrorCodeRejected, http.StatusBadRequest, false},
+	{ErrOutletLocked, api.ErrorCodeOutletLocked, http.StatusConflict, false},
+	{ErrLoginRequired, api.ErrorCodeLoginRequired, http.StatusServiceUnavailable, true},
+	{ErrInvalidPassword, api.ErrorCodeInvalidPassword, http.StatusBadGateway, false},
+	{ErrNotPolledYet, api.ErrorCodeNotPolledYet, http.StatusServiceUnavailable, true},
+	{ErrClosed, api.ErrorCodePDUClosed, http.StatusServiceUnavailable, true},
+	{ErrInvalidTimeRange, api.ErrorCodeInvalidTimeRange, http.StatusBadRequest, false},
+	{ErrInvalidSchedule, api.ErrorCodeInvalidSchedule, http.StatusBadRequest, false},
+	{ErrInvalidCron, api.ErrorCodeInvalidCron, http.StatusBadRequest, false},
+	{ErrScheduleNotFound, api.ErrorCodeScheduleNotFound, http.StatusNotFound, false},
+	{ErrScheduleReadOnly, api.ErrorCodeScheduleReadOnly, http.StatusBadRequest, false},
+	{ErrSequenceNotFound, api.ErrorCodeSequenceNotFound, http.StatusNotFound, false},
+	{ErrSequenceRunning, api.ErrorCodeSequenceRunning, http.StatusConflict, true},
+}
+
+// RegisterError maps a sentinel error of a PDU driver to an error code of the REST API.
+// It must be called during initialization.
+func RegisterError(err error, code ErrorCode, statusCode int, retryable bool) {
+	errorKinds = append(errorKinds, errorKind{err, code, statusCode, retryable})
+}
+
+// NewError classifies an error by the first sentinel error which it wraps.
+// Unknown errors are reported as internal errors.
+func NewError(err error) *Error {
+	if ae := (*Error)(nil); errors.As(err, &ae) {
+		e := *ae
+		e.Message = err.Error()
+
+		return &e
+	}
+
+	e := &Error{
+		Code:       api.ErrorCodeInternal,
+		Message:    err.Error(),
+		StatusCode: http.StatusInternalServerError,
+
+		err: err,
+	}
+
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			e.Code = k.code
+			e.StatusCode = k.statusCode
+			e.Retryable = k.retryable
+
+			break
+		}
+	}
+
+	return e
+}
+
+// ErrorFromAPI reconstructs an error returned by the REST API
+// including the sentinel error identified by its code.
+func ErrorFromAPI(statusCode int, ae *api.Error) *Error {
+	e := &Error{
+		Code:       ae.Code,
+		Message:    ae.Message,
+		Retryable:  ae.Retryable,
+		StatusCode: statusCode,
+	}
+
+	// Older servers only return a message
+	if e.Message == "" {
+		e.Message = ae.Error
+	}
+
+	if ae.Outlet != nil {
+		e.Outlet = *ae.Outlet
+	}
+
+	for _, k := range errorKinds {
+		if k.code == e.Code {
+			e.err = k.err
+			break
+		}
+	}
+
+	return e
+}
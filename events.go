@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/stv0g/pductl/internal/api"
+)
+
+type (
+	Event     = api.Event
+	EventType = api.EventType
+)
+
+const (
+	EventStatus           = api.EventTypeStatus
+	EventOutletState      = api.EventTypeOutletState
+	EventOutletLock       = api.EventTypeOutletLock
+	EventBreakerThreshold = api.EventTypeBreakerThreshold
+
+	eventQueueLength = 16
+)
+
+// EventSource is implemented by PDUs which can stream events.
+type EventSource interface {
+	Events(ctx context.Context, cb func(*Event) error) error
+}
+
+// EventBroker distributes events to all of its subscribers.
+type EventBroker struct {
+	subscribers map[chan *Event]struct{}
+	mu          sync.Mutex
+}
+
+func NewEventBroker() *EventBroker {
+	return &EventBroker{
+		subscribers: map[chan *Event]struct{}{},
+	}
+}
+
+// Subscribe returns a channel receiving all published events
+// and a function to cancel the subscription.
+func (b *EventBroker) Subscribe() (<-chan *Event, func()) {
+	ch := make(chan *Event, eventQueueLength)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// Publish passes an event to all subscribers.
+// Events are dropped for subscribers which do not keep up.
+func (b *EventBroker) Publish(e *Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			slog.Warn("Dropped event for slow subscriber", slog.Any("type", e.Type))
+		}
+	}
+}
+
+// StatusEvents returns all change events between the previous and the new status
+// followed by the status event for the new status.
+func StatusEvents(prevSts, newSts *Status, thresholds map[string]float32) []*Event {
+	events := []*Event{}
+
+	if prevSts != nil {
+		events = changeEvents(prevSts, newSts, thresholds)
+	}
+
+	return append(events, &Event{
+		Type:      EventStatus,
+		Timestamp: newSts.Timestamp,
+		Status:    newSts,
+	})
+}
+
+func changeEvents(prevSts, newSts *Status, thresholds map[string]float32) (events []*Event) {
+	for i := range newSts.Outlets {
+		if i >= len(prevSts.Outlets) {
+			break
+		}
+
+		prevOutlet := prevSts.Outlets[i]
+		newOutlet := newSts.Outlets[i]
+
+		if prevOutlet.State != newOutlet.State {
+			events = append(events, &Event{
+				Type:      EventOutletState,
+				Timestamp: newSts.Timestamp,
+				Outlet:    &newOutlet,
+			})
+		}
+
+		if prevOutlet.Locked != newOutlet.Locked {
+			events = append(events, &Event{
+				Type:      EventOutletLock,
+				Timestamp: newSts.Timestamp,
+				Outlet:    &newOutlet,
+			})
+		}
+	}
+
+	for i := range newSts.Breakers {
+		if i >= len(prevSts.Breakers) {
+			break
+		}
+
+		prevBreaker := prevSts.Breakers[i]
+		newBreaker := newSts.Breakers[i]
+
+		// Viper lower-cases all map keys
+		threshold, ok := thresholds[strings.ToLower(newBreaker.Name)]
+		if !ok {
+			continue
+		}
+
+		wasExceeded := prevBreaker.TrueRMSCurrent > threshold
+		isExceeded := newBreaker.TrueRMSCurrent > threshold
+
+		if wasExceeded != isExceeded {
+			events = append(events, &Event{
+				Type:      EventBreakerThreshold,
+				Timestamp: newSts.Timestamp,
+				Breaker:   &newBreaker,
+				Threshold: &threshold,
+				Exceeded:  &isExceeded,
+			})
+		}
+	}
+
+	return events
+}
@@ -14,6 +14,9 @@ var (
 	ErrInvalidOutletID = errors.New("invalid outlet ID")
 	ErrLoginRequired   = errors.New("login required")
 	ErrInvalidPassword = errors.New("invalid password")
+	ErrUserNotFound    = errors.New("failed to find user")
+	ErrRejected        = errors.New("rejected by PDU")
+	ErrOutletLocked    = errors.New("outlet is locked")
 )
 
 var (
@@ -0,0 +1,368 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+var ErrInvalidTimeRange = errors.New("invalid time range")
+
+// HistoryPDU is implemented by PDUs which retain past status samples.
+type HistoryPDU interface {
+	History(from, to time.Time, step time.Duration, id string) ([]Status, error)
+	HistoryContext(ctx context.Context, from, to time.Time, step time.Duration, id string) ([]Status, error)
+}
+
+// History retains past status samples in a ring buffer.
+// Samples are downsampled to the configured resolution and
+// optionally appended to a file to survive restarts.
+type History struct {
+	retention  time.Duration
+	resolution time.Duration
+
+	samples []Status // Ring buffer
+	first   int
+	count   int
+
+	path    string
+	file    *os.File
+	written int
+
+	mu sync.RWMutex
+}
+
+// NewHistory creates a new history for samples polled at the given interval.
+// Samples are persisted to the file at path unless it is empty.
+func NewHistory(cfg HistoryConfig, interval time.Duration, path string) (*History, error) {
+	size := int(cfg.Retention/max(cfg.Resolution, interval)) + 1
+
+	h := &History{
+		retention:  cfg.Retention,
+		resolution: cfg.Resolution,
+		samples:    make([]Status, size),
+		path:       path,
+	}
+
+	if path != "" {
+		if err := h.load(); err != nil {
+			return nil, err
+		}
+
+		if err := h.compact(); err != nil {
+			return nil, err
+		}
+	}
+
+	return h, nil
+}
+
+// Add appends a new sample unless another sample has already been recorded
+// within the same resolution interval.
+func (h *History) Add(sts *Status) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.count > 0 && h.resolution > 0 {
+		last := h.at(h.count - 1)
+		if sts.Timestamp.Truncate(h.resolution).Equal(last.Timestamp.Truncate(h.resolution)) {
+			return nil
+		}
+	}
+
+	sample := *sts
+	sample.Connection = nil
+
+	h.push(sample)
+
+	if h.file == nil {
+		return nil
+	}
+
+	// Rewrite the file once it contains twice as many samples as the ring buffer
+	if h.written >= 2*len(h.samples) {
+		return h.compact()
+	}
+
+	return h.append(&sample)
+}
+
+// Query returns all samples between from and to.
+// If step is non-zero, the samples are averaged over intervals of the step size.
+// If id is not empty, only the outlets selected by the outlet expression are included.
+func (h *History) Query(from, to time.Time, step time.Duration, id string) ([]Status, error) {
+	if to.Before(from) || step < 0 {
+		return nil, ErrInvalidTimeRange
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	var ids map[int]bool
+	if id != "" && h.count > 0 {
+		outlets, err := ResolveOutlets(id, h.at(h.count-1).Outlets)
+		if err != nil {
+			return nil, err
+		}
+
+		ids = map[int]bool{}
+		for _, o := range outlets {
+			ids[o.ID] = true
+		}
+	}
+
+	samples := []Status{}
+	bucket := []*Status{}
+	bucketStart := time.Time{}
+
+	flush := func() {
+		if len(bucket) > 0 {
+			sample := averageStatus(bucket)
+			sample.Timestamp = bucketStart
+			samples = append(samples, sample)
+			bucket = bucket[:0]
+		}
+	}
+
+	for i := range h.count {
+		s := h.at(i)
+		if s.Timestamp.Before(from) || s.Timestamp.After(to) {
+			continue
+		}
+
+		sample := *s
+		if ids != nil {
+			sample.Outlets = []OutletStatus{}
+			for _, o := range s.Outlets {
+				if ids[o.ID] {
+					sample.Outlets = append(sample.Outlets, o)
+				}
+			}
+		}
+
+		if step == 0 {
+			samples = append(samples, sample)
+			continue
+		}
+
+		start := from.Add(s.Timestamp.Sub(from).Truncate(step))
+		if !start.Equal(bucketStart) {
+			flush()
+			bucketStart = start
+		}
+
+		bucket = append(bucket, &sample)
+	}
+
+	flush()
+
+	return samples, nil
+}
+
+// Close closes the history file.
+func (h *History) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.file == nil {
+		return nil
+	}
+
+	return h.file.Close()
+}
+
+// at returns the i-th oldest sample. The caller must hold h.mu.
+func (h *History) at(i int) *Status {
+	return &h.samples[(h.first+i)%len(h.samples)]
+}
+
+// push adds a sample to the ring buffer and overwrites the oldest one if it is full.
+// The caller must hold h.mu.
+func (h *History) push(sts Status) {
+	if h.count < len(h.samples) {
+		h.samples[(h.first+h.count)%len(h.samples)] = sts
+		h.count++
+	} else {
+		h.samples[h.first] = sts
+		h.first = (h.first + 1) % len(h.samples)
+	}
+
+	// Drop samples exceeding the retention
+	for h.count > 0 && sts.Timestamp.Sub(h.at(0).Timestamp) > h.retention {
+		h.first = (h.first + 1) % len(h.samples)
+		h.count--
+	}
+}
+
+// load reads samples from the history file. The caller must hold h.mu.
+func (h *History) load() error {
+	f, err := os.Open(h.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+
+	for scanner.Scan() {
+		sts := Status{}
+		if err := json.Unmarshal(scanner.Bytes(), &sts); err != nil {
+			slog.Warn("Skipping invalid history sample", slog.String("path", h.path), slog.Any("error", err))
+			continue
+		}
+
+		if time.Since(sts.Timestamp) <= h.retention {
+			h.push(sts)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read history: %w", err)
+	}
+
+	slog.Info("Loaded history", slog.String("path", h.path), slog.Int("samples", h.count))
+
+	return nil
+}
+
+// compact rewrites the history file with the samples of the ring buffer.
+// The caller must hold h.mu.
+func (h *History) compact() error {
+	if h.file != nil {
+		h.file.Close()
+		h.file = nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(h.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp := h.path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return fmt.Errorf("failed to create history: %w", err)
+	}
+
+	wr := bufio.NewWriter(f)
+	enc := json.NewEncoder(wr)
+
+	for i := range h.count {
+		if err := enc.Encode(h.at(i)); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write history: %w", err)
+		}
+	}
+
+	if err := wr.Flush(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	if err := os.Rename(tmp, h.path); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	if h.file, err = os.OpenFile(h.path, os.O_WRONLY|os.O_APPEND, 0o644); err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+
+	h.written = h.count
+
+	return nil
+}
+
+// append writes a single sample to the end of the history file.
+// The caller must hold h.mu.
+func (h *History) append(sts *Status) error {
+	buf, err := json.Marshal(sts)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.file.Write(append(buf, '\n')); err != nil {
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+
+	h.written++
+
+	return nil
+}
+
+// averageStatus returns the average of multiple samples.
+// Measurements are averaged while energy counters and states are taken from the last sample.
+func averageStatus(samples []*Status) Status {
+	last := samples[len(samples)-1]
+	avg := *last
+	n := float32(len(samples))
+
+	avg.Breakers = append([]BreakerStatus{}, last.Breakers...)
+	avg.Groups = append([]GroupStatus{}, last.Groups...)
+	avg.Outlets = append([]OutletStatus{}, last.Outlets...)
+	avg.Temperature = 0
+
+	for i := range avg.Breakers {
+		avg.Breakers[i].TrueRMSCurrent = 0
+	}
+
+	for i := range avg.Groups {
+		avg.Groups[i].TrueRMSCurrent = 0
+		avg.Groups[i].TrueRMSVoltage = 0
+		avg.Groups[i].AveragePower = 0
+		avg.Groups[i].Power = 0
+	}
+
+	for i := range avg.Outlets {
+		avg.Outlets[i].TrueRMSCurrent = 0
+		avg.Outlets[i].TrueRMSVoltage = 0
+		avg.Outlets[i].AveragePower = 0
+		avg.Outlets[i].Power = 0
+	}
+
+	for _, s := range samples {
+		avg.Temperature += s.Temperature / n
+
+		for i := range min(len(s.Breakers), len(avg.Breakers)) {
+			avg.Breakers[i].TrueRMSCurrent += s.Breakers[i].TrueRMSCurrent / n
+		}
+
+		for i := range min(len(s.Groups), len(avg.Groups)) {
+			g := &s.Groups[i]
+			avg.Groups[i].TrueRMSCurrent += g.TrueRMSCurrent / n
+			avg.Groups[i].TrueRMSVoltage += g.TrueRMSVoltage / n
+			avg.Groups[i].AveragePower += g.AveragePower / n
+			avg.Groups[i].Power += g.Power / n
+		}
+
+		for i := range min(len(s.Outlets), len(avg.Outlets)) {
+			o := &s.Outlets[i]
+			avg.Outlets[i].TrueRMSCurrent += o.TrueRMSCurrent / n
+			avg.Outlets[i].TrueRMSVoltage += o.TrueRMSVoltage / n
+			avg.Outlets[i].AveragePower += o.AveragePower / n
+			avg.Outlets[i].Power += o.Power / n
+		}
+	}
+
+	return avg
+}
@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/GehirnInc/crypt"
+	_ "github.com/GehirnInc/crypt/apr1_crypt"   // Register $apr1$
+	_ "github.com/GehirnInc/crypt/md5_crypt"    // Register $1$
+	_ "github.com/GehirnInc/crypt/sha256_crypt" // Register $5$
+	_ "github.com/GehirnInc/crypt/sha512_crypt" // Register $6$
+	"golang.org/x/crypto/bcrypt"
+)
+
+var ErrUnsupportedHash = errors.New("unsupported password hash")
+
+// htpasswd maps user names to password hashes as created by the htpasswd tool of Apache.
+type htpasswd map[string]string
+
+// loadHtpasswd reads an htpasswd file.
+// Supported are bcrypt (-B), MD5 (-m), SHA-1 (-s), SHA-256 (-2) and SHA-512 (-5) hashes.
+func loadHtpasswd(path string) (htpasswd, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open htpasswd file: %w", err)
+	}
+
+	defer f.Close()
+
+	users := htpasswd{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		username, hash, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid line in htpasswd file: %s", line)
+		}
+
+		if !supportedPasswordHash(hash) {
+			return nil, fmt.Errorf("%w of user %s in htpasswd file", ErrUnsupportedHash, username)
+		}
+
+		if strings.HasPrefix(hash, "{SHA}") {
+			slog.Warn("Password hash in htpasswd file is unsalted, use bcrypt instead", slog.String("user", username))
+		}
+
+		users[username] = hash
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read htpasswd file: %w", err)
+	}
+
+	return users, nil
+}
+
+// htgroup maps user names to their groups as listed in an AuthGroupFile of Apache.
+type htgroup map[string][]string
+
+// loadHtgroup reads a group file with lines like "admins: alice bob".
+func loadHtgroup(path string) (htgroup, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open htgroup file: %w", err)
+	}
+
+	defer f.Close()
+
+	groups := htgroup{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		group, users, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid line in htgroup file: %s", line)
+		}
+
+		for _, user := range strings.Fields(users) {
+			groups[user] = append(groups[user], strings.TrimSpace(group))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read htgroup file: %w", err)
+	}
+
+	return groups, nil
+}
+
+// of returns the groups of the user.
+func (g htgroup) of(username string) []string {
+	return g[username]
+}
+
+// verify checks the password of the user.
+func (h htpasswd) verify(username, password string) error {
+	hash, ok := h[username]
+	if !ok {
+		return ErrInvalidCredentials
+	}
+
+	if err := verifyPassword(hash, password); err != nil {
+		return ErrInvalidCredentials
+	}
+
+	return nil
+}
+
+func supportedPasswordHash(hash string) bool {
+	return isBcrypt(hash) || strings.HasPrefix(hash, "{SHA}") || crypt.IsHashSupported(hash)
+}
+
+// isBcrypt checks for the prefixes of bcrypt hashes including "$2y$" used by htpasswd.
+func isBcrypt(hash string) bool {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2y$"} {
+		if strings.HasPrefix(hash, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verifyPassword compares the password with a hash of the supported formats.
+func verifyPassword(hash, password string) error {
+	switch {
+	case isBcrypt(hash):
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+
+	case strings.HasPrefix(hash, "{SHA}"):
+		sum := sha1.Sum([]byte(password)) //nolint:gosec
+		if subtle.ConstantTimeCompare([]byte("{SHA}"+base64.StdEncoding.EncodeToString(sum[:])), []byte(hash)) != 1 {
+			return ErrInvalidCredentials
+		}
+
+		return nil
+
+	case crypt.IsHashSupported(hash):
+		return crypt.NewFromHash(hash).Verify(hash, []byte(password))
+	}
+
+	return ErrUnsupportedHash
+}
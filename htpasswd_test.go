@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Hashes of the password "secret" created by openssl passwd and libxcrypt
+var knownHashes = map[string]string{
+	"bcrypt-2a":     "$2a$05$CCCCCCCCCCCCCCCCCCCCC.pIKrLVkBX9/OvQMSygY1pWEVn72t6na",
+	"bcrypt-2b":     "$2b$05$CCCCCCCCCCCCCCCCCCCCC.pIKrLVkBX9/OvQMSygY1pWEVn72t6na",
+	"bcrypt-2y":     "$2y$05$abcdefghijklmnopqrstuuOQiyCxlgf/oeuTqixKmWdcYUh4Hjl0a",
+	"apr1":          "$apr1$saltsalt$LrttParrLPdxvgutaSXWJ0",
+	"md5":           "$1$saltsalt$9xy1btjgzLYfb7hivXtC//",
+	"sha256":        "$5$saltstring$C3o4O1TC6aRHF4FI.QSZMXtHbaj2gSXr4sUc/3NcUi.",
+	"sha256-rounds": "$5$rounds=10000$saltstringsaltst$nRVJUfBu7b60x4u50TScFun8GLsggmlJvebgklaft49",
+	"sha512":        "$6$saltstring$AIsRs/Ee56G/tC8MEHhvReZTfx8u3rXXMl6eYrjCG9ibix19DxoMBLogdTET5Ukw9Sf7eZTITsuk0Ry5qulYz.",
+	"sha1":          "{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=",
+}
+
+func TestVerifyPassword(t *testing.T) {
+	for name, hash := range knownHashes {
+		t.Run(name, func(t *testing.T) {
+			if !supportedPasswordHash(hash) {
+				t.Fatalf("Hash is not supported")
+			}
+
+			if err := verifyPassword(hash, "secret"); err != nil {
+				t.Errorf("Failed to verify correct password: %v", err)
+			}
+
+			if err := verifyPassword(hash, "wrong"); err == nil {
+				t.Errorf("Verified wrong password")
+			}
+		})
+	}
+}
+
+func TestLoadHtpasswd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "htpasswd")
+
+	content := "# comment\n\nalice:" + knownHashes["bcrypt-2y"] + "\nbob:" + knownHashes["apr1"] + "\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	h, err := loadHtpasswd(path)
+	if err != nil {
+		t.Fatalf("Failed to load htpasswd file: %v", err)
+	}
+
+	if err := h.verify("alice", "secret"); err != nil {
+		t.Errorf("Failed to verify alice: %v", err)
+	}
+
+	if err := h.verify("bob", "wrong"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected invalid credentials, got %v", err)
+	}
+
+	if err := h.verify("carol", "secret"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected invalid credentials for unknown user, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("dave:plaintext\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadHtpasswd(path); !errors.Is(err, ErrUnsupportedHash) {
+		t.Errorf("Expected unsupported hash error, got %v", err)
+	}
+}
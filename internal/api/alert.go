@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func PrintAlerts(f io.Writer, format string, alerts []Alert) {
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(alerts)
+
+		return
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		"Since",
+		"Severity",
+		"Rule",
+		"Message",
+	})
+
+	for _, a := range alerts {
+		t.AppendRow(table.Row{
+			a.Since.Local().Format(time.DateTime),
+			a.Severity,
+			a.Rule,
+			a.Message,
+		})
+	}
+
+	renderTable(t, f, format)
+}
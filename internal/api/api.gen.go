@@ -2,7 +2,7 @@
 
 // Package api provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.3.0 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package api
 
 import (
@@ -20,6 +20,213 @@ import (
 	strictnethttp "github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 )
 
+const (
+	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
+	BasicAuthScopes  = "BasicAuth.Scopes"
+	BearerAuthScopes = "BearerAuth.Scopes"
+)
+
+// Defines values for AlertSeverity.
+const (
+	Critical AlertSeverity = "critical"
+	Info     AlertSeverity = "info"
+	Warning  AlertSeverity = "warning"
+)
+
+// Defines values for AlertState.
+const (
+	Firing   AlertState = "firing"
+	Resolved AlertState = "resolved"
+)
+
+// Defines values for AuditEntryResult.
+const (
+	Failure AuditEntryResult = "failure"
+	Partial AuditEntryResult = "partial"
+	Success AuditEntryResult = "success"
+)
+
+// Defines values for ConnectionState.
+const (
+	Connected    ConnectionState = "connected"
+	Connecting   ConnectionState = "connecting"
+	Disconnected ConnectionState = "disconnected"
+)
+
+// Defines values for ErrorCode.
+const (
+	ErrorCodeAccessDenied             ErrorCode = "access_denied"
+	ErrorCodeAliasLoop                ErrorCode = "alias_loop"
+	ErrorCodeConnectionClosed         ErrorCode = "connection_closed"
+	ErrorCodeDecodeFailed             ErrorCode = "decode_failed"
+	ErrorCodeDisconnected             ErrorCode = "disconnected"
+	ErrorCodeInternal                 ErrorCode = "internal"
+	ErrorCodeInvalidCredentials       ErrorCode = "invalid_credentials"
+	ErrorCodeInvalidCron              ErrorCode = "invalid_cron"
+	ErrorCodeInvalidOutletID          ErrorCode = "invalid_outlet_id"
+	ErrorCodeInvalidPassword          ErrorCode = "invalid_password"
+	ErrorCodeInvalidRequest           ErrorCode = "invalid_request"
+	ErrorCodeInvalidSchedule          ErrorCode = "invalid_schedule"
+	ErrorCodeInvalidTimeRange         ErrorCode = "invalid_time_range"
+	ErrorCodeLoginRequired            ErrorCode = "login_required"
+	ErrorCodeMissingClientCertificate ErrorCode = "missing_client_certificate"
+	ErrorCodeMissingCredentials       ErrorCode = "missing_credentials"
+	ErrorCodeNotEnabled               ErrorCode = "not_enabled"
+	ErrorCodeNotPolledYet             ErrorCode = "not_polled_yet"
+	ErrorCodeOutletLocked             ErrorCode = "outlet_locked"
+	ErrorCodeOutletNotFound           ErrorCode = "outlet_not_found"
+	ErrorCodePDUClosed                ErrorCode = "pdu_closed"
+	ErrorCodeRejected                 ErrorCode = "rejected"
+	ErrorCodeScheduleNotFound         ErrorCode = "schedule_not_found"
+	ErrorCodeScheduleReadOnly         ErrorCode = "schedule_read_only"
+	ErrorCodeSequenceNotFound         ErrorCode = "sequence_not_found"
+	ErrorCodeSequenceRunning          ErrorCode = "sequence_running"
+	ErrorCodeTimeout                  ErrorCode = "timeout"
+	ErrorCodeUnknownPDU               ErrorCode = "unknown_pdu"
+	ErrorCodeUserNotFound             ErrorCode = "user_not_found"
+)
+
+// Defines values for EventType.
+const (
+	EventTypeAlert            EventType = "alert"
+	EventTypeBreakerThreshold EventType = "breaker-threshold"
+	EventTypeLoadShedding     EventType = "load-shedding"
+	EventTypeOutletLock       EventType = "outlet-lock"
+	EventTypeOutletState      EventType = "outlet-state"
+	EventTypeSequence         EventType = "sequence"
+	EventTypeStatus           EventType = "status"
+	EventTypeWatchdog         EventType = "watchdog"
+)
+
+// Defines values for LoadSheddingActionAction.
+const (
+	Restore LoadSheddingActionAction = "restore"
+	Shed    LoadSheddingActionAction = "shed"
+)
+
+// Defines values for ScheduleSource.
+const (
+	Api    ScheduleSource = "api"
+	Config ScheduleSource = "config"
+)
+
+// Defines values for ScheduleAction.
+const (
+	Lock   ScheduleAction = "lock"
+	Off    ScheduleAction = "off"
+	On     ScheduleAction = "on"
+	Reboot ScheduleAction = "reboot"
+	Unlock ScheduleAction = "unlock"
+)
+
+// Defines values for SequenceRunStatus.
+const (
+	SequenceRunStatusCompleted SequenceRunStatus = "completed"
+	SequenceRunStatusFailed    SequenceRunStatus = "failed"
+	SequenceRunStatusRunning   SequenceRunStatus = "running"
+)
+
+// Defines values for SequenceStepRunStatus.
+const (
+	SequenceStepRunStatusCompleted SequenceStepRunStatus = "completed"
+	SequenceStepRunStatusFailed    SequenceStepRunStatus = "failed"
+	SequenceStepRunStatusPending   SequenceStepRunStatus = "pending"
+	SequenceStepRunStatusSkipped   SequenceStepRunStatus = "skipped"
+	SequenceStepRunStatusSwitching SequenceStepRunStatus = "switching"
+	SequenceStepRunStatusWaiting   SequenceStepRunStatus = "waiting"
+)
+
+// Defines values for WatchdogStatusState.
+const (
+	Cooldown WatchdogStatusState = "cooldown"
+	Failing  WatchdogStatusState = "failing"
+	Limited  WatchdogStatusState = "limited"
+	Ok       WatchdogStatusState = "ok"
+	Paused   WatchdogStatusState = "paused"
+	Unknown  WatchdogStatusState = "unknown"
+)
+
+// Alert defines model for Alert.
+type Alert struct {
+	// ID ID of the outlet, group or breaker
+	ID      *int   `json:"id,omitempty"`
+	Message string `json:"message"`
+
+	// Name Name of the outlet, group or breaker
+	Name *string `json:"name,omitempty"`
+	PDU  string  `json:"pdu"`
+
+	// Resolved Time at which the alert has been resolved
+	Resolved *time.Time `json:"resolved,omitempty"`
+
+	// Rule Name of the alert rule
+	Rule     string        `json:"rule"`
+	Severity AlertSeverity `json:"severity"`
+
+	// Since Time at which the alert fired
+	Since time.Time  `json:"since"`
+	State AlertState `json:"state"`
+
+	// Threshold Threshold which has been crossed
+	Threshold *float32 `json:"threshold,omitempty"`
+
+	// Type Type of the alert rule
+	Type string `json:"type"`
+
+	// Value Measured value which triggered or resolved the alert
+	Value float32 `json:"value"`
+}
+
+// AlertSeverity defines model for Alert.Severity.
+type AlertSeverity string
+
+// AlertState defines model for Alert.State.
+type AlertState string
+
+// AuditEntry defines model for AuditEntry.
+type AuditEntry struct {
+	// After Status of the selected outlets after the operation derived from its results for the individual outlets
+	After []OutletStatus `json:"after,omitempty"`
+
+	// Before Status of the selected outlets before the operation
+	Before []OutletStatus `json:"before,omitempty"`
+
+	// Client Identity of the authenticated client (common name of the client certificate, name of the API key, user name or token claim)
+	Client *string `json:"client,omitempty"`
+	Error  *string `json:"error,omitempty"`
+
+	// Latency Duration of the operation [s]
+	Latency float32 `json:"latency"`
+
+	// Method Method by which the client has been authenticated (certificate, api-key, basic or jwt)
+	Method *string `json:"method,omitempty"`
+
+	// Operation Operation ID
+	Operation string `json:"operation"`
+
+	// Outlet Requested outlet expression
+	Outlet *string `json:"outlet,omitempty"`
+	PDU    string  `json:"pdu"`
+
+	// RemoteAddress Address of the client
+	RemoteAddress string `json:"remote_address"`
+
+	// Result Partial if the operation failed only for some of the outlets
+	Result AuditEntryResult `json:"result"`
+
+	// Source Component which issued the command (api, redfish, mqtt, snmp, scheduler, sequencer, watchdog or load-shedding)
+	Source string `json:"source"`
+
+	// State Requested state for switch-outlet and lock-outlet
+	State *bool `json:"state,omitempty"`
+
+	// Timestamp Time at which the operation has been requested
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// AuditEntryResult Partial if the operation failed only for some of the outlets
+type AuditEntryResult string
+
 // BreakerStatus defines model for BreakerStatus.
 type BreakerStatus struct {
 	ID             int     `json:"id"`
@@ -28,6 +235,109 @@ type BreakerStatus struct {
 	TrueRMSCurrent float32 `json:"true_rms_current"`
 }
 
+// Connection defines model for Connection.
+type Connection struct {
+	// LastError Last connection error
+	LastError *string `json:"last_error,omitempty"`
+
+	// Reconnects Number of successful reconnects
+	Reconnects int `json:"reconnects"`
+
+	// Since Time of last state change
+	Since time.Time `json:"since"`
+
+	// State State of the connection to the PDU
+	State ConnectionState `json:"state"`
+}
+
+// ConnectionState State of the connection to the PDU
+type ConnectionState string
+
+// Diagnostics defines model for Diagnostics.
+type Diagnostics struct {
+	// Alive Whether the status is polled regularly
+	Alive bool `json:"alive"`
+
+	// AverageLatency Average duration of the executed commands [s]
+	AverageLatency float32 `json:"average_latency"`
+
+	// Commands Number of commands executed on the PDU
+	Commands   int        `json:"commands"`
+	Connection Connection `json:"connection"`
+
+	// ConsecutiveFailures Number of failed polls since the last successful one
+	ConsecutiveFailures int `json:"consecutive_failures"`
+
+	// LastError Error of the last failed poll
+	LastError *string `json:"last_error,omitempty"`
+
+	// LastPoll Time of the last attempt to poll the status
+	LastPoll *time.Time `json:"last_poll,omitempty"`
+
+	// LastSuccessfulPoll Time of the last successfully polled status
+	LastSuccessfulPoll *time.Time `json:"last_successful_poll,omitempty"`
+
+	// LoggedIn Whether a session with the PDU has been established
+	LoggedIn bool `json:"logged_in"`
+
+	// PollInterval Interval between status updates [s]
+	PollInterval float32 `json:"poll_interval"`
+
+	// Ready Whether a recent status has been polled
+	Ready bool `json:"ready"`
+
+	// Staleness Age of the last polled status [s]
+	Staleness *float32 `json:"staleness,omitempty"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	// Code Stable identifier of the cause of an error
+	Code ErrorCode `json:"code"`
+
+	// Error Same as message for older clients
+	// Deprecated:
+	Error string `json:"error"`
+
+	// Message An error message
+	Message string `json:"message"`
+
+	// Outlet Outlet expression of the request which the error refers to
+	Outlet *string `json:"outlet,omitempty"`
+
+	// Retryable Whether the request may succeed if it is retried later
+	Retryable bool `json:"retryable"`
+}
+
+// ErrorCode Stable identifier of the cause of an error
+type ErrorCode string
+
+// Event defines model for Event.
+type Event struct {
+	Alert   *Alert         `json:"alert,omitempty"`
+	Breaker *BreakerStatus `json:"breaker,omitempty"`
+
+	// Exceeded True if the breaker current rose above the threshold, false if it fell below again
+	Exceeded     *bool               `json:"exceeded,omitempty"`
+	LoadShedding *LoadSheddingAction `json:"load_shedding,omitempty"`
+	Outlet       *OutletStatus       `json:"outlet,omitempty"`
+	Sequence     *SequenceRun        `json:"sequence,omitempty"`
+	Status       *Status             `json:"status,omitempty"`
+
+	// Threshold Current threshold of the breaker [A]
+	Threshold *float32 `json:"threshold,omitempty"`
+
+	// Timestamp Time of the event
+	Timestamp time.Time `json:"timestamp"`
+
+	// Type Type of the event
+	Type     EventType       `json:"type"`
+	Watchdog *WatchdogStatus `json:"watchdog,omitempty"`
+}
+
+// EventType Type of the event
+type EventType string
+
 // GroupStatus defines model for GroupStatus.
 type GroupStatus struct {
 	// AveragePower Average power [W]
@@ -52,6 +362,25 @@ type GroupStatus struct {
 	TrueRMSVoltage float32 `json:"true_rms_voltage"`
 }
 
+// LoadSheddingAction defines model for LoadSheddingAction.
+type LoadSheddingAction struct {
+	// Action Shed switches the outlet off, restore switches it on again
+	Action LoadSheddingActionAction `json:"action"`
+
+	// Current Current of the breaker or group [A]
+	Current float32 `json:"current"`
+
+	// Limit Current limit of the policy [A]
+	Limit  float32      `json:"limit"`
+	Outlet OutletResult `json:"outlet"`
+
+	// Policy Name of the load shedding policy
+	Policy string `json:"policy"`
+}
+
+// LoadSheddingActionAction Shed switches the outlet off, restore switches it on again
+type LoadSheddingActionAction string
+
 // Measurements defines model for Measurements.
 type Measurements struct {
 	// AveragePower Average power [W]
@@ -73,6 +402,38 @@ type Measurements struct {
 	TrueRMSVoltage float32 `json:"true_rms_voltage"`
 }
 
+// NewSchedule defines model for NewSchedule.
+type NewSchedule struct {
+	Action ScheduleAction `json:"action"`
+
+	// At Time of a one-shot schedule
+	At *time.Time `json:"at,omitempty"`
+
+	// Cron Cron expression (minute hour day-of-month month day-of-week) or a descriptor like @daily
+	Cron *string `json:"cron,omitempty"`
+	Name *string `json:"name,omitempty"`
+
+	// Outlets Outlet expression
+	Outlets string `json:"outlets"`
+}
+
+// NewUser defines model for NewUser.
+type NewUser struct {
+	Name string `json:"name"`
+
+	// Outlets Outlet expression for the outlets which the user is allowed to control
+	Outlets  *string `json:"outlets,omitempty"`
+	Password string  `json:"password"`
+}
+
+// OutletResult defines model for OutletResult.
+type OutletResult struct {
+	// Error An error message if the operation failed for this outlet
+	Error *string `json:"error,omitempty"`
+	ID    int     `json:"id"`
+	Name  string  `json:"name"`
+}
+
 // OutletStatus defines model for OutletStatus.
 type OutletStatus struct {
 	// AveragePower Average power [W]
@@ -100,12 +461,108 @@ type OutletStatus struct {
 	TrueRMSVoltage float32 `json:"true_rms_voltage"`
 }
 
+// Schedule defines model for Schedule.
+type Schedule struct {
+	Action ScheduleAction `json:"action"`
+
+	// At Time of a one-shot schedule
+	At *time.Time `json:"at,omitempty"`
+
+	// CreatedBy Common name of the client which created the schedule
+	CreatedBy *string `json:"created_by,omitempty"`
+
+	// Cron Cron expression (minute hour day-of-month month day-of-week) or a descriptor like @daily
+	Cron *string `json:"cron,omitempty"`
+	ID   string  `json:"id"`
+	Name *string `json:"name,omitempty"`
+
+	// NextRun Time of the next run
+	NextRun *time.Time `json:"next_run,omitempty"`
+
+	// Outlets Outlet expression
+	Outlets string `json:"outlets"`
+
+	// Source Origin of the schedule
+	Source ScheduleSource `json:"source"`
+}
+
+// ScheduleSource Origin of the schedule
+type ScheduleSource string
+
+// ScheduleAction defines model for ScheduleAction.
+type ScheduleAction string
+
+// ScheduleRun defines model for ScheduleRun.
+type ScheduleRun struct {
+	Action     ScheduleAction  `json:"action"`
+	Error      *string         `json:"error,omitempty"`
+	Name       *string         `json:"name,omitempty"`
+	Outlets    string          `json:"outlets"`
+	Results    *[]OutletResult `json:"results,omitempty"`
+	ScheduleID string          `json:"schedule_id"`
+	Timestamp  time.Time       `json:"timestamp"`
+}
+
+// Sequence defines model for Sequence.
+type Sequence struct {
+	LastRun *SequenceRun   `json:"last_run,omitempty"`
+	Name    string         `json:"name"`
+	Steps   []SequenceStep `json:"steps"`
+}
+
+// SequenceRun defines model for SequenceRun.
+type SequenceRun struct {
+	Error    *string    `json:"error,omitempty"`
+	Finished *time.Time `json:"finished,omitempty"`
+	Sequence string     `json:"sequence"`
+	Started  time.Time  `json:"started"`
+
+	// State True if the outlets are switched on in order, false if switched off in reverse order
+	State  bool              `json:"state"`
+	Status SequenceRunStatus `json:"status"`
+
+	// Steps Progress of the steps in the order of execution
+	Steps []SequenceStepRun `json:"steps"`
+}
+
+// SequenceRunStatus defines model for SequenceRun.Status.
+type SequenceRunStatus string
+
+// SequenceStep defines model for SequenceStep.
+type SequenceStep struct {
+	// Delay Time to wait after the step, e.g. 10s
+	Delay *string `json:"delay,omitempty"`
+
+	// Outlets Outlet expression
+	Outlets string `json:"outlets"`
+
+	// Timeout Maximum time to wait for the current, e.g. 1m
+	Timeout *string `json:"timeout,omitempty"`
+
+	// WaitCurrent Wait until each outlet draws more than this current after switching on [A]
+	WaitCurrent *float32 `json:"wait_current,omitempty"`
+}
+
+// SequenceStepRun defines model for SequenceStepRun.
+type SequenceStepRun struct {
+	Error    *string               `json:"error,omitempty"`
+	Finished *time.Time            `json:"finished,omitempty"`
+	Outlets  string                `json:"outlets"`
+	Results  *[]OutletResult       `json:"results,omitempty"`
+	Started  *time.Time            `json:"started,omitempty"`
+	Status   SequenceStepRunStatus `json:"status"`
+}
+
+// SequenceStepRunStatus defines model for SequenceStepRun.Status.
+type SequenceStepRunStatus string
+
 // Status defines model for Status.
 type Status struct {
-	Breakers []BreakerStatus `json:"breakers"`
-	Groups   []GroupStatus   `json:"groups"`
-	Outlets  []OutletStatus  `json:"outlets"`
-	Switches []bool          `json:"switches"`
+	Breakers   []BreakerStatus `json:"breakers"`
+	Connection *Connection     `json:"connection,omitempty"`
+	Groups     []GroupStatus   `json:"groups"`
+	Outlets    []OutletStatus  `json:"outlets"`
+	Switches   []bool          `json:"switches"`
 
 	// Temperature Temperature [C]
 	Temperature float32 `json:"temperature"`
@@ -115,18 +572,101 @@ type Status struct {
 
 	// TotalEnergy Total energy [kWh]
 	TotalEnergy float32 `json:"total_energy"`
+
+	// Watchdogs Watchdogs of pdud which reboot the outlets of hung devices
+	Watchdogs []WatchdogStatus `json:"watchdogs,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	Name string `json:"name"`
+
+	// Outlets IDs of the outlets which the user is allowed to control
+	Outlets []int `json:"outlets"`
+}
+
+// WatchdogStatus defines model for WatchdogStatus.
+type WatchdogStatus struct {
+	// Failures Number of consecutive failed checks
+	Failures int `json:"failures"`
+
+	// LastCheck Time of the last check
+	LastCheck *time.Time `json:"last_check,omitempty"`
+
+	// LastError Error of the last failed check
+	LastError *string `json:"last_error,omitempty"`
+
+	// LastReboot Time of the last reboot
+	LastReboot *time.Time `json:"last_reboot,omitempty"`
+
+	// Name Name of the watchdog
+	Name string `json:"name"`
+
+	// Outlets Outlets which are rebooted
+	Outlets string `json:"outlets"`
+
+	// Reboots Number of reboots within the last 24 hours
+	Reboots int `json:"reboots"`
+
+	// State Unknown before the first check, ok if the last check succeeded, failing after failed checks, cooldown after a reboot, limited if the maximum number of reboots per day has been reached and paused while the outlets are switched off
+	State WatchdogStatusState `json:"state"`
+
+	// Target Host, address, URL or command which is checked
+	Target string `json:"target"`
+
+	// Type Type of the check (ping, tcp, http or exec)
+	Type string `json:"type"`
 }
 
+// WatchdogStatusState Unknown before the first check, ok if the last check succeeded, failing after failed checks, cooldown after a reboot, limited if the maximum number of reboots per day has been reached and paused while the outlets are switched off
+type WatchdogStatusState string
+
 // Detailed defines model for detailed.
 type Detailed = bool
 
 // Id defines model for id.
 type Id = string
 
-// Error defines model for Error.
-type Error struct {
-	// Error An error message
-	Error string `json:"error"`
+// Name defines model for name.
+type Name = string
+
+// Seq defines model for seq.
+type Seq = string
+
+// AuditParams defines parameters for Audit.
+type AuditParams struct {
+	// From Start of the time range (defaults to one day ago)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the time range (defaults to now)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Outlet Outlet expression selecting the outlets of the operations to include
+	Outlet *string `form:"outlet,omitempty" json:"outlet,omitempty"`
+
+	// Operation Operation ID of the operations to include
+	Operation *string `form:"operation,omitempty" json:"operation,omitempty"`
+
+	// Client Identity of the client of the operations to include
+	Client *string `form:"client,omitempty" json:"client,omitempty"`
+
+	// Limit Maximum number of entries to return (the most recent ones)
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// HistoryParams defines parameters for History.
+type HistoryParams struct {
+	// From Start of the time range (defaults to one hour ago)
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To End of the time range (defaults to now)
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// Step Interval for downsampling the samples (e.g. 5m)
+	Step *string `form:"step,omitempty" json:"step,omitempty"`
+
+	// Outlet Outlet expression selecting the outlets to include
+	Outlet *string `form:"outlet,omitempty" json:"outlet,omitempty"`
 }
 
 // LockOutletJSONBody defines parameters for LockOutlet.
@@ -135,18 +675,42 @@ type LockOutletJSONBody = bool
 // SwitchOutletJSONBody defines parameters for SwitchOutlet.
 type SwitchOutletJSONBody = bool
 
+// RunSequenceJSONBody defines parameters for RunSequence.
+type RunSequenceJSONBody = bool
+
 // StatusParams defines parameters for Status.
 type StatusParams struct {
 	// Detailed Detailed
 	Detailed *Detailed `form:"detailed,omitempty" json:"detailed,omitempty"`
 }
 
+// SetUserOutletsJSONBody defines parameters for SetUserOutlets.
+type SetUserOutletsJSONBody = string
+
+// ChangePasswordJSONBody defines parameters for ChangePassword.
+type ChangePasswordJSONBody = string
+
 // LockOutletJSONRequestBody defines body for LockOutlet for application/json ContentType.
 type LockOutletJSONRequestBody = LockOutletJSONBody
 
 // SwitchOutletJSONRequestBody defines body for SwitchOutlet for application/json ContentType.
 type SwitchOutletJSONRequestBody = SwitchOutletJSONBody
 
+// AddScheduleJSONRequestBody defines body for AddSchedule for application/json ContentType.
+type AddScheduleJSONRequestBody = NewSchedule
+
+// RunSequenceJSONRequestBody defines body for RunSequence for application/json ContentType.
+type RunSequenceJSONRequestBody = RunSequenceJSONBody
+
+// SetUserOutletsJSONRequestBody defines body for SetUserOutlets for application/json ContentType.
+type SetUserOutletsJSONRequestBody = SetUserOutletsJSONBody
+
+// ChangePasswordJSONRequestBody defines body for ChangePassword for application/json ContentType.
+type ChangePasswordJSONRequestBody = ChangePasswordJSONBody
+
+// AddUserJSONRequestBody defines body for AddUser for application/json ContentType.
+type AddUserJSONRequestBody = NewUser
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -220,9 +784,27 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ListAlerts request
+	ListAlerts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Audit request
+	Audit(ctx context.Context, params *AuditParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ClearMaximumCurrents request
 	ClearMaximumCurrents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// Diagnostics request
+	Diagnostics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Events request
+	Events(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// History request
+	History(ctx context.Context, params *HistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// StatusOutlet request
+	StatusOutlet(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// LockOutletWithBody request with any body
 	LockOutletWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -236,18 +818,64 @@ type ClientInterface interface {
 
 	SwitchOutlet(ctx context.Context, id Id, body SwitchOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteSchedule request
+	DeleteSchedule(ctx context.Context, sid string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSchedules request
+	ListSchedules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddScheduleWithBody request with any body
+	AddScheduleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddSchedule(ctx context.Context, body AddScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListScheduleRuns request
+	ListScheduleRuns(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSequences request
+	ListSequences(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetSequence request
+	GetSequence(ctx context.Context, seq Seq, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RunSequenceWithBody request with any body
+	RunSequenceWithBody(ctx context.Context, seq Seq, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	RunSequence(ctx context.Context, seq Seq, body RunSequenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// Status request
 	Status(ctx context.Context, params *StatusParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// Temperature request
 	Temperature(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteUser request
+	DeleteUser(ctx context.Context, name Name, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SetUserOutletsWithBody request with any body
+	SetUserOutletsWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SetUserOutlets(ctx context.Context, name Name, body SetUserOutletsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ChangePasswordWithBody request with any body
+	ChangePasswordWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ChangePassword(ctx context.Context, name Name, body ChangePasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListUsers request
+	ListUsers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// AddUserWithBody request with any body
+	AddUserWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	AddUser(ctx context.Context, body AddUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// WhoAmI request
 	WhoAmI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
-func (c *Client) ClearMaximumCurrents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewClearMaximumCurrentsRequest(c.Server)
+func (c *Client) ListAlerts(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListAlertsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -258,8 +886,8 @@ func (c *Client) ClearMaximumCurrents(ctx context.Context, reqEditors ...Request
 	return c.Client.Do(req)
 }
 
-func (c *Client) LockOutletWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewLockOutletRequestWithBody(c.Server, id, contentType, body)
+func (c *Client) Audit(ctx context.Context, params *AuditParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAuditRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -270,8 +898,8 @@ func (c *Client) LockOutletWithBody(ctx context.Context, id Id, contentType stri
 	return c.Client.Do(req)
 }
 
-func (c *Client) LockOutlet(ctx context.Context, id Id, body LockOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewLockOutletRequest(c.Server, id, body)
+func (c *Client) ClearMaximumCurrents(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewClearMaximumCurrentsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -282,8 +910,8 @@ func (c *Client) LockOutlet(ctx context.Context, id Id, body LockOutletJSONReque
 	return c.Client.Do(req)
 }
 
-func (c *Client) RebootOutlet(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewRebootOutletRequest(c.Server, id)
+func (c *Client) Diagnostics(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDiagnosticsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -294,8 +922,8 @@ func (c *Client) RebootOutlet(ctx context.Context, id Id, reqEditors ...RequestE
 	return c.Client.Do(req)
 }
 
-func (c *Client) SwitchOutletWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSwitchOutletRequestWithBody(c.Server, id, contentType, body)
+func (c *Client) Events(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewEventsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
@@ -306,8 +934,8 @@ func (c *Client) SwitchOutletWithBody(ctx context.Context, id Id, contentType st
 	return c.Client.Do(req)
 }
 
-func (c *Client) SwitchOutlet(ctx context.Context, id Id, body SwitchOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewSwitchOutletRequest(c.Server, id, body)
+func (c *Client) History(ctx context.Context, params *HistoryParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHistoryRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
@@ -318,8 +946,8 @@ func (c *Client) SwitchOutlet(ctx context.Context, id Id, body SwitchOutletJSONR
 	return c.Client.Do(req)
 }
 
-func (c *Client) Status(ctx context.Context, params *StatusParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewStatusRequest(c.Server, params)
+func (c *Client) StatusOutlet(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStatusOutletRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
@@ -330,8 +958,8 @@ func (c *Client) Status(ctx context.Context, params *StatusParams, reqEditors ..
 	return c.Client.Do(req)
 }
 
-func (c *Client) Temperature(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewTemperatureRequest(c.Server)
+func (c *Client) LockOutletWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLockOutletRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
 		return nil, err
 	}
@@ -342,8 +970,8 @@ func (c *Client) Temperature(ctx context.Context, reqEditors ...RequestEditorFn)
 	return c.Client.Do(req)
 }
 
-func (c *Client) WhoAmI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
-	req, err := NewWhoAmIRequest(c.Server)
+func (c *Client) LockOutlet(ctx context.Context, id Id, body LockOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLockOutletRequest(c.Server, id, body)
 	if err != nil {
 		return nil, err
 	}
@@ -354,212 +982,284 @@ func (c *Client) WhoAmI(ctx context.Context, reqEditors ...RequestEditorFn) (*ht
 	return c.Client.Do(req)
 }
 
-// NewClearMaximumCurrentsRequest generates requests for ClearMaximumCurrents
-func NewClearMaximumCurrentsRequest(server string) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) RebootOutlet(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRebootOutletRequest(c.Server, id)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/clear")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) SwitchOutletWithBody(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSwitchOutletRequestWithBody(c.Server, id, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+func (c *Client) SwitchOutlet(ctx context.Context, id Id, body SwitchOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSwitchOutletRequest(c.Server, id, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewLockOutletRequest calls the generic LockOutlet builder with application/json body
-func NewLockOutletRequest(server string, id Id, body LockOutletJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) DeleteSchedule(ctx context.Context, sid string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteScheduleRequest(c.Server, sid)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewLockOutletRequestWithBody(server, id, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewLockOutletRequestWithBody generates requests for LockOutlet with any type of body
-func NewLockOutletRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) ListSchedules(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSchedulesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) AddScheduleWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddScheduleRequestWithBody(c.Server, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/outlet/%s/lock", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) AddSchedule(ctx context.Context, body AddScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddScheduleRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) ListScheduleRuns(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListScheduleRunsRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewRebootOutletRequest generates requests for RebootOutlet
-func NewRebootOutletRequest(server string, id Id) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) ListSequences(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSequencesRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) GetSequence(ctx context.Context, seq Seq, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetSequenceRequest(c.Server, seq)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/outlet/%s/reboot", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) RunSequenceWithBody(ctx context.Context, seq Seq, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRunSequenceRequestWithBody(c.Server, seq, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), nil)
+func (c *Client) RunSequence(ctx context.Context, seq Seq, body RunSequenceJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRunSequenceRequest(c.Server, seq, body)
 	if err != nil {
 		return nil, err
 	}
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewSwitchOutletRequest calls the generic SwitchOutlet builder with application/json body
-func NewSwitchOutletRequest(server string, id Id, body SwitchOutletJSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
+func (c *Client) Status(ctx context.Context, params *StatusParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewStatusRequest(c.Server, params)
 	if err != nil {
 		return nil, err
 	}
-	bodyReader = bytes.NewReader(buf)
-	return NewSwitchOutletRequestWithBody(server, id, "application/json", bodyReader)
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewSwitchOutletRequestWithBody generates requests for SwitchOutlet with any type of body
-func NewSwitchOutletRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
-	var err error
-
-	var pathParam0 string
-
-	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+func (c *Client) Temperature(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTemperatureRequest(c.Server)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	serverURL, err := url.Parse(server)
+func (c *Client) DeleteUser(ctx context.Context, name Name, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteUserRequest(c.Server, name)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/outlet/%s/state", pathParam0)
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) SetUserOutletsWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetUserOutletsRequestWithBody(c.Server, name, contentType, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("POST", queryURL.String(), body)
+func (c *Client) SetUserOutlets(ctx context.Context, name Name, body SetUserOutletsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSetUserOutletsRequest(c.Server, name, body)
 	if err != nil {
 		return nil, err
 	}
-
-	req.Header.Add("Content-Type", contentType)
-
-	return req, nil
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewStatusRequest generates requests for Status
-func NewStatusRequest(server string, params *StatusParams) (*http.Request, error) {
-	var err error
-
-	serverURL, err := url.Parse(server)
+func (c *Client) ChangePasswordWithBody(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewChangePasswordRequestWithBody(c.Server, name, contentType, body)
 	if err != nil {
 		return nil, err
 	}
-
-	operationPath := fmt.Sprintf("/status")
-	if operationPath[0] == '/' {
-		operationPath = "." + operationPath
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	queryURL, err := serverURL.Parse(operationPath)
+func (c *Client) ChangePassword(ctx context.Context, name Name, body ChangePasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewChangePasswordRequest(c.Server, name, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	if params != nil {
-		queryValues := queryURL.Query()
-
-		if params.Detailed != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "detailed", runtime.ParamLocationQuery, *params.Detailed); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
+func (c *Client) ListUsers(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListUsersRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-		queryURL.RawQuery = queryValues.Encode()
+func (c *Client) AddUserWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddUserRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
 	}
+	return c.Client.Do(req)
+}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+func (c *Client) AddUser(ctx context.Context, body AddUserJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewAddUserRequest(c.Server, body)
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
 
-	return req, nil
+func (c *Client) WhoAmI(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewWhoAmIRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
-// NewTemperatureRequest generates requests for Temperature
-func NewTemperatureRequest(server string) (*http.Request, error) {
+// NewListAlertsRequest generates requests for ListAlerts
+func NewListAlertsRequest(server string) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -567,7 +1267,7 @@ func NewTemperatureRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/temperature")
+	operationPath := fmt.Sprintf("/alerts")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -585,8 +1285,8 @@ func NewTemperatureRequest(server string) (*http.Request, error) {
 	return req, nil
 }
 
-// NewWhoAmIRequest generates requests for WhoAmI
-func NewWhoAmIRequest(server string) (*http.Request, error) {
+// NewAuditRequest generates requests for Audit
+func NewAuditRequest(server string, params *AuditParams) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -594,7 +1294,7 @@ func NewWhoAmIRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/whoami")
+	operationPath := fmt.Sprintf("/audit")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -604,1393 +1304,5738 @@ func NewWhoAmIRequest(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+	if params != nil {
+		queryValues := queryURL.Query()
 
-	return req, nil
-}
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
-	for _, r := range c.RequestEditors {
-		if err := r(ctx, req); err != nil {
-			return err
-		}
-	}
-	for _, r := range additionalEditors {
-		if err := r(ctx, req); err != nil {
-			return err
 		}
-	}
-	return nil
-}
 
-// ClientWithResponses builds on ClientInterface to offer response payloads
-type ClientWithResponses struct {
-	ClientInterface
-}
+		if params.To != nil {
 
-// NewClientWithResponses creates a new ClientWithResponses, which wraps
-// Client with return type handling
-func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
-	client, err := NewClient(server, opts...)
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Outlet != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "outlet", runtime.ParamLocationQuery, *params.Outlet); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Operation != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "operation", runtime.ParamLocationQuery, *params.Operation); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Client != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "client", runtime.ParamLocationQuery, *params.Client); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
 	if err != nil {
 		return nil, err
 	}
-	return &ClientWithResponses{client}, nil
+
+	return req, nil
 }
 
-// WithBaseURL overrides the baseURL.
-func WithBaseURL(baseURL string) ClientOption {
-	return func(c *Client) error {
-		newBaseURL, err := url.Parse(baseURL)
-		if err != nil {
-			return err
+// NewClearMaximumCurrentsRequest generates requests for ClearMaximumCurrents
+func NewClearMaximumCurrentsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/clear")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDiagnosticsRequest generates requests for Diagnostics
+func NewDiagnosticsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/diagnostics")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewEventsRequest generates requests for Events
+func NewEventsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewHistoryRequest generates requests for History
+func NewHistoryRequest(server string, params *HistoryParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/history")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.From != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "from", runtime.ParamLocationQuery, *params.From); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
 		}
-		c.Server = newBaseURL.String()
-		return nil
+
+		if params.To != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "to", runtime.ParamLocationQuery, *params.To); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Step != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "step", runtime.ParamLocationQuery, *params.Step); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Outlet != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "outlet", runtime.ParamLocationQuery, *params.Outlet); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewStatusOutletRequest generates requests for StatusOutlet
+func NewStatusOutletRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
 	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/outlet/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLockOutletRequest calls the generic LockOutlet builder with application/json body
+func NewLockOutletRequest(server string, id Id, body LockOutletJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLockOutletRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewLockOutletRequestWithBody generates requests for LockOutlet with any type of body
+func NewLockOutletRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/outlet/%s/lock", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewRebootOutletRequest generates requests for RebootOutlet
+func NewRebootOutletRequest(server string, id Id) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/outlet/%s/reboot", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSwitchOutletRequest calls the generic SwitchOutlet builder with application/json body
+func NewSwitchOutletRequest(server string, id Id, body SwitchOutletJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSwitchOutletRequestWithBody(server, id, "application/json", bodyReader)
+}
+
+// NewSwitchOutletRequestWithBody generates requests for SwitchOutlet with any type of body
+func NewSwitchOutletRequestWithBody(server string, id Id, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/outlet/%s/state", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteScheduleRequest generates requests for DeleteSchedule
+func NewDeleteScheduleRequest(server string, sid string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "sid", runtime.ParamLocationPath, sid)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/schedule/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListSchedulesRequest generates requests for ListSchedules
+func NewListSchedulesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/schedules")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddScheduleRequest calls the generic AddSchedule builder with application/json body
+func NewAddScheduleRequest(server string, body AddScheduleJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddScheduleRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddScheduleRequestWithBody generates requests for AddSchedule with any type of body
+func NewAddScheduleRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/schedules")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListScheduleRunsRequest generates requests for ListScheduleRuns
+func NewListScheduleRunsRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/schedules/runs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListSequencesRequest generates requests for ListSequences
+func NewListSequencesRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sequences")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSequenceRequest generates requests for GetSequence
+func NewGetSequenceRequest(server string, seq Seq) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "seq", runtime.ParamLocationPath, seq)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sequences/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRunSequenceRequest calls the generic RunSequence builder with application/json body
+func NewRunSequenceRequest(server string, seq Seq, body RunSequenceJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewRunSequenceRequestWithBody(server, seq, "application/json", bodyReader)
+}
+
+// NewRunSequenceRequestWithBody generates requests for RunSequence with any type of body
+func NewRunSequenceRequestWithBody(server string, seq Seq, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "seq", runtime.ParamLocationPath, seq)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/sequences/%s/run", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewStatusRequest generates requests for Status
+func NewStatusRequest(server string, params *StatusParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/status")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Detailed != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "detailed", runtime.ParamLocationQuery, *params.Detailed); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTemperatureRequest generates requests for Temperature
+func NewTemperatureRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/temperature")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteUserRequest generates requests for DeleteUser
+func NewDeleteUserRequest(server string, name Name) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewSetUserOutletsRequest calls the generic SetUserOutlets builder with application/json body
+func NewSetUserOutletsRequest(server string, name Name, body SetUserOutletsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSetUserOutletsRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewSetUserOutletsRequestWithBody generates requests for SetUserOutlets with any type of body
+func NewSetUserOutletsRequestWithBody(server string, name Name, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/%s/outlets", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewChangePasswordRequest calls the generic ChangePassword builder with application/json body
+func NewChangePasswordRequest(server string, name Name, body ChangePasswordJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewChangePasswordRequestWithBody(server, name, "application/json", bodyReader)
+}
+
+// NewChangePasswordRequestWithBody generates requests for ChangePassword with any type of body
+func NewChangePasswordRequestWithBody(server string, name Name, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/user/%s/password", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListUsersRequest generates requests for ListUsers
+func NewListUsersRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewAddUserRequest calls the generic AddUser builder with application/json body
+func NewAddUserRequest(server string, body AddUserJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewAddUserRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewAddUserRequestWithBody generates requests for AddUser with any type of body
+func NewAddUserRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewWhoAmIRequest generates requests for WhoAmI
+func NewWhoAmIRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/whoami")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// ListAlertsWithResponse request
+	ListAlertsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAlertsResponse, error)
+
+	// AuditWithResponse request
+	AuditWithResponse(ctx context.Context, params *AuditParams, reqEditors ...RequestEditorFn) (*AuditResponse, error)
+
+	// ClearMaximumCurrentsWithResponse request
+	ClearMaximumCurrentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ClearMaximumCurrentsResponse, error)
+
+	// DiagnosticsWithResponse request
+	DiagnosticsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DiagnosticsResponse, error)
+
+	// EventsWithResponse request
+	EventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*EventsResponse, error)
+
+	// HistoryWithResponse request
+	HistoryWithResponse(ctx context.Context, params *HistoryParams, reqEditors ...RequestEditorFn) (*HistoryResponse, error)
+
+	// StatusOutletWithResponse request
+	StatusOutletWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*StatusOutletResponse, error)
+
+	// LockOutletWithBodyWithResponse request with any body
+	LockOutletWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LockOutletResponse, error)
+
+	LockOutletWithResponse(ctx context.Context, id Id, body LockOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*LockOutletResponse, error)
+
+	// RebootOutletWithResponse request
+	RebootOutletWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RebootOutletResponse, error)
+
+	// SwitchOutletWithBodyWithResponse request with any body
+	SwitchOutletWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SwitchOutletResponse, error)
+
+	SwitchOutletWithResponse(ctx context.Context, id Id, body SwitchOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*SwitchOutletResponse, error)
+
+	// DeleteScheduleWithResponse request
+	DeleteScheduleWithResponse(ctx context.Context, sid string, reqEditors ...RequestEditorFn) (*DeleteScheduleResponse, error)
+
+	// ListSchedulesWithResponse request
+	ListSchedulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSchedulesResponse, error)
+
+	// AddScheduleWithBodyWithResponse request with any body
+	AddScheduleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddScheduleResponse, error)
+
+	AddScheduleWithResponse(ctx context.Context, body AddScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*AddScheduleResponse, error)
+
+	// ListScheduleRunsWithResponse request
+	ListScheduleRunsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListScheduleRunsResponse, error)
+
+	// ListSequencesWithResponse request
+	ListSequencesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSequencesResponse, error)
+
+	// GetSequenceWithResponse request
+	GetSequenceWithResponse(ctx context.Context, seq Seq, reqEditors ...RequestEditorFn) (*GetSequenceResponse, error)
+
+	// RunSequenceWithBodyWithResponse request with any body
+	RunSequenceWithBodyWithResponse(ctx context.Context, seq Seq, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RunSequenceResponse, error)
+
+	RunSequenceWithResponse(ctx context.Context, seq Seq, body RunSequenceJSONRequestBody, reqEditors ...RequestEditorFn) (*RunSequenceResponse, error)
+
+	// StatusWithResponse request
+	StatusWithResponse(ctx context.Context, params *StatusParams, reqEditors ...RequestEditorFn) (*StatusResponse, error)
+
+	// TemperatureWithResponse request
+	TemperatureWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TemperatureResponse, error)
+
+	// DeleteUserWithResponse request
+	DeleteUserWithResponse(ctx context.Context, name Name, reqEditors ...RequestEditorFn) (*DeleteUserResponse, error)
+
+	// SetUserOutletsWithBodyWithResponse request with any body
+	SetUserOutletsWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetUserOutletsResponse, error)
+
+	SetUserOutletsWithResponse(ctx context.Context, name Name, body SetUserOutletsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetUserOutletsResponse, error)
+
+	// ChangePasswordWithBodyWithResponse request with any body
+	ChangePasswordWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ChangePasswordResponse, error)
+
+	ChangePasswordWithResponse(ctx context.Context, name Name, body ChangePasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*ChangePasswordResponse, error)
+
+	// ListUsersWithResponse request
+	ListUsersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListUsersResponse, error)
+
+	// AddUserWithBodyWithResponse request with any body
+	AddUserWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddUserResponse, error)
+
+	AddUserWithResponse(ctx context.Context, body AddUserJSONRequestBody, reqEditors ...RequestEditorFn) (*AddUserResponse, error)
+
+	// WhoAmIWithResponse request
+	WhoAmIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*WhoAmIResponse, error)
+}
+
+type ListAlertsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Alert
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListAlertsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListAlertsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AuditResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]AuditEntry
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AuditResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AuditResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ClearMaximumCurrentsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ClearMaximumCurrentsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ClearMaximumCurrentsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DiagnosticsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Diagnostics
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DiagnosticsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DiagnosticsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type EventsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r EventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r EventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type HistoryResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Status
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r HistoryResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r HistoryResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StatusOutletResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]OutletStatus
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StatusOutletResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StatusOutletResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LockOutletResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]OutletResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r LockOutletResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LockOutletResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RebootOutletResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]OutletResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RebootOutletResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RebootOutletResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SwitchOutletResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]OutletResult
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SwitchOutletResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SwitchOutletResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteScheduleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteScheduleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteScheduleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSchedulesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Schedule
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSchedulesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSchedulesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddScheduleResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Schedule
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AddScheduleResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddScheduleResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListScheduleRunsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]ScheduleRun
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListScheduleRunsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListScheduleRunsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSequencesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Sequence
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSequencesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSequencesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetSequenceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Sequence
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetSequenceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetSequenceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RunSequenceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *SequenceRun
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON409      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RunSequenceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RunSequenceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type StatusResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Status
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r StatusResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r StatusResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TemperatureResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Temperature Temperature [C]
+		Temperature float32 `json:"temperature"`
+	}
+	JSON401 *Error
+	JSON403 *Error
+	JSON500 *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r TemperatureResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TemperatureResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SetUserOutletsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SetUserOutletsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SetUserOutletsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ChangePasswordResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ChangePasswordResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ChangePasswordResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]User
+	JSON401      *Error
+	JSON403      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type AddUserResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *Error
+	JSON401      *Error
+	JSON403      *Error
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r AddUserResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r AddUserResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type WhoAmIResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		// Username The username of the current user
+		Username string `json:"username"`
+	}
+	JSON401 *Error
+	JSON403 *Error
+	JSON500 *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r WhoAmIResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r WhoAmIResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListAlertsWithResponse request returning *ListAlertsResponse
+func (c *ClientWithResponses) ListAlertsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListAlertsResponse, error) {
+	rsp, err := c.ListAlerts(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListAlertsResponse(rsp)
+}
+
+// AuditWithResponse request returning *AuditResponse
+func (c *ClientWithResponses) AuditWithResponse(ctx context.Context, params *AuditParams, reqEditors ...RequestEditorFn) (*AuditResponse, error) {
+	rsp, err := c.Audit(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAuditResponse(rsp)
+}
+
+// ClearMaximumCurrentsWithResponse request returning *ClearMaximumCurrentsResponse
+func (c *ClientWithResponses) ClearMaximumCurrentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ClearMaximumCurrentsResponse, error) {
+	rsp, err := c.ClearMaximumCurrents(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseClearMaximumCurrentsResponse(rsp)
+}
+
+// DiagnosticsWithResponse request returning *DiagnosticsResponse
+func (c *ClientWithResponses) DiagnosticsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*DiagnosticsResponse, error) {
+	rsp, err := c.Diagnostics(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDiagnosticsResponse(rsp)
+}
+
+// EventsWithResponse request returning *EventsResponse
+func (c *ClientWithResponses) EventsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*EventsResponse, error) {
+	rsp, err := c.Events(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseEventsResponse(rsp)
+}
+
+// HistoryWithResponse request returning *HistoryResponse
+func (c *ClientWithResponses) HistoryWithResponse(ctx context.Context, params *HistoryParams, reqEditors ...RequestEditorFn) (*HistoryResponse, error) {
+	rsp, err := c.History(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHistoryResponse(rsp)
+}
+
+// StatusOutletWithResponse request returning *StatusOutletResponse
+func (c *ClientWithResponses) StatusOutletWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*StatusOutletResponse, error) {
+	rsp, err := c.StatusOutlet(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStatusOutletResponse(rsp)
+}
+
+// LockOutletWithBodyWithResponse request with arbitrary body returning *LockOutletResponse
+func (c *ClientWithResponses) LockOutletWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LockOutletResponse, error) {
+	rsp, err := c.LockOutletWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLockOutletResponse(rsp)
+}
+
+func (c *ClientWithResponses) LockOutletWithResponse(ctx context.Context, id Id, body LockOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*LockOutletResponse, error) {
+	rsp, err := c.LockOutlet(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLockOutletResponse(rsp)
+}
+
+// RebootOutletWithResponse request returning *RebootOutletResponse
+func (c *ClientWithResponses) RebootOutletWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RebootOutletResponse, error) {
+	rsp, err := c.RebootOutlet(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRebootOutletResponse(rsp)
+}
+
+// SwitchOutletWithBodyWithResponse request with arbitrary body returning *SwitchOutletResponse
+func (c *ClientWithResponses) SwitchOutletWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SwitchOutletResponse, error) {
+	rsp, err := c.SwitchOutletWithBody(ctx, id, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSwitchOutletResponse(rsp)
+}
+
+func (c *ClientWithResponses) SwitchOutletWithResponse(ctx context.Context, id Id, body SwitchOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*SwitchOutletResponse, error) {
+	rsp, err := c.SwitchOutlet(ctx, id, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSwitchOutletResponse(rsp)
+}
+
+// DeleteScheduleWithResponse request returning *DeleteScheduleResponse
+func (c *ClientWithResponses) DeleteScheduleWithResponse(ctx context.Context, sid string, reqEditors ...RequestEditorFn) (*DeleteScheduleResponse, error) {
+	rsp, err := c.DeleteSchedule(ctx, sid, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteScheduleResponse(rsp)
+}
+
+// ListSchedulesWithResponse request returning *ListSchedulesResponse
+func (c *ClientWithResponses) ListSchedulesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSchedulesResponse, error) {
+	rsp, err := c.ListSchedules(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSchedulesResponse(rsp)
+}
+
+// AddScheduleWithBodyWithResponse request with arbitrary body returning *AddScheduleResponse
+func (c *ClientWithResponses) AddScheduleWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddScheduleResponse, error) {
+	rsp, err := c.AddScheduleWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddScheduleResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddScheduleWithResponse(ctx context.Context, body AddScheduleJSONRequestBody, reqEditors ...RequestEditorFn) (*AddScheduleResponse, error) {
+	rsp, err := c.AddSchedule(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddScheduleResponse(rsp)
+}
+
+// ListScheduleRunsWithResponse request returning *ListScheduleRunsResponse
+func (c *ClientWithResponses) ListScheduleRunsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListScheduleRunsResponse, error) {
+	rsp, err := c.ListScheduleRuns(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListScheduleRunsResponse(rsp)
+}
+
+// ListSequencesWithResponse request returning *ListSequencesResponse
+func (c *ClientWithResponses) ListSequencesWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListSequencesResponse, error) {
+	rsp, err := c.ListSequences(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSequencesResponse(rsp)
+}
+
+// GetSequenceWithResponse request returning *GetSequenceResponse
+func (c *ClientWithResponses) GetSequenceWithResponse(ctx context.Context, seq Seq, reqEditors ...RequestEditorFn) (*GetSequenceResponse, error) {
+	rsp, err := c.GetSequence(ctx, seq, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetSequenceResponse(rsp)
+}
+
+// RunSequenceWithBodyWithResponse request with arbitrary body returning *RunSequenceResponse
+func (c *ClientWithResponses) RunSequenceWithBodyWithResponse(ctx context.Context, seq Seq, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*RunSequenceResponse, error) {
+	rsp, err := c.RunSequenceWithBody(ctx, seq, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRunSequenceResponse(rsp)
+}
+
+func (c *ClientWithResponses) RunSequenceWithResponse(ctx context.Context, seq Seq, body RunSequenceJSONRequestBody, reqEditors ...RequestEditorFn) (*RunSequenceResponse, error) {
+	rsp, err := c.RunSequence(ctx, seq, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRunSequenceResponse(rsp)
+}
+
+// StatusWithResponse request returning *StatusResponse
+func (c *ClientWithResponses) StatusWithResponse(ctx context.Context, params *StatusParams, reqEditors ...RequestEditorFn) (*StatusResponse, error) {
+	rsp, err := c.Status(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStatusResponse(rsp)
+}
+
+// TemperatureWithResponse request returning *TemperatureResponse
+func (c *ClientWithResponses) TemperatureWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TemperatureResponse, error) {
+	rsp, err := c.Temperature(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTemperatureResponse(rsp)
+}
+
+// DeleteUserWithResponse request returning *DeleteUserResponse
+func (c *ClientWithResponses) DeleteUserWithResponse(ctx context.Context, name Name, reqEditors ...RequestEditorFn) (*DeleteUserResponse, error) {
+	rsp, err := c.DeleteUser(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteUserResponse(rsp)
+}
+
+// SetUserOutletsWithBodyWithResponse request with arbitrary body returning *SetUserOutletsResponse
+func (c *ClientWithResponses) SetUserOutletsWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SetUserOutletsResponse, error) {
+	rsp, err := c.SetUserOutletsWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetUserOutletsResponse(rsp)
+}
+
+func (c *ClientWithResponses) SetUserOutletsWithResponse(ctx context.Context, name Name, body SetUserOutletsJSONRequestBody, reqEditors ...RequestEditorFn) (*SetUserOutletsResponse, error) {
+	rsp, err := c.SetUserOutlets(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSetUserOutletsResponse(rsp)
+}
+
+// ChangePasswordWithBodyWithResponse request with arbitrary body returning *ChangePasswordResponse
+func (c *ClientWithResponses) ChangePasswordWithBodyWithResponse(ctx context.Context, name Name, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ChangePasswordResponse, error) {
+	rsp, err := c.ChangePasswordWithBody(ctx, name, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseChangePasswordResponse(rsp)
+}
+
+func (c *ClientWithResponses) ChangePasswordWithResponse(ctx context.Context, name Name, body ChangePasswordJSONRequestBody, reqEditors ...RequestEditorFn) (*ChangePasswordResponse, error) {
+	rsp, err := c.ChangePassword(ctx, name, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseChangePasswordResponse(rsp)
+}
+
+// ListUsersWithResponse request returning *ListUsersResponse
+func (c *ClientWithResponses) ListUsersWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ListUsersResponse, error) {
+	rsp, err := c.ListUsers(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListUsersResponse(rsp)
+}
+
+// AddUserWithBodyWithResponse request with arbitrary body returning *AddUserResponse
+func (c *ClientWithResponses) AddUserWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*AddUserResponse, error) {
+	rsp, err := c.AddUserWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddUserResponse(rsp)
+}
+
+func (c *ClientWithResponses) AddUserWithResponse(ctx context.Context, body AddUserJSONRequestBody, reqEditors ...RequestEditorFn) (*AddUserResponse, error) {
+	rsp, err := c.AddUser(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAddUserResponse(rsp)
+}
+
+// WhoAmIWithResponse request returning *WhoAmIResponse
+func (c *ClientWithResponses) WhoAmIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*WhoAmIResponse, error) {
+	rsp, err := c.WhoAmI(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWhoAmIResponse(rsp)
+}
+
+// ParseListAlertsResponse parses an HTTP response from a ListAlertsWithResponse call
+func ParseListAlertsResponse(rsp *http.Response) (*ListAlertsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListAlertsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Alert
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAuditResponse parses an HTTP response from a AuditWithResponse call
+func ParseAuditResponse(rsp *http.Response) (*AuditResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AuditResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []AuditEntry
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseClearMaximumCurrentsResponse parses an HTTP response from a ClearMaximumCurrentsWithResponse call
+func ParseClearMaximumCurrentsResponse(rsp *http.Response) (*ClearMaximumCurrentsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ClearMaximumCurrentsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDiagnosticsResponse parses an HTTP response from a DiagnosticsWithResponse call
+func ParseDiagnosticsResponse(rsp *http.Response) (*DiagnosticsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DiagnosticsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Diagnostics
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseEventsResponse parses an HTTP response from a EventsWithResponse call
+func ParseEventsResponse(rsp *http.Response) (*EventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &EventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseHistoryResponse parses an HTTP response from a HistoryWithResponse call
+func ParseHistoryResponse(rsp *http.Response) (*HistoryResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HistoryResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStatusOutletResponse parses an HTTP response from a StatusOutletWithResponse call
+func ParseStatusOutletResponse(rsp *http.Response) (*StatusOutletResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StatusOutletResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []OutletStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLockOutletResponse parses an HTTP response from a LockOutletWithResponse call
+func ParseLockOutletResponse(rsp *http.Response) (*LockOutletResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LockOutletResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []OutletResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRebootOutletResponse parses an HTTP response from a RebootOutletWithResponse call
+func ParseRebootOutletResponse(rsp *http.Response) (*RebootOutletResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RebootOutletResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []OutletResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSwitchOutletResponse parses an HTTP response from a SwitchOutletWithResponse call
+func ParseSwitchOutletResponse(rsp *http.Response) (*SwitchOutletResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SwitchOutletResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []OutletResult
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteScheduleResponse parses an HTTP response from a DeleteScheduleWithResponse call
+func ParseDeleteScheduleResponse(rsp *http.Response) (*DeleteScheduleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteScheduleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListSchedulesResponse parses an HTTP response from a ListSchedulesWithResponse call
+func ParseListSchedulesResponse(rsp *http.Response) (*ListSchedulesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSchedulesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Schedule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddScheduleResponse parses an HTTP response from a AddScheduleWithResponse call
+func ParseAddScheduleResponse(rsp *http.Response) (*AddScheduleResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddScheduleResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Schedule
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListScheduleRunsResponse parses an HTTP response from a ListScheduleRunsWithResponse call
+func ParseListScheduleRunsResponse(rsp *http.Response) (*ListScheduleRunsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListScheduleRunsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []ScheduleRun
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListSequencesResponse parses an HTTP response from a ListSequencesWithResponse call
+func ParseListSequencesResponse(rsp *http.Response) (*ListSequencesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSequencesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Sequence
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetSequenceResponse parses an HTTP response from a GetSequenceWithResponse call
+func ParseGetSequenceResponse(rsp *http.Response) (*GetSequenceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetSequenceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Sequence
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRunSequenceResponse parses an HTTP response from a RunSequenceWithResponse call
+func ParseRunSequenceResponse(rsp *http.Response) (*RunSequenceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RunSequenceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SequenceRun
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseStatusResponse parses an HTTP response from a StatusWithResponse call
+func ParseStatusResponse(rsp *http.Response) (*StatusResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &StatusResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Status
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTemperatureResponse parses an HTTP response from a TemperatureWithResponse call
+func ParseTemperatureResponse(rsp *http.Response) (*TemperatureResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TemperatureResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Temperature Temperature [C]
+			Temperature float32 `json:"temperature"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteUserResponse parses an HTTP response from a DeleteUserWithResponse call
+func ParseDeleteUserResponse(rsp *http.Response) (*DeleteUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSetUserOutletsResponse parses an HTTP response from a SetUserOutletsWithResponse call
+func ParseSetUserOutletsResponse(rsp *http.Response) (*SetUserOutletsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SetUserOutletsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseChangePasswordResponse parses an HTTP response from a ChangePasswordWithResponse call
+func ParseChangePasswordResponse(rsp *http.Response) (*ChangePasswordResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ChangePasswordResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListUsersResponse parses an HTTP response from a ListUsersWithResponse call
+func ParseListUsersResponse(rsp *http.Response) (*ListUsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListUsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseAddUserResponse parses an HTTP response from a AddUserWithResponse call
+func ParseAddUserResponse(rsp *http.Response) (*AddUserResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &AddUserResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseWhoAmIResponse parses an HTTP response from a WhoAmIWithResponse call
+func ParseWhoAmIResponse(rsp *http.Response) (*WhoAmIResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &WhoAmIResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			// Username The username of the current user
+			Username string `json:"username"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// List active alerts
+	// (GET /alerts)
+	ListAlerts(w http.ResponseWriter, r *http.Request)
+	// Query the audit log
+	// (GET /audit)
+	Audit(w http.ResponseWriter, r *http.Request, params AuditParams)
+	// Clear peak RMS current
+	// (POST /clear)
+	ClearMaximumCurrents(w http.ResponseWriter, r *http.Request)
+	// Get diagnostics of the PDU connection and poller
+	// (GET /diagnostics)
+	Diagnostics(w http.ResponseWriter, r *http.Request)
+	// Stream status updates and change events
+	// (GET /events)
+	Events(w http.ResponseWriter, r *http.Request)
+	// Get past status samples
+	// (GET /history)
+	History(w http.ResponseWriter, r *http.Request, params HistoryParams)
+	// Get status of outlets
+	// (GET /outlet/{id})
+	StatusOutlet(w http.ResponseWriter, r *http.Request, id Id)
+	// Switch lock state of outlet
+	// (POST /outlet/{id}/lock)
+	LockOutlet(w http.ResponseWriter, r *http.Request, id Id)
+	// Reboot the outlet
+	// (POST /outlet/{id}/reboot)
+	RebootOutlet(w http.ResponseWriter, r *http.Request, id Id)
+	// Switch state of outlet
+	// (POST /outlet/{id}/state)
+	SwitchOutlet(w http.ResponseWriter, r *http.Request, id Id)
+	// Remove a scheduled outlet action
+	// (DELETE /schedule/{sid})
+	DeleteSchedule(w http.ResponseWriter, r *http.Request, sid string)
+	// List scheduled outlet actions
+	// (GET /schedules)
+	ListSchedules(w http.ResponseWriter, r *http.Request)
+	// Add a scheduled outlet action
+	// (POST /schedules)
+	AddSchedule(w http.ResponseWriter, r *http.Request)
+	// List recent runs of scheduled outlet actions
+	// (GET /schedules/runs)
+	ListScheduleRuns(w http.ResponseWriter, r *http.Request)
+	// List power sequences
+	// (GET /sequences)
+	ListSequences(w http.ResponseWriter, r *http.Request)
+	// Get a power sequence and the progress of its last run
+	// (GET /sequences/{seq})
+	GetSequence(w http.ResponseWriter, r *http.Request, seq Seq)
+	// Run a power sequence
+	// (POST /sequences/{seq}/run)
+	RunSequence(w http.ResponseWriter, r *http.Request, seq Seq)
+	// Get status of PDU
+	// (GET /status)
+	Status(w http.ResponseWriter, r *http.Request, params StatusParams)
+	// Get temperature of PDU
+	// (GET /temperature)
+	Temperature(w http.ResponseWriter, r *http.Request)
+	// Remove a user account from the PDU
+	// (DELETE /user/{name})
+	DeleteUser(w http.ResponseWriter, r *http.Request, name Name)
+	// Set the outlets which a user is allowed to control
+	// (POST /user/{name}/outlets)
+	SetUserOutlets(w http.ResponseWriter, r *http.Request, name Name)
+	// Change the password of a user
+	// (POST /user/{name}/password)
+	ChangePassword(w http.ResponseWriter, r *http.Request, name Name)
+	// List user accounts of the PDU
+	// (GET /users)
+	ListUsers(w http.ResponseWriter, r *http.Request)
+	// Add a user account to the PDU
+	// (POST /users)
+	AddUser(w http.ResponseWriter, r *http.Request)
+	// Get name of current user
+	// (GET /whoami)
+	WhoAmI(w http.ResponseWriter, r *http.Request)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// ListAlerts operation middleware
+func (siw *ServerInterfaceWrapper) ListAlerts(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListAlerts(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Audit operation middleware
+func (siw *ServerInterfaceWrapper) Audit(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AuditParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "outlet" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "outlet", r.URL.Query(), &params.Outlet)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "outlet", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "operation" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "operation", r.URL.Query(), &params.Operation)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "operation", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "client" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "client", r.URL.Query(), &params.Client)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "client", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Audit(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ClearMaximumCurrents operation middleware
+func (siw *ServerInterfaceWrapper) ClearMaximumCurrents(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ClearMaximumCurrents(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Diagnostics operation middleware
+func (siw *ServerInterfaceWrapper) Diagnostics(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Diagnostics(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Events operation middleware
+func (siw *ServerInterfaceWrapper) Events(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Events(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// History operation middleware
+func (siw *ServerInterfaceWrapper) History(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params HistoryParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "step" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "step", r.URL.Query(), &params.Step)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "step", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "outlet" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "outlet", r.URL.Query(), &params.Outlet)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "outlet", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.History(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// StatusOutlet operation middleware
+func (siw *ServerInterfaceWrapper) StatusOutlet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.StatusOutlet(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// LockOutlet operation middleware
+func (siw *ServerInterfaceWrapper) LockOutlet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LockOutlet(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RebootOutlet operation middleware
+func (siw *ServerInterfaceWrapper) RebootOutlet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RebootOutlet(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SwitchOutlet operation middleware
+func (siw *ServerInterfaceWrapper) SwitchOutlet(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id Id
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SwitchOutlet(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteSchedule operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSchedule(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "sid" -------------
+	var sid string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sid", r.PathValue("sid"), &sid, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sid", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSchedule(w, r, sid)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSchedules operation middleware
+func (siw *ServerInterfaceWrapper) ListSchedules(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSchedules(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddSchedule operation middleware
+func (siw *ServerInterfaceWrapper) AddSchedule(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddSchedule(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListScheduleRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListScheduleRuns(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListScheduleRuns(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListSequences operation middleware
+func (siw *ServerInterfaceWrapper) ListSequences(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListSequences(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetSequence operation middleware
+func (siw *ServerInterfaceWrapper) GetSequence(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "seq" -------------
+	var seq Seq
+
+	err = runtime.BindStyledParameterWithOptions("simple", "seq", r.PathValue("seq"), &seq, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "seq", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSequence(w, r, seq)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// RunSequence operation middleware
+func (siw *ServerInterfaceWrapper) RunSequence(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "seq" -------------
+	var seq Seq
+
+	err = runtime.BindStyledParameterWithOptions("simple", "seq", r.PathValue("seq"), &seq, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "seq", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RunSequence(w, r, seq)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Status operation middleware
+func (siw *ServerInterfaceWrapper) Status(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StatusParams
+
+	// ------------- Optional query parameter "detailed" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "detailed", r.URL.Query(), &params.Detailed)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "detailed", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Status(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// Temperature operation middleware
+func (siw *ServerInterfaceWrapper) Temperature(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.Temperature(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteUser operation middleware
+func (siw *ServerInterfaceWrapper) DeleteUser(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name Name
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", r.PathValue("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteUser(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// SetUserOutlets operation middleware
+func (siw *ServerInterfaceWrapper) SetUserOutlets(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name Name
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", r.PathValue("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.SetUserOutlets(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ChangePassword operation middleware
+func (siw *ServerInterfaceWrapper) ChangePassword(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name Name
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", r.PathValue("name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ChangePassword(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListUsers operation middleware
+func (siw *ServerInterfaceWrapper) ListUsers(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListUsers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// AddUser operation middleware
+func (siw *ServerInterfaceWrapper) AddUser(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.AddUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// WhoAmI operation middleware
+func (siw *ServerInterfaceWrapper) WhoAmI(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BasicAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.WhoAmI(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+type UnescapedCookieParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnescapedCookieParamError) Error() string {
+	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
+}
+
+func (e *UnescapedCookieParamError) Unwrap() error {
+	return e.Err
+}
+
+type UnmarshalingParamError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *UnmarshalingParamError) Error() string {
+	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *UnmarshalingParamError) Unwrap() error {
+	return e.Err
+}
+
+type RequiredParamError struct {
+	ParamName string
+}
+
+func (e *RequiredParamError) Error() string {
+	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
+}
+
+type RequiredHeaderError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *RequiredHeaderError) Error() string {
+	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
+}
+
+func (e *RequiredHeaderError) Unwrap() error {
+	return e.Err
+}
+
+type InvalidParamFormatError struct {
+	ParamName string
+	Err       error
+}
+
+func (e *InvalidParamFormatError) Error() string {
+	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
+}
+
+func (e *InvalidParamFormatError) Unwrap() error {
+	return e.Err
+}
+
+type TooManyValuesForParamError struct {
+	ParamName string
+	Count     int
+}
+
+func (e *TooManyValuesForParamError) Error() string {
+	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
+}
+
+// Handler creates http.Handler with routing matching OpenAPI spec.
+func Handler(si ServerInterface) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{})
+}
+
+// ServeMux is an abstraction of http.ServeMux.
+type ServeMux interface {
+	HandleFunc(pattern string, handler func(http.ResponseWriter, *http.Request))
+	ServeHTTP(w http.ResponseWriter, r *http.Request)
+}
+
+type StdHTTPServerOptions struct {
+	BaseURL          string
+	BaseRouter       ServeMux
+	Middlewares      []MiddlewareFunc
+	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
+func HandlerFromMux(si ServerInterface, m ServeMux) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseRouter: m,
+	})
+}
+
+func HandlerFromMuxWithBaseURL(si ServerInterface, m ServeMux, baseURL string) http.Handler {
+	return HandlerWithOptions(si, StdHTTPServerOptions{
+		BaseURL:    baseURL,
+		BaseRouter: m,
+	})
+}
+
+// HandlerWithOptions creates http.Handler with additional options
+func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
+	m := options.BaseRouter
+
+	if m == nil {
+		m = http.NewServeMux()
+	}
+	if options.ErrorHandlerFunc == nil {
+		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}
+
+	wrapper := ServerInterfaceWrapper{
+		Handler:            si,
+		HandlerMiddlewares: options.Middlewares,
+		ErrorHandlerFunc:   options.ErrorHandlerFunc,
+	}
+
+	m.HandleFunc("GET "+options.BaseURL+"/alerts", wrapper.ListAlerts)
+	m.HandleFunc("GET "+options.BaseURL+"/audit", wrapper.Audit)
+	m.HandleFunc("POST "+options.BaseURL+"/clear", wrapper.ClearMaximumCurrents)
+	m.HandleFunc("GET "+options.BaseURL+"/diagnostics", wrapper.Diagnostics)
+	m.HandleFunc("GET "+options.BaseURL+"/events", wrapper.Events)
+	m.HandleFunc("GET "+options.BaseURL+"/history", wrapper.History)
+	m.HandleFunc("GET "+options.BaseURL+"/outlet/{id}", wrapper.StatusOutlet)
+	m.HandleFunc("POST "+options.BaseURL+"/outlet/{id}/lock", wrapper.LockOutlet)
+	m.HandleFunc("POST "+options.BaseURL+"/outlet/{id}/reboot", wrapper.RebootOutlet)
+	m.HandleFunc("POST "+options.BaseURL+"/outlet/{id}/state", wrapper.SwitchOutlet)
+	m.HandleFunc("DELETE "+options.BaseURL+"/schedule/{sid}", wrapper.DeleteSchedule)
+	m.HandleFunc("GET "+options.BaseURL+"/schedules", wrapper.ListSchedules)
+	m.HandleFunc("POST "+options.BaseURL+"/schedules", wrapper.AddSchedule)
+	m.HandleFunc("GET "+options.BaseURL+"/schedules/runs", wrapper.ListScheduleRuns)
+	m.HandleFunc("GET "+options.BaseURL+"/sequences", wrapper.ListSequences)
+	m.HandleFunc("GET "+options.BaseURL+"/sequences/{seq}", wrapper.GetSequence)
+	m.HandleFunc("POST "+options.BaseURL+"/sequences/{seq}/run", wrapper.RunSequence)
+	m.HandleFunc("GET "+options.BaseURL+"/status", wrapper.Status)
+	m.HandleFunc("GET "+options.BaseURL+"/temperature", wrapper.Temperature)
+	m.HandleFunc("DELETE "+options.BaseURL+"/user/{name}", wrapper.DeleteUser)
+	m.HandleFunc("POST "+options.BaseURL+"/user/{name}/outlets", wrapper.SetUserOutlets)
+	m.HandleFunc("POST "+options.BaseURL+"/user/{name}/password", wrapper.ChangePassword)
+	m.HandleFunc("GET "+options.BaseURL+"/users", wrapper.ListUsers)
+	m.HandleFunc("POST "+options.BaseURL+"/users", wrapper.AddUser)
+	m.HandleFunc("GET "+options.BaseURL+"/whoami", wrapper.WhoAmI)
+
+	return m
+}
+
+type ErrorJSONResponse Error
+
+type SuccessResponse struct {
+}
+
+type ListAlertsRequestObject struct {
+}
+
+type ListAlertsResponseObject interface {
+	VisitListAlertsResponse(w http.ResponseWriter) error
+}
+
+type ListAlerts200JSONResponse []Alert
+
+func (response ListAlerts200JSONResponse) VisitListAlertsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAlerts401JSONResponse struct{ ErrorJSONResponse }
+
+func (response ListAlerts401JSONResponse) VisitListAlertsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAlerts403JSONResponse Error
+
+func (response ListAlerts403JSONResponse) VisitListAlertsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListAlerts500JSONResponse Error
+
+func (response ListAlerts500JSONResponse) VisitListAlertsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type AuditRequestObject struct {
+	Params AuditParams
+}
+
+type AuditResponseObject interface {
+	VisitAuditResponse(w http.ResponseWriter) error
+}
+
+type Audit200JSONResponse []AuditEntry
+
+func (response Audit200JSONResponse) VisitAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Audit400JSONResponse struct{ ErrorJSONResponse }
+
+func (response Audit400JSONResponse) VisitAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Audit401JSONResponse Error
+
+func (response Audit401JSONResponse) VisitAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Audit403JSONResponse Error
+
+func (response Audit403JSONResponse) VisitAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Audit500JSONResponse Error
+
+func (response Audit500JSONResponse) VisitAuditResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClearMaximumCurrentsRequestObject struct {
+}
+
+type ClearMaximumCurrentsResponseObject interface {
+	VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error
+}
+
+type ClearMaximumCurrents200Response = SuccessResponse
+
+func (response ClearMaximumCurrents200Response) VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
+
+type ClearMaximumCurrents400JSONResponse struct{ ErrorJSONResponse }
+
+func (response ClearMaximumCurrents400JSONResponse) VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClearMaximumCurrents401JSONResponse Error
+
+func (response ClearMaximumCurrents401JSONResponse) VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClearMaximumCurrents403JSONResponse Error
+
+func (response ClearMaximumCurrents403JSONResponse) VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ClearMaximumCurrents500JSONResponse Error
+
+func (response ClearMaximumCurrents500JSONResponse) VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DiagnosticsRequestObject struct {
+}
+
+type DiagnosticsResponseObject interface {
+	VisitDiagnosticsResponse(w http.ResponseWriter) error
+}
+
+type Diagnostics200JSONResponse Diagnostics
+
+func (response Diagnostics200JSONResponse) VisitDiagnosticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Diagnostics401JSONResponse struct{ ErrorJSONResponse }
+
+func (response Diagnostics401JSONResponse) VisitDiagnosticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Diagnostics403JSONResponse Error
+
+func (response Diagnostics403JSONResponse) VisitDiagnosticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Diagnostics500JSONResponse Error
+
+func (response Diagnostics500JSONResponse) VisitDiagnosticsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type EventsRequestObject struct {
+}
+
+type EventsResponseObject interface {
+	VisitEventsResponse(w http.ResponseWriter) error
+}
+
+type Events200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response Events200TexteventStreamResponse) VisitEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type Events401JSONResponse struct{ ErrorJSONResponse }
+
+func (response Events401JSONResponse) VisitEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Events403JSONResponse Error
+
+func (response Events403JSONResponse) VisitEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type Events500JSONResponse Error
+
+func (response Events500JSONResponse) VisitEventsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type HistoryRequestObject struct {
+	Params HistoryParams
+}
+
+type HistoryResponseObject interface {
+	VisitHistoryResponse(w http.ResponseWriter) error
+}
+
+type History200JSONResponse []Status
+
+func (response History200JSONResponse) VisitHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type History400JSONResponse struct{ ErrorJSONResponse }
+
+func (response History400JSONResponse) VisitHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type History401JSONResponse Error
+
+func (response History401JSONResponse) VisitHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type History403JSONResponse Error
+
+func (response History403JSONResponse) VisitHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type History404JSONResponse Error
+
+func (response History404JSONResponse) VisitHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type History500JSONResponse Error
+
+func (response History500JSONResponse) VisitHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StatusOutletRequestObject struct {
+	Id Id `json:"id"`
+}
+
+type StatusOutletResponseObject interface {
+	VisitStatusOutletResponse(w http.ResponseWriter) error
+}
+
+type StatusOutlet200JSONResponse []OutletStatus
+
+func (response StatusOutlet200JSONResponse) VisitStatusOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StatusOutlet400JSONResponse struct{ ErrorJSONResponse }
+
+func (response StatusOutlet400JSONResponse) VisitStatusOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type StatusOutlet401JSONResponse Error
+
+func (response StatusOutlet401JSONResponse) VisitStatusOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ClientWithResponsesInterface is the interface specification for the client with responses above.
-type ClientWithResponsesInterface interface {
-	// ClearMaximumCurrentsWithResponse request
-	ClearMaximumCurrentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ClearMaximumCurrentsResponse, error)
+type StatusOutlet403JSONResponse Error
 
-	// LockOutletWithBodyWithResponse request with any body
-	LockOutletWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LockOutletResponse, error)
+func (response StatusOutlet403JSONResponse) VisitStatusOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
 
-	LockOutletWithResponse(ctx context.Context, id Id, body LockOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*LockOutletResponse, error)
+	return json.NewEncoder(w).Encode(response)
+}
 
-	// RebootOutletWithResponse request
-	RebootOutletWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RebootOutletResponse, error)
+type StatusOutlet404JSONResponse Error
 
-	// SwitchOutletWithBodyWithResponse request with any body
-	SwitchOutletWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SwitchOutletResponse, error)
+func (response StatusOutlet404JSONResponse) VisitStatusOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
 
-	SwitchOutletWithResponse(ctx context.Context, id Id, body SwitchOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*SwitchOutletResponse, error)
+	return json.NewEncoder(w).Encode(response)
+}
 
-	// StatusWithResponse request
-	StatusWithResponse(ctx context.Context, params *StatusParams, reqEditors ...RequestEditorFn) (*StatusResponse, error)
+type StatusOutlet500JSONResponse Error
 
-	// TemperatureWithResponse request
-	TemperatureWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TemperatureResponse, error)
+func (response StatusOutlet500JSONResponse) VisitStatusOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	// WhoAmIWithResponse request
-	WhoAmIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*WhoAmIResponse, error)
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ClearMaximumCurrentsResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *Error
-	JSON401      *Error
-	JSON403      *Error
-	JSON500      *Error
+type LockOutletRequestObject struct {
+	Id   Id `json:"id"`
+	Body *LockOutletJSONRequestBody
 }
 
-// Status returns HTTPResponse.Status
-func (r ClearMaximumCurrentsResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+type LockOutletResponseObject interface {
+	VisitLockOutletResponse(w http.ResponseWriter) error
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r ClearMaximumCurrentsResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+type LockOutlet200JSONResponse []OutletResult
 
-type LockOutletResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *Error
-	JSON401      *Error
-	JSON403      *Error
-	JSON404      *Error
-	JSON500      *Error
-}
+func (response LockOutlet200JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-// Status returns HTTPResponse.Status
-func (r LockOutletResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+	return json.NewEncoder(w).Encode(response)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r LockOutletResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+type LockOutlet400JSONResponse struct{ ErrorJSONResponse }
 
-type RebootOutletResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *Error
-	JSON401      *Error
-	JSON403      *Error
-	JSON404      *Error
-	JSON500      *Error
-}
+func (response LockOutlet400JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-// Status returns HTTPResponse.Status
-func (r RebootOutletResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+	return json.NewEncoder(w).Encode(response)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r RebootOutletResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+type LockOutlet401JSONResponse Error
 
-type SwitchOutletResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON400      *Error
-	JSON401      *Error
-	JSON403      *Error
-	JSON404      *Error
-	JSON500      *Error
-}
+func (response LockOutlet401JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
 
-// Status returns HTTPResponse.Status
-func (r SwitchOutletResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+	return json.NewEncoder(w).Encode(response)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r SwitchOutletResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+type LockOutlet403JSONResponse Error
 
-type StatusResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *Status
-	JSON401      *Error
-	JSON403      *Error
-	JSON500      *Error
-}
+func (response LockOutlet403JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
 
-// Status returns HTTPResponse.Status
-func (r StatusResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+	return json.NewEncoder(w).Encode(response)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r StatusResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
-}
+type LockOutlet404JSONResponse Error
 
-type TemperatureResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *struct {
-		// Temperature Temperature [C]
-		Temperature float32 `json:"temperature"`
-	}
-	JSON401 *Error
-	JSON403 *Error
-	JSON500 *Error
+func (response LockOutlet404JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// Status returns HTTPResponse.Status
-func (r TemperatureResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+type LockOutlet500JSONResponse Error
+
+func (response LockOutlet500JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r TemperatureResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+type RebootOutletRequestObject struct {
+	Id Id `json:"id"`
 }
 
-type WhoAmIResponse struct {
-	Body         []byte
-	HTTPResponse *http.Response
-	JSON200      *struct {
-		// Username The username of the current user
-		Username string `json:"username"`
-	}
-	JSON401 *Error
-	JSON403 *Error
-	JSON500 *Error
+type RebootOutletResponseObject interface {
+	VisitRebootOutletResponse(w http.ResponseWriter) error
 }
 
-// Status returns HTTPResponse.Status
-func (r WhoAmIResponse) Status() string {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.Status
-	}
-	return http.StatusText(0)
+type RebootOutlet200JSONResponse []OutletResult
+
+func (response RebootOutlet200JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// StatusCode returns HTTPResponse.StatusCode
-func (r WhoAmIResponse) StatusCode() int {
-	if r.HTTPResponse != nil {
-		return r.HTTPResponse.StatusCode
-	}
-	return 0
+type RebootOutlet400JSONResponse struct{ ErrorJSONResponse }
+
+func (response RebootOutlet400JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ClearMaximumCurrentsWithResponse request returning *ClearMaximumCurrentsResponse
-func (c *ClientWithResponses) ClearMaximumCurrentsWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ClearMaximumCurrentsResponse, error) {
-	rsp, err := c.ClearMaximumCurrents(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseClearMaximumCurrentsResponse(rsp)
+type RebootOutlet401JSONResponse Error
+
+func (response RebootOutlet401JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// LockOutletWithBodyWithResponse request with arbitrary body returning *LockOutletResponse
-func (c *ClientWithResponses) LockOutletWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LockOutletResponse, error) {
-	rsp, err := c.LockOutletWithBody(ctx, id, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseLockOutletResponse(rsp)
+type RebootOutlet403JSONResponse Error
+
+func (response RebootOutlet403JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (c *ClientWithResponses) LockOutletWithResponse(ctx context.Context, id Id, body LockOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*LockOutletResponse, error) {
-	rsp, err := c.LockOutlet(ctx, id, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseLockOutletResponse(rsp)
+type RebootOutlet404JSONResponse Error
+
+func (response RebootOutlet404JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// RebootOutletWithResponse request returning *RebootOutletResponse
-func (c *ClientWithResponses) RebootOutletWithResponse(ctx context.Context, id Id, reqEditors ...RequestEditorFn) (*RebootOutletResponse, error) {
-	rsp, err := c.RebootOutlet(ctx, id, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseRebootOutletResponse(rsp)
+type RebootOutlet500JSONResponse Error
+
+func (response RebootOutlet500JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// SwitchOutletWithBodyWithResponse request with arbitrary body returning *SwitchOutletResponse
-func (c *ClientWithResponses) SwitchOutletWithBodyWithResponse(ctx context.Context, id Id, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SwitchOutletResponse, error) {
-	rsp, err := c.SwitchOutletWithBody(ctx, id, contentType, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseSwitchOutletResponse(rsp)
+type SwitchOutletRequestObject struct {
+	Id   Id `json:"id"`
+	Body *SwitchOutletJSONRequestBody
 }
 
-func (c *ClientWithResponses) SwitchOutletWithResponse(ctx context.Context, id Id, body SwitchOutletJSONRequestBody, reqEditors ...RequestEditorFn) (*SwitchOutletResponse, error) {
-	rsp, err := c.SwitchOutlet(ctx, id, body, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseSwitchOutletResponse(rsp)
+type SwitchOutletResponseObject interface {
+	VisitSwitchOutletResponse(w http.ResponseWriter) error
 }
 
-// StatusWithResponse request returning *StatusResponse
-func (c *ClientWithResponses) StatusWithResponse(ctx context.Context, params *StatusParams, reqEditors ...RequestEditorFn) (*StatusResponse, error) {
-	rsp, err := c.Status(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseStatusResponse(rsp)
+type SwitchOutlet200JSONResponse []OutletResult
+
+func (response SwitchOutlet200JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// TemperatureWithResponse request returning *TemperatureResponse
-func (c *ClientWithResponses) TemperatureWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*TemperatureResponse, error) {
-	rsp, err := c.Temperature(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseTemperatureResponse(rsp)
+type SwitchOutlet400JSONResponse struct{ ErrorJSONResponse }
+
+func (response SwitchOutlet400JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// WhoAmIWithResponse request returning *WhoAmIResponse
-func (c *ClientWithResponses) WhoAmIWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*WhoAmIResponse, error) {
-	rsp, err := c.WhoAmI(ctx, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseWhoAmIResponse(rsp)
+type SwitchOutlet401JSONResponse Error
+
+func (response SwitchOutlet401JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ParseClearMaximumCurrentsResponse parses an HTTP response from a ClearMaximumCurrentsWithResponse call
-func ParseClearMaximumCurrentsResponse(rsp *http.Response) (*ClearMaximumCurrentsResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+type SwitchOutlet403JSONResponse Error
 
-	response := &ClearMaximumCurrentsResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+func (response SwitchOutlet403JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON401 = &dest
+type SwitchOutlet404JSONResponse Error
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON403 = &dest
+func (response SwitchOutlet404JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	}
+type SwitchOutlet500JSONResponse Error
 
-	return response, nil
+func (response SwitchOutlet500JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ParseLockOutletResponse parses an HTTP response from a LockOutletWithResponse call
-func ParseLockOutletResponse(rsp *http.Response) (*LockOutletResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+type DeleteScheduleRequestObject struct {
+	Sid string `json:"sid"`
+}
 
-	response := &LockOutletResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+type DeleteScheduleResponseObject interface {
+	VisitDeleteScheduleResponse(w http.ResponseWriter) error
+}
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
+type DeleteSchedule200Response = SuccessResponse
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON401 = &dest
+func (response DeleteSchedule200Response) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON403 = &dest
+type DeleteSchedule400JSONResponse struct{ ErrorJSONResponse }
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON404 = &dest
+func (response DeleteSchedule400JSONResponse) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	}
+type DeleteSchedule401JSONResponse Error
 
-	return response, nil
+func (response DeleteSchedule401JSONResponse) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ParseRebootOutletResponse parses an HTTP response from a RebootOutletWithResponse call
-func ParseRebootOutletResponse(rsp *http.Response) (*RebootOutletResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+type DeleteSchedule403JSONResponse Error
 
-	response := &RebootOutletResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+func (response DeleteSchedule403JSONResponse) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON401 = &dest
+type DeleteSchedule404JSONResponse Error
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON403 = &dest
+func (response DeleteSchedule404JSONResponse) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON404 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+type DeleteSchedule500JSONResponse Error
 
-	}
+func (response DeleteSchedule500JSONResponse) VisitDeleteScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	return response, nil
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ParseSwitchOutletResponse parses an HTTP response from a SwitchOutletWithResponse call
-func ParseSwitchOutletResponse(rsp *http.Response) (*SwitchOutletResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+type ListSchedulesRequestObject struct {
+}
+
+type ListSchedulesResponseObject interface {
+	VisitListSchedulesResponse(w http.ResponseWriter) error
+}
+
+type ListSchedules200JSONResponse []Schedule
 
-	response := &SwitchOutletResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+func (response ListSchedules200JSONResponse) VisitListSchedulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON400 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON401 = &dest
+type ListSchedules401JSONResponse struct{ ErrorJSONResponse }
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON403 = &dest
+func (response ListSchedules401JSONResponse) VisitListSchedulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON404 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+type ListSchedules403JSONResponse Error
 
-	}
+func (response ListSchedules403JSONResponse) VisitListSchedulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
 
-	return response, nil
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ParseStatusResponse parses an HTTP response from a StatusWithResponse call
-func ParseStatusResponse(rsp *http.Response) (*StatusResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+type ListSchedules500JSONResponse Error
 
-	response := &StatusResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+func (response ListSchedules500JSONResponse) VisitListSchedulesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest Status
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON401 = &dest
+type AddScheduleRequestObject struct {
+	Body *AddScheduleJSONRequestBody
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON403 = &dest
+type AddScheduleResponseObject interface {
+	VisitAddScheduleResponse(w http.ResponseWriter) error
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+type AddSchedule200JSONResponse Schedule
 
-	}
+func (response AddSchedule200JSONResponse) VisitAddScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	return response, nil
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ParseTemperatureResponse parses an HTTP response from a TemperatureWithResponse call
-func ParseTemperatureResponse(rsp *http.Response) (*TemperatureResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+type AddSchedule400JSONResponse struct{ ErrorJSONResponse }
 
-	response := &TemperatureResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+func (response AddSchedule400JSONResponse) VisitAddScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			// Temperature Temperature [C]
-			Temperature float32 `json:"temperature"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON401 = &dest
+type AddSchedule401JSONResponse Error
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON403 = &dest
+func (response AddSchedule401JSONResponse) VisitAddScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	}
+type AddSchedule403JSONResponse Error
 
-	return response, nil
+func (response AddSchedule403JSONResponse) VisitAddScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ParseWhoAmIResponse parses an HTTP response from a WhoAmIWithResponse call
-func ParseWhoAmIResponse(rsp *http.Response) (*WhoAmIResponse, error) {
-	bodyBytes, err := io.ReadAll(rsp.Body)
-	defer func() { _ = rsp.Body.Close() }()
-	if err != nil {
-		return nil, err
-	}
+type AddSchedule404JSONResponse Error
 
-	response := &WhoAmIResponse{
-		Body:         bodyBytes,
-		HTTPResponse: rsp,
-	}
+func (response AddSchedule404JSONResponse) VisitAddScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
 
-	switch {
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest struct {
-			// Username The username of the current user
-			Username string `json:"username"`
-		}
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON200 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON401 = &dest
+type AddSchedule500JSONResponse Error
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON403 = &dest
+func (response AddSchedule500JSONResponse) VisitAddScheduleResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
-		var dest Error
-		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
-			return nil, err
-		}
-		response.JSON500 = &dest
+	return json.NewEncoder(w).Encode(response)
+}
 
-	}
+type ListScheduleRunsRequestObject struct {
+}
 
-	return response, nil
+type ListScheduleRunsResponseObject interface {
+	VisitListScheduleRunsResponse(w http.ResponseWriter) error
 }
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Clear peak RMS current
-	// (POST /clear)
-	ClearMaximumCurrents(w http.ResponseWriter, r *http.Request)
-	// Switch lock state of outlet
-	// (POST /outlet/{id}/lock)
-	LockOutlet(w http.ResponseWriter, r *http.Request, id Id)
-	// Reboot the outlet
-	// (POST /outlet/{id}/reboot)
-	RebootOutlet(w http.ResponseWriter, r *http.Request, id Id)
-	// Switch state of outlet
-	// (POST /outlet/{id}/state)
-	SwitchOutlet(w http.ResponseWriter, r *http.Request, id Id)
-	// Get status of PDU
-	// (GET /status)
-	Status(w http.ResponseWriter, r *http.Request, params StatusParams)
-	// Get temperature of PDU
-	// (GET /temperature)
-	Temperature(w http.ResponseWriter, r *http.Request)
-	// Get name of current user
-	// (GET /whoami)
-	WhoAmI(w http.ResponseWriter, r *http.Request)
+type ListScheduleRuns200JSONResponse []ScheduleRun
+
+func (response ListScheduleRuns200JSONResponse) VisitListScheduleRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+type ListScheduleRuns401JSONResponse struct{ ErrorJSONResponse }
+
+func (response ListScheduleRuns401JSONResponse) VisitListScheduleRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+type ListScheduleRuns403JSONResponse Error
 
-// ClearMaximumCurrents operation middleware
-func (siw *ServerInterfaceWrapper) ClearMaximumCurrents(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+func (response ListScheduleRuns403JSONResponse) VisitListScheduleRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ClearMaximumCurrents(w, r)
-	}))
+	return json.NewEncoder(w).Encode(response)
+}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+type ListScheduleRuns500JSONResponse Error
+
+func (response ListScheduleRuns500JSONResponse) VisitListScheduleRunsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+type ListSequencesRequestObject struct {
 }
 
-// LockOutlet operation middleware
-func (siw *ServerInterfaceWrapper) LockOutlet(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+type ListSequencesResponseObject interface {
+	VisitListSequencesResponse(w http.ResponseWriter) error
+}
 
-	var err error
+type ListSequences200JSONResponse []Sequence
 
-	// ------------- Path parameter "id" -------------
-	var id Id
+func (response ListSequences200JSONResponse) VisitListSequencesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.LockOutlet(w, r, id)
-	}))
+type ListSequences401JSONResponse struct{ ErrorJSONResponse }
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+func (response ListSequences401JSONResponse) VisitListSequencesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	return json.NewEncoder(w).Encode(response)
 }
 
-// RebootOutlet operation middleware
-func (siw *ServerInterfaceWrapper) RebootOutlet(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+type ListSequences403JSONResponse Error
 
-	var err error
+func (response ListSequences403JSONResponse) VisitListSequencesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
 
-	// ------------- Path parameter "id" -------------
-	var id Id
+	return json.NewEncoder(w).Encode(response)
+}
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+type ListSequences500JSONResponse Error
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.RebootOutlet(w, r, id)
-	}))
+func (response ListSequences500JSONResponse) VisitListSequencesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+type GetSequenceRequestObject struct {
+	Seq Seq `json:"seq"`
 }
 
-// SwitchOutlet operation middleware
-func (siw *ServerInterfaceWrapper) SwitchOutlet(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+type GetSequenceResponseObject interface {
+	VisitGetSequenceResponse(w http.ResponseWriter) error
+}
 
-	var err error
+type GetSequence200JSONResponse Sequence
 
-	// ------------- Path parameter "id" -------------
-	var id Id
+func (response GetSequence200JSONResponse) VisitGetSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", r.PathValue("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
-		return
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.SwitchOutlet(w, r, id)
-	}))
+type GetSequence401JSONResponse struct{ ErrorJSONResponse }
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+func (response GetSequence401JSONResponse) VisitGetSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	return json.NewEncoder(w).Encode(response)
 }
 
-// Status operation middleware
-func (siw *ServerInterfaceWrapper) Status(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
-	var err error
-
-	// Parameter object where we will unmarshal all parameters from the context
-	var params StatusParams
+type GetSequence403JSONResponse Error
 
-	// ------------- Optional query parameter "detailed" -------------
+func (response GetSequence403JSONResponse) VisitGetSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
 
-	err = runtime.BindQueryParameter("form", true, false, "detailed", r.URL.Query(), &params.Detailed)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "detailed", Err: err})
-		return
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Status(w, r, params)
-	}))
+type GetSequence404JSONResponse Error
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+func (response GetSequence404JSONResponse) VisitGetSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	return json.NewEncoder(w).Encode(response)
 }
 
-// Temperature operation middleware
-func (siw *ServerInterfaceWrapper) Temperature(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+type GetSequence500JSONResponse Error
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.Temperature(w, r)
-	}))
+func (response GetSequence500JSONResponse) VisitGetSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+type RunSequenceRequestObject struct {
+	Seq  Seq `json:"seq"`
+	Body *RunSequenceJSONRequestBody
 }
 
-// WhoAmI operation middleware
-func (siw *ServerInterfaceWrapper) WhoAmI(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+type RunSequenceResponseObject interface {
+	VisitRunSequenceResponse(w http.ResponseWriter) error
+}
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.WhoAmI(w, r)
-	}))
+type RunSequence200JSONResponse SequenceRun
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
-	}
+func (response RunSequence200JSONResponse) VisitRunSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
+	return json.NewEncoder(w).Encode(response)
 }
 
-type UnescapedCookieParamError struct {
-	ParamName string
-	Err       error
-}
+type RunSequence400JSONResponse struct{ ErrorJSONResponse }
 
-func (e *UnescapedCookieParamError) Error() string {
-	return fmt.Sprintf("error unescaping cookie parameter '%s'", e.ParamName)
-}
+func (response RunSequence400JSONResponse) VisitRunSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
 
-func (e *UnescapedCookieParamError) Unwrap() error {
-	return e.Err
+	return json.NewEncoder(w).Encode(response)
 }
 
-type UnmarshalingParamError struct {
-	ParamName string
-	Err       error
-}
+type RunSequence401JSONResponse Error
 
-func (e *UnmarshalingParamError) Error() string {
-	return fmt.Sprintf("Error unmarshaling parameter %s as JSON: %s", e.ParamName, e.Err.Error())
-}
+func (response RunSequence401JSONResponse) VisitRunSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
 
-func (e *UnmarshalingParamError) Unwrap() error {
-	return e.Err
+	return json.NewEncoder(w).Encode(response)
 }
 
-type RequiredParamError struct {
-	ParamName string
-}
+type RunSequence403JSONResponse Error
 
-func (e *RequiredParamError) Error() string {
-	return fmt.Sprintf("Query argument %s is required, but not found", e.ParamName)
-}
+func (response RunSequence403JSONResponse) VisitRunSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
 
-type RequiredHeaderError struct {
-	ParamName string
-	Err       error
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *RequiredHeaderError) Error() string {
-	return fmt.Sprintf("Header parameter %s is required, but not found", e.ParamName)
-}
+type RunSequence404JSONResponse Error
 
-func (e *RequiredHeaderError) Unwrap() error {
-	return e.Err
-}
+func (response RunSequence404JSONResponse) VisitRunSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
 
-type InvalidParamFormatError struct {
-	ParamName string
-	Err       error
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *InvalidParamFormatError) Error() string {
-	return fmt.Sprintf("Invalid format for parameter %s: %s", e.ParamName, e.Err.Error())
-}
+type RunSequence409JSONResponse Error
 
-func (e *InvalidParamFormatError) Unwrap() error {
-	return e.Err
-}
+func (response RunSequence409JSONResponse) VisitRunSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
 
-type TooManyValuesForParamError struct {
-	ParamName string
-	Count     int
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (e *TooManyValuesForParamError) Error() string {
-	return fmt.Sprintf("Expected one value for %s, got %d", e.ParamName, e.Count)
-}
+type RunSequence500JSONResponse Error
 
-// Handler creates http.Handler with routing matching OpenAPI spec.
-func Handler(si ServerInterface) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{})
-}
+func (response RunSequence500JSONResponse) VisitRunSequenceResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-type StdHTTPServerOptions struct {
-	BaseURL          string
-	BaseRouter       *http.ServeMux
-	Middlewares      []MiddlewareFunc
-	ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+	return json.NewEncoder(w).Encode(response)
 }
 
-// HandlerFromMux creates http.Handler with routing matching OpenAPI spec based on the provided mux.
-func HandlerFromMux(si ServerInterface, m *http.ServeMux) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseRouter: m,
-	})
+type StatusRequestObject struct {
+	Params StatusParams
 }
 
-func HandlerFromMuxWithBaseURL(si ServerInterface, m *http.ServeMux, baseURL string) http.Handler {
-	return HandlerWithOptions(si, StdHTTPServerOptions{
-		BaseURL:    baseURL,
-		BaseRouter: m,
-	})
+type StatusResponseObject interface {
+	VisitStatusResponse(w http.ResponseWriter) error
 }
 
-// HandlerWithOptions creates http.Handler with additional options
-func HandlerWithOptions(si ServerInterface, options StdHTTPServerOptions) http.Handler {
-	m := options.BaseRouter
+type Status200JSONResponse Status
 
-	if m == nil {
-		m = http.NewServeMux()
-	}
-	if options.ErrorHandlerFunc == nil {
-		options.ErrorHandlerFunc = func(w http.ResponseWriter, r *http.Request, err error) {
-			http.Error(w, err.Error(), http.StatusBadRequest)
-		}
-	}
+func (response Status200JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
 
-	wrapper := ServerInterfaceWrapper{
-		Handler:            si,
-		HandlerMiddlewares: options.Middlewares,
-		ErrorHandlerFunc:   options.ErrorHandlerFunc,
-	}
+	return json.NewEncoder(w).Encode(response)
+}
 
-	m.HandleFunc("POST "+options.BaseURL+"/clear", wrapper.ClearMaximumCurrents)
-	m.HandleFunc("POST "+options.BaseURL+"/outlet/{id}/lock", wrapper.LockOutlet)
-	m.HandleFunc("POST "+options.BaseURL+"/outlet/{id}/reboot", wrapper.RebootOutlet)
-	m.HandleFunc("POST "+options.BaseURL+"/outlet/{id}/state", wrapper.SwitchOutlet)
-	m.HandleFunc("GET "+options.BaseURL+"/status", wrapper.Status)
-	m.HandleFunc("GET "+options.BaseURL+"/temperature", wrapper.Temperature)
-	m.HandleFunc("GET "+options.BaseURL+"/whoami", wrapper.WhoAmI)
+type Status401JSONResponse struct{ ErrorJSONResponse }
 
-	return m
+func (response Status401JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ErrorJSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
+type Status403JSONResponse Error
+
+func (response Status403JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type SuccessResponse struct {
-}
+type Status500JSONResponse Error
 
-type ClearMaximumCurrentsRequestObject struct {
-}
+func (response Status500JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
 
-type ClearMaximumCurrentsResponseObject interface {
-	VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ClearMaximumCurrents200Response = SuccessResponse
+type TemperatureRequestObject struct {
+}
 
-func (response ClearMaximumCurrents200Response) VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error {
-	w.WriteHeader(200)
-	return nil
+type TemperatureResponseObject interface {
+	VisitTemperatureResponse(w http.ResponseWriter) error
 }
 
-type ClearMaximumCurrents400JSONResponse struct{ ErrorJSONResponse }
+type Temperature200JSONResponse struct {
+	// Temperature Temperature [C]
+	Temperature float32 `json:"temperature"`
+}
 
-func (response ClearMaximumCurrents400JSONResponse) VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error {
+func (response Temperature200JSONResponse) VisitTemperatureResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ClearMaximumCurrents401JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type Temperature401JSONResponse struct{ ErrorJSONResponse }
 
-func (response ClearMaximumCurrents401JSONResponse) VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error {
+func (response Temperature401JSONResponse) VisitTemperatureResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ClearMaximumCurrents403JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type Temperature403JSONResponse Error
 
-func (response ClearMaximumCurrents403JSONResponse) VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error {
+func (response Temperature403JSONResponse) VisitTemperatureResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ClearMaximumCurrents500JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type Temperature500JSONResponse Error
 
-func (response ClearMaximumCurrents500JSONResponse) VisitClearMaximumCurrentsResponse(w http.ResponseWriter) error {
+func (response Temperature500JSONResponse) VisitTemperatureResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LockOutletRequestObject struct {
-	Id   Id `json:"id"`
-	Body *LockOutletJSONRequestBody
+type DeleteUserRequestObject struct {
+	Name Name `json:"name"`
 }
 
-type LockOutletResponseObject interface {
-	VisitLockOutletResponse(w http.ResponseWriter) error
+type DeleteUserResponseObject interface {
+	VisitDeleteUserResponse(w http.ResponseWriter) error
 }
 
-type LockOutlet200Response = SuccessResponse
+type DeleteUser200Response = SuccessResponse
 
-func (response LockOutlet200Response) VisitLockOutletResponse(w http.ResponseWriter) error {
+func (response DeleteUser200Response) VisitDeleteUserResponse(w http.ResponseWriter) error {
 	w.WriteHeader(200)
 	return nil
 }
 
-type LockOutlet400JSONResponse struct{ ErrorJSONResponse }
+type DeleteUser400JSONResponse struct{ ErrorJSONResponse }
 
-func (response LockOutlet400JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+func (response DeleteUser400JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LockOutlet401JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type DeleteUser401JSONResponse Error
 
-func (response LockOutlet401JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+func (response DeleteUser401JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LockOutlet403JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type DeleteUser403JSONResponse Error
 
-func (response LockOutlet403JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+func (response DeleteUser403JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LockOutlet404JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type DeleteUser404JSONResponse Error
 
-func (response LockOutlet404JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+func (response DeleteUser404JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type LockOutlet500JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type DeleteUser500JSONResponse Error
 
-func (response LockOutlet500JSONResponse) VisitLockOutletResponse(w http.ResponseWriter) error {
+func (response DeleteUser500JSONResponse) VisitDeleteUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RebootOutletRequestObject struct {
-	Id Id `json:"id"`
+type SetUserOutletsRequestObject struct {
+	Name Name `json:"name"`
+	Body *SetUserOutletsJSONRequestBody
 }
 
-type RebootOutletResponseObject interface {
-	VisitRebootOutletResponse(w http.ResponseWriter) error
+type SetUserOutletsResponseObject interface {
+	VisitSetUserOutletsResponse(w http.ResponseWriter) error
 }
 
-type RebootOutlet200Response = SuccessResponse
+type SetUserOutlets200Response = SuccessResponse
 
-func (response RebootOutlet200Response) VisitRebootOutletResponse(w http.ResponseWriter) error {
+func (response SetUserOutlets200Response) VisitSetUserOutletsResponse(w http.ResponseWriter) error {
 	w.WriteHeader(200)
 	return nil
 }
 
-type RebootOutlet400JSONResponse struct{ ErrorJSONResponse }
+type SetUserOutlets400JSONResponse struct{ ErrorJSONResponse }
 
-func (response RebootOutlet400JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+func (response SetUserOutlets400JSONResponse) VisitSetUserOutletsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RebootOutlet401JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type SetUserOutlets401JSONResponse Error
 
-func (response RebootOutlet401JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+func (response SetUserOutlets401JSONResponse) VisitSetUserOutletsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RebootOutlet403JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type SetUserOutlets403JSONResponse Error
 
-func (response RebootOutlet403JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+func (response SetUserOutlets403JSONResponse) VisitSetUserOutletsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RebootOutlet404JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type SetUserOutlets404JSONResponse Error
 
-func (response RebootOutlet404JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+func (response SetUserOutlets404JSONResponse) VisitSetUserOutletsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type RebootOutlet500JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type SetUserOutlets500JSONResponse Error
 
-func (response RebootOutlet500JSONResponse) VisitRebootOutletResponse(w http.ResponseWriter) error {
+func (response SetUserOutlets500JSONResponse) VisitSetUserOutletsResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type SwitchOutletRequestObject struct {
-	Id   Id `json:"id"`
-	Body *SwitchOutletJSONRequestBody
+type ChangePasswordRequestObject struct {
+	Name Name `json:"name"`
+	Body *ChangePasswordJSONRequestBody
 }
 
-type SwitchOutletResponseObject interface {
-	VisitSwitchOutletResponse(w http.ResponseWriter) error
+type ChangePasswordResponseObject interface {
+	VisitChangePasswordResponse(w http.ResponseWriter) error
 }
 
-type SwitchOutlet200Response = SuccessResponse
+type ChangePassword200Response = SuccessResponse
 
-func (response SwitchOutlet200Response) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+func (response ChangePassword200Response) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.WriteHeader(200)
 	return nil
 }
 
-type SwitchOutlet400JSONResponse struct{ ErrorJSONResponse }
+type ChangePassword400JSONResponse struct{ ErrorJSONResponse }
 
-func (response SwitchOutlet400JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+func (response ChangePassword400JSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type SwitchOutlet401JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type ChangePassword401JSONResponse Error
 
-func (response SwitchOutlet401JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+func (response ChangePassword401JSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type SwitchOutlet403JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type ChangePassword403JSONResponse Error
 
-func (response SwitchOutlet403JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+func (response ChangePassword403JSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type SwitchOutlet404JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type ChangePassword404JSONResponse Error
 
-func (response SwitchOutlet404JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+func (response ChangePassword404JSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type SwitchOutlet500JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type ChangePassword500JSONResponse Error
 
-func (response SwitchOutlet500JSONResponse) VisitSwitchOutletResponse(w http.ResponseWriter) error {
+func (response ChangePassword500JSONResponse) VisitChangePasswordResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type StatusRequestObject struct {
-	Params StatusParams
+type ListUsersRequestObject struct {
 }
 
-type StatusResponseObject interface {
-	VisitStatusResponse(w http.ResponseWriter) error
+type ListUsersResponseObject interface {
+	VisitListUsersResponse(w http.ResponseWriter) error
 }
 
-type Status200JSONResponse Status
+type ListUsers200JSONResponse []User
 
-func (response Status200JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+func (response ListUsers200JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Status401JSONResponse struct{ ErrorJSONResponse }
+type ListUsers401JSONResponse struct{ ErrorJSONResponse }
 
-func (response Status401JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+func (response ListUsers401JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Status403JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type ListUsers403JSONResponse Error
 
-func (response Status403JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+func (response ListUsers403JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Status500JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type ListUsers500JSONResponse Error
 
-func (response Status500JSONResponse) VisitStatusResponse(w http.ResponseWriter) error {
+func (response ListUsers500JSONResponse) VisitListUsersResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type TemperatureRequestObject struct {
+type AddUserRequestObject struct {
+	Body *AddUserJSONRequestBody
 }
 
-type TemperatureResponseObject interface {
-	VisitTemperatureResponse(w http.ResponseWriter) error
+type AddUserResponseObject interface {
+	VisitAddUserResponse(w http.ResponseWriter) error
 }
 
-type Temperature200JSONResponse struct {
-	// Temperature Temperature [C]
-	Temperature float32 `json:"temperature"`
+type AddUser200Response = SuccessResponse
+
+func (response AddUser200Response) VisitAddUserResponse(w http.ResponseWriter) error {
+	w.WriteHeader(200)
+	return nil
 }
 
-func (response Temperature200JSONResponse) VisitTemperatureResponse(w http.ResponseWriter) error {
+type AddUser400JSONResponse struct{ ErrorJSONResponse }
+
+func (response AddUser400JSONResponse) VisitAddUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Temperature401JSONResponse struct{ ErrorJSONResponse }
+type AddUser401JSONResponse Error
 
-func (response Temperature401JSONResponse) VisitTemperatureResponse(w http.ResponseWriter) error {
+func (response AddUser401JSONResponse) VisitAddUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Temperature403JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type AddUser403JSONResponse Error
 
-func (response Temperature403JSONResponse) VisitTemperatureResponse(w http.ResponseWriter) error {
+func (response AddUser403JSONResponse) VisitAddUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type Temperature500JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
+type AddUser404JSONResponse Error
+
+func (response AddUser404JSONResponse) VisitAddUserResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-func (response Temperature500JSONResponse) VisitTemperatureResponse(w http.ResponseWriter) error {
+type AddUser500JSONResponse Error
+
+func (response AddUser500JSONResponse) VisitAddUserResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(500)
 
@@ -2025,10 +7070,7 @@ func (response WhoAmI401JSONResponse) VisitWhoAmIResponse(w http.ResponseWriter)
 	return json.NewEncoder(w).Encode(response)
 }
 
-type WhoAmI403JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type WhoAmI403JSONResponse Error
 
 func (response WhoAmI403JSONResponse) VisitWhoAmIResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -2037,10 +7079,7 @@ func (response WhoAmI403JSONResponse) VisitWhoAmIResponse(w http.ResponseWriter)
 	return json.NewEncoder(w).Encode(response)
 }
 
-type WhoAmI500JSONResponse struct {
-	// Error An error message
-	Error string `json:"error"`
-}
+type WhoAmI500JSONResponse Error
 
 func (response WhoAmI500JSONResponse) VisitWhoAmIResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
@@ -2051,9 +7090,27 @@ func (response WhoAmI500JSONResponse) VisitWhoAmIResponse(w http.ResponseWriter)
 
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// List active alerts
+	// (GET /alerts)
+	ListAlerts(ctx context.Context, request ListAlertsRequestObject) (ListAlertsResponseObject, error)
+	// Query the audit log
+	// (GET /audit)
+	Audit(ctx context.Context, request AuditRequestObject) (AuditResponseObject, error)
 	// Clear peak RMS current
 	// (POST /clear)
 	ClearMaximumCurrents(ctx context.Context, request ClearMaximumCurrentsRequestObject) (ClearMaximumCurrentsResponseObject, error)
+	// Get diagnostics of the PDU connection and poller
+	// (GET /diagnostics)
+	Diagnostics(ctx context.Context, request DiagnosticsRequestObject) (DiagnosticsResponseObject, error)
+	// Stream status updates and change events
+	// (GET /events)
+	Events(ctx context.Context, request EventsRequestObject) (EventsResponseObject, error)
+	// Get past status samples
+	// (GET /history)
+	History(ctx context.Context, request HistoryRequestObject) (HistoryResponseObject, error)
+	// Get status of outlets
+	// (GET /outlet/{id})
+	StatusOutlet(ctx context.Context, request StatusOutletRequestObject) (StatusOutletResponseObject, error)
 	// Switch lock state of outlet
 	// (POST /outlet/{id}/lock)
 	LockOutlet(ctx context.Context, request LockOutletRequestObject) (LockOutletResponseObject, error)
@@ -2063,12 +7120,48 @@ type StrictServerInterface interface {
 	// Switch state of outlet
 	// (POST /outlet/{id}/state)
 	SwitchOutlet(ctx context.Context, request SwitchOutletRequestObject) (SwitchOutletResponseObject, error)
+	// Remove a scheduled outlet action
+	// (DELETE /schedule/{sid})
+	DeleteSchedule(ctx context.Context, request DeleteScheduleRequestObject) (DeleteScheduleResponseObject, error)
+	// List scheduled outlet actions
+	// (GET /schedules)
+	ListSchedules(ctx context.Context, request ListSchedulesRequestObject) (ListSchedulesResponseObject, error)
+	// Add a scheduled outlet action
+	// (POST /schedules)
+	AddSchedule(ctx context.Context, request AddScheduleRequestObject) (AddScheduleResponseObject, error)
+	// List recent runs of scheduled outlet actions
+	// (GET /schedules/runs)
+	ListScheduleRuns(ctx context.Context, request ListScheduleRunsRequestObject) (ListScheduleRunsResponseObject, error)
+	// List power sequences
+	// (GET /sequences)
+	ListSequences(ctx context.Context, request ListSequencesRequestObject) (ListSequencesResponseObject, error)
+	// Get a power sequence and the progress of its last run
+	// (GET /sequences/{seq})
+	GetSequence(ctx context.Context, request GetSequenceRequestObject) (GetSequenceResponseObject, error)
+	// Run a power sequence
+	// (POST /sequences/{seq}/run)
+	RunSequence(ctx context.Context, request RunSequenceRequestObject) (RunSequenceResponseObject, error)
 	// Get status of PDU
 	// (GET /status)
 	Status(ctx context.Context, request StatusRequestObject) (StatusResponseObject, error)
 	// Get temperature of PDU
 	// (GET /temperature)
 	Temperature(ctx context.Context, request TemperatureRequestObject) (TemperatureResponseObject, error)
+	// Remove a user account from the PDU
+	// (DELETE /user/{name})
+	DeleteUser(ctx context.Context, request DeleteUserRequestObject) (DeleteUserResponseObject, error)
+	// Set the outlets which a user is allowed to control
+	// (POST /user/{name}/outlets)
+	SetUserOutlets(ctx context.Context, request SetUserOutletsRequestObject) (SetUserOutletsResponseObject, error)
+	// Change the password of a user
+	// (POST /user/{name}/password)
+	ChangePassword(ctx context.Context, request ChangePasswordRequestObject) (ChangePasswordResponseObject, error)
+	// List user accounts of the PDU
+	// (GET /users)
+	ListUsers(ctx context.Context, request ListUsersRequestObject) (ListUsersResponseObject, error)
+	// Add a user account to the PDU
+	// (POST /users)
+	AddUser(ctx context.Context, request AddUserRequestObject) (AddUserResponseObject, error)
 	// Get name of current user
 	// (GET /whoami)
 	WhoAmI(ctx context.Context, request WhoAmIRequestObject) (WhoAmIResponseObject, error)
@@ -2097,29 +7190,179 @@ func NewStrictHandlerWithOptions(ssi StrictServerInterface, middlewares []Strict
 	return &strictHandler{ssi: ssi, middlewares: middlewares, options: options}
 }
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
-	options     StrictHTTPServerOptions
-}
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+	options     StrictHTTPServerOptions
+}
+
+// ListAlerts operation middleware
+func (sh *strictHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	var request ListAlertsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListAlerts(ctx, request.(ListAlertsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListAlerts")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListAlertsResponseObject); ok {
+		if err := validResponse.VisitListAlertsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Audit operation middleware
+func (sh *strictHandler) Audit(w http.ResponseWriter, r *http.Request, params AuditParams) {
+	var request AuditRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Audit(ctx, request.(AuditRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Audit")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AuditResponseObject); ok {
+		if err := validResponse.VisitAuditResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ClearMaximumCurrents operation middleware
+func (sh *strictHandler) ClearMaximumCurrents(w http.ResponseWriter, r *http.Request) {
+	var request ClearMaximumCurrentsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ClearMaximumCurrents(ctx, request.(ClearMaximumCurrentsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ClearMaximumCurrents")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ClearMaximumCurrentsResponseObject); ok {
+		if err := validResponse.VisitClearMaximumCurrentsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Diagnostics operation middleware
+func (sh *strictHandler) Diagnostics(w http.ResponseWriter, r *http.Request) {
+	var request DiagnosticsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Diagnostics(ctx, request.(DiagnosticsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Diagnostics")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DiagnosticsResponseObject); ok {
+		if err := validResponse.VisitDiagnosticsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// Events operation middleware
+func (sh *strictHandler) Events(w http.ResponseWriter, r *http.Request) {
+	var request EventsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.Events(ctx, request.(EventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Events")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(EventsResponseObject); ok {
+		if err := validResponse.VisitEventsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// History operation middleware
+func (sh *strictHandler) History(w http.ResponseWriter, r *http.Request, params HistoryParams) {
+	var request HistoryRequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.History(ctx, request.(HistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "History")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(HistoryResponseObject); ok {
+		if err := validResponse.VisitHistoryResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// StatusOutlet operation middleware
+func (sh *strictHandler) StatusOutlet(w http.ResponseWriter, r *http.Request, id Id) {
+	var request StatusOutletRequestObject
 
-// ClearMaximumCurrents operation middleware
-func (sh *strictHandler) ClearMaximumCurrents(w http.ResponseWriter, r *http.Request) {
-	var request ClearMaximumCurrentsRequestObject
+	request.Id = id
 
 	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
-		return sh.ssi.ClearMaximumCurrents(ctx, request.(ClearMaximumCurrentsRequestObject))
+		return sh.ssi.StatusOutlet(ctx, request.(StatusOutletRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ClearMaximumCurrents")
+		handler = middleware(handler, "StatusOutlet")
 	}
 
 	response, err := handler(r.Context(), w, r, request)
 
 	if err != nil {
 		sh.options.ResponseErrorHandlerFunc(w, r, err)
-	} else if validResponse, ok := response.(ClearMaximumCurrentsResponseObject); ok {
-		if err := validResponse.VisitClearMaximumCurrentsResponse(w); err != nil {
+	} else if validResponse, ok := response.(StatusOutletResponseObject); ok {
+		if err := validResponse.VisitStatusOutletResponse(w); err != nil {
 			sh.options.ResponseErrorHandlerFunc(w, r, err)
 		}
 	} else if response != nil {
@@ -2219,6 +7462,194 @@ func (sh *strictHandler) SwitchOutlet(w http.ResponseWriter, r *http.Request, id
 	}
 }
 
+// DeleteSchedule operation middleware
+func (sh *strictHandler) DeleteSchedule(w http.ResponseWriter, r *http.Request, sid string) {
+	var request DeleteScheduleRequestObject
+
+	request.Sid = sid
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteSchedule(ctx, request.(DeleteScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteSchedule")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteScheduleResponseObject); ok {
+		if err := validResponse.VisitDeleteScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListSchedules operation middleware
+func (sh *strictHandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	var request ListSchedulesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListSchedules(ctx, request.(ListSchedulesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListSchedules")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListSchedulesResponseObject); ok {
+		if err := validResponse.VisitListSchedulesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddSchedule operation middleware
+func (sh *strictHandler) AddSchedule(w http.ResponseWriter, r *http.Request) {
+	var request AddScheduleRequestObject
+
+	var body AddScheduleJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddSchedule(ctx, request.(AddScheduleRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddSchedule")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddScheduleResponseObject); ok {
+		if err := validResponse.VisitAddScheduleResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListScheduleRuns operation middleware
+func (sh *strictHandler) ListScheduleRuns(w http.ResponseWriter, r *http.Request) {
+	var request ListScheduleRunsRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListScheduleRuns(ctx, request.(ListScheduleRunsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListScheduleRuns")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListScheduleRunsResponseObject); ok {
+		if err := validResponse.VisitListScheduleRunsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListSequences operation middleware
+func (sh *strictHandler) ListSequences(w http.ResponseWriter, r *http.Request) {
+	var request ListSequencesRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListSequences(ctx, request.(ListSequencesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListSequences")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListSequencesResponseObject); ok {
+		if err := validResponse.VisitListSequencesResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetSequence operation middleware
+func (sh *strictHandler) GetSequence(w http.ResponseWriter, r *http.Request, seq Seq) {
+	var request GetSequenceRequestObject
+
+	request.Seq = seq
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSequence(ctx, request.(GetSequenceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSequence")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetSequenceResponseObject); ok {
+		if err := validResponse.VisitGetSequenceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// RunSequence operation middleware
+func (sh *strictHandler) RunSequence(w http.ResponseWriter, r *http.Request, seq Seq) {
+	var request RunSequenceRequestObject
+
+	request.Seq = seq
+
+	var body RunSequenceJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.RunSequence(ctx, request.(RunSequenceRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RunSequence")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(RunSequenceResponseObject); ok {
+		if err := validResponse.VisitRunSequenceResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // Status operation middleware
 func (sh *strictHandler) Status(w http.ResponseWriter, r *http.Request, params StatusParams) {
 	var request StatusRequestObject
@@ -2269,6 +7700,153 @@ func (sh *strictHandler) Temperature(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// DeleteUser operation middleware
+func (sh *strictHandler) DeleteUser(w http.ResponseWriter, r *http.Request, name Name) {
+	var request DeleteUserRequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUser(ctx, request.(DeleteUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteUserResponseObject); ok {
+		if err := validResponse.VisitDeleteUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// SetUserOutlets operation middleware
+func (sh *strictHandler) SetUserOutlets(w http.ResponseWriter, r *http.Request, name Name) {
+	var request SetUserOutletsRequestObject
+
+	request.Name = name
+
+	var body SetUserOutletsJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.SetUserOutlets(ctx, request.(SetUserOutletsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetUserOutlets")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(SetUserOutletsResponseObject); ok {
+		if err := validResponse.VisitSetUserOutletsResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ChangePassword operation middleware
+func (sh *strictHandler) ChangePassword(w http.ResponseWriter, r *http.Request, name Name) {
+	var request ChangePasswordRequestObject
+
+	request.Name = name
+
+	var body ChangePasswordJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ChangePassword(ctx, request.(ChangePasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ChangePassword")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ChangePasswordResponseObject); ok {
+		if err := validResponse.VisitChangePasswordResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListUsers operation middleware
+func (sh *strictHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	var request ListUsersRequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListUsers(ctx, request.(ListUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListUsers")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListUsersResponseObject); ok {
+		if err := validResponse.VisitListUsersResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// AddUser operation middleware
+func (sh *strictHandler) AddUser(w http.ResponseWriter, r *http.Request) {
+	var request AddUserRequestObject
+
+	var body AddUserJSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.AddUser(ctx, request.(AddUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "AddUser")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(AddUserResponseObject); ok {
+		if err := validResponse.VisitAddUserResponse(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // WhoAmI operation middleware
 func (sh *strictHandler) WhoAmI(w http.ResponseWriter, r *http.Request) {
 	var request WhoAmIRequestObject
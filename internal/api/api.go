@@ -7,13 +7,45 @@ package api
 
 func OutletIDFromRequest(r any) string {
 	switch r := r.(type) {
-	case *LockOutletRequestObject:
+	case LockOutletRequestObject:
 		return r.Id
-	case *SwitchOutletRequestObject:
+	case SwitchOutletRequestObject:
 		return r.Id
-	case *RebootOutletRequestObject:
+	case RebootOutletRequestObject:
 		return r.Id
+	case StatusOutletRequestObject:
+		return r.Id
+	case AddScheduleRequestObject:
+		if r.Body != nil {
+			return r.Body.Outlets
+		}
 	}
 
 	return ""
 }
+
+// StateFromRequest returns the requested state of switch-outlet and lock-outlet requests.
+func StateFromRequest(r any) *bool {
+	switch r := r.(type) {
+	case LockOutletRequestObject:
+		return r.Body
+	case SwitchOutletRequestObject:
+		return r.Body
+	}
+
+	return nil
+}
+
+// OutletResultsFromResponse returns the results of the individual outlets of a successful outlet operation.
+func OutletResultsFromResponse(r any) []OutletResult {
+	switch r := r.(type) {
+	case LockOutlet200JSONResponse:
+		return r
+	case SwitchOutlet200JSONResponse:
+		return r
+	case RebootOutlet200JSONResponse:
+		return r
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func PrintAudit(f io.Writer, format string, entries []AuditEntry) {
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(entries)
+
+		return
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		"Time",
+		"Source",
+		"Client",
+		"Address",
+		"Operation",
+		"Outlets",
+		"State",
+		"Change",
+		"Result",
+		"Latency",
+		"Error",
+	})
+
+	for _, e := range entries {
+		state := ""
+		if e.State != nil {
+			if e.Operation == "lock-outlet" {
+				state = lockUnlock(*e.State)
+			} else {
+				state = onOff(*e.State)
+			}
+		}
+
+		t.AppendRow(table.Row{
+			e.Timestamp.Local().Format(time.DateTime),
+			e.Source,
+			auditClient(&e),
+			e.RemoteAddress,
+			e.Operation,
+			deref(e.Outlet),
+			state,
+			auditChanges(&e),
+			e.Result,
+			fmt.Sprintf("%.3fs", e.Latency),
+			deref(e.Error),
+		})
+	}
+
+	renderTable(t, f, format)
+}
+
+// auditClient returns the identity of the client with its authentication method.
+func auditClient(e *AuditEntry) string {
+	if e.Method == nil {
+		return deref(e.Client)
+	}
+
+	return fmt.Sprintf("%s (%s)", deref(e.Client), *e.Method)
+}
+
+// auditChanges describes the state of each outlet before and after the operation.
+func auditChanges(e *AuditEntry) string {
+	after := map[int]*OutletStatus{}
+	for i := range e.After {
+		after[e.After[i].ID] = &e.After[i]
+	}
+
+	str := func(o *OutletStatus) string {
+		if e.Operation == "lock-outlet" {
+			return lockUnlock(o.Locked)
+		}
+
+		return onOff(o.State)
+	}
+
+	changes := []string{}
+	for i := range e.Before {
+		b := &e.Before[i]
+
+		change := fmt.Sprintf("%s: %s", b.Name, str(b))
+		if a, ok := after[b.ID]; ok {
+			change += "→" + str(a)
+		}
+
+		changes = append(changes, change)
+	}
+
+	return strings.Join(changes, "\n")
+}
+
+func lockUnlock(locked bool) string {
+	if locked {
+		return "locked"
+	}
+
+	return "unlocked"
+}
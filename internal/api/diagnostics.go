@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+func (d *Diagnostics) Print(f io.Writer, format string) {
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(d)
+
+		return
+	}
+
+	c := d.Connection
+
+	fmt.Fprintf(f, "Connection: %s since %s (%d reconnects)\n", c.State, c.Since.Format(time.RFC3339), c.Reconnects)
+
+	if c.LastError != nil {
+		fmt.Fprintf(f, "Last Connection Error: %s\n", *c.LastError)
+	}
+
+	fmt.Fprintf(f, "Logged In: %t\n", d.LoggedIn)
+	fmt.Fprintf(f, "Commands: %d (average latency %s)\n", d.Commands, seconds(d.AverageLatency))
+	fmt.Fprintf(f, "Poll Interval: %s\n", seconds(d.PollInterval))
+
+	if d.LastPoll != nil {
+		fmt.Fprintf(f, "Last Poll: %s\n", d.LastPoll.Format(time.RFC3339))
+	}
+
+	if d.LastSuccessfulPoll != nil {
+		fmt.Fprintf(f, "Last Successful Poll: %s\n", d.LastSuccessfulPoll.Format(time.RFC3339))
+	}
+
+	fmt.Fprintf(f, "Consecutive Failures: %d\n", d.ConsecutiveFailures)
+
+	if d.LastError != nil {
+		fmt.Fprintf(f, "Last Poll Error: %s\n", *d.LastError)
+	}
+
+	if d.Staleness != nil {
+		fmt.Fprintf(f, "Staleness: %s\n", seconds(*d.Staleness))
+	}
+
+	fmt.Fprintf(f, "Alive: %t\n", d.Alive)
+	fmt.Fprintf(f, "Ready: %t\n", d.Ready)
+}
+
+func seconds(s float32) time.Duration {
+	return (time.Duration(s * float32(time.Second))).Round(time.Microsecond)
+}
@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+type namedMeasurements struct {
+	name string
+	m    Measurements
+}
+
+// HistorySeries is the time series of a single value of an outlet or group.
+type HistorySeries struct {
+	Name   string
+	Values []float32
+}
+
+// NewHistorySeries extracts the series of a value ("power", "current", "voltage" or "energy")
+// for each outlet or group from a list of samples.
+func NewHistorySeries(samples []Status, value string, groups bool) ([]HistorySeries, error) {
+	get, unit, err := historyValue(value)
+	if err != nil {
+		return nil, err
+	}
+
+	series := []HistorySeries{}
+	index := map[string]int{}
+
+	for i, s := range samples {
+		measurements := []namedMeasurements{}
+
+		if groups {
+			for _, g := range s.Groups {
+				measurements = append(measurements, namedMeasurements{g.Name, Measurements{
+					TrueRMSCurrent: g.TrueRMSCurrent,
+					TrueRMSVoltage: g.TrueRMSVoltage,
+					AveragePower:   g.AveragePower,
+					Energy:         g.Energy,
+				}})
+			}
+		} else {
+			for _, o := range s.Outlets {
+				measurements = append(measurements, namedMeasurements{o.Name, Measurements{
+					TrueRMSCurrent: o.TrueRMSCurrent,
+					TrueRMSVoltage: o.TrueRMSVoltage,
+					AveragePower:   o.AveragePower,
+					Energy:         o.Energy,
+				}})
+			}
+		}
+
+		for _, m := range measurements {
+			name := fmt.Sprintf("%s [%s]", m.name, unit)
+
+			j, ok := index[name]
+			if !ok {
+				j = len(series)
+				index[name] = j
+				series = append(series, HistorySeries{
+					Name:   name,
+					Values: make([]float32, len(samples)),
+				})
+			}
+
+			series[j].Values[i] = get(m.m)
+		}
+	}
+
+	return series, nil
+}
+
+func historyValue(value string) (func(Measurements) float32, string, error) {
+	switch value {
+	case "power":
+		return func(m Measurements) float32 { return m.AveragePower }, "W", nil
+	case "current":
+		return func(m Measurements) float32 { return m.TrueRMSCurrent }, "A", nil
+	case "voltage":
+		return func(m Measurements) float32 { return m.TrueRMSVoltage }, "V", nil
+	case "energy":
+		return func(m Measurements) float32 { return m.Energy }, "kWh", nil
+	}
+
+	return nil, "", fmt.Errorf("invalid value: %s", value)
+}
+
+// PrintHistory renders the series as table with a row per sample or as sparklines.
+func PrintHistory(f io.Writer, format string, samples []Status, series []HistorySeries) {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(samples)
+
+		return
+
+	case "sparkline":
+		printSparklines(f, series)
+
+		return
+	}
+
+	t := table.NewWriter()
+
+	header := table.Row{"Time"}
+	for _, s := range series {
+		header = append(header, s.Name)
+	}
+
+	t.AppendHeader(header)
+
+	for i, sample := range samples {
+		row := table.Row{sample.Timestamp.Local().Format("2006-01-02 15:04:05")}
+		for _, s := range series {
+			row = append(row, fmt.Sprintf("%.2f", s.Values[i]))
+		}
+
+		t.AppendRow(row)
+	}
+
+	renderTable(t, f, format)
+}
+
+func printSparklines(f io.Writer, series []HistorySeries) {
+	width := 0
+	for _, s := range series {
+		width = max(width, len(s.Name))
+	}
+
+	for _, s := range series {
+		if len(s.Values) == 0 {
+			continue
+		}
+
+		lo, hi := slices.Min(s.Values), slices.Max(s.Values)
+
+		var sb strings.Builder
+		for _, v := range s.Values {
+			i := 0
+			if hi > lo {
+				i = int((v - lo) / (hi - lo) * float32(len(sparks)-1))
+			}
+
+			sb.WriteRune(sparks[i])
+		}
+
+		fmt.Fprintf(f, "%-*s %s  min %.2f max %.2f\n", width, s.Name, sb.String(), lo, hi)
+	}
+}
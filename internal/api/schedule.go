@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func PrintSchedules(f io.Writer, format string, schedules []Schedule) {
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(schedules)
+
+		return
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		"ID",
+		"Name",
+		"When",
+		"Action",
+		"Outlets",
+		"Next Run",
+		"Source",
+	})
+
+	for _, s := range schedules {
+		when := ""
+		if s.Cron != nil {
+			when = *s.Cron
+		} else if s.At != nil {
+			when = s.At.Local().Format(time.DateTime)
+		}
+
+		nextRun := ""
+		if s.NextRun != nil {
+			nextRun = s.NextRun.Local().Format(time.DateTime)
+		}
+
+		source := string(s.Source)
+		if s.CreatedBy != nil {
+			source += " (" + *s.CreatedBy + ")"
+		}
+
+		t.AppendRow(table.Row{
+			s.ID,
+			deref(s.Name),
+			when,
+			s.Action,
+			s.Outlets,
+			nextRun,
+			source,
+		})
+	}
+
+	renderTable(t, f, format)
+}
+
+func PrintScheduleRuns(f io.Writer, format string, runs []ScheduleRun) {
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(runs)
+
+		return
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		"Time",
+		"Schedule",
+		"Action",
+		"Outlets",
+		"Result",
+	})
+
+	for _, r := range runs {
+		schedule := r.ScheduleID
+		if r.Name != nil {
+			schedule = *r.Name
+		}
+
+		result := "ok"
+		if r.Error != nil {
+			result = *r.Error
+		} else if r.Results != nil {
+			for _, or := range *r.Results {
+				if or.Error != nil {
+					result = "partially failed"
+					break
+				}
+			}
+		}
+
+		t.AppendRow(table.Row{
+			r.Timestamp.Local().Format(time.DateTime),
+			schedule,
+			r.Action,
+			r.Outlets,
+			result,
+		})
+	}
+
+	renderTable(t, f, format)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
@@ -0,0 +1,104 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+func PrintSequences(f io.Writer, format string, seqs []Sequence) {
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(seqs)
+
+		return
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		"Sequence",
+		"Steps",
+		"Last Run",
+	})
+
+	for _, s := range seqs {
+		steps := []string{}
+		for _, st := range s.Steps {
+			step := st.Outlets
+			if st.WaitCurrent != nil {
+				step += fmt.Sprintf(" (> %.2f A)", *st.WaitCurrent)
+			}
+
+			if st.Delay != nil {
+				step += " +" + *st.Delay
+			}
+
+			steps = append(steps, step)
+		}
+
+		lastRun := ""
+		if r := s.LastRun; r != nil {
+			lastRun = fmt.Sprintf("%s %s (%s)", r.Started.Local().Format(time.DateTime), onOff(r.State), r.Status)
+		}
+
+		t.AppendRow(table.Row{
+			s.Name,
+			strings.Join(steps, " → "),
+			lastRun,
+		})
+	}
+
+	renderTable(t, f, format)
+}
+
+func PrintSequenceRun(f io.Writer, format string, run *SequenceRun) {
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(run)
+
+		return
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		"Step",
+		"Outlets",
+		"Status",
+		"Duration",
+		"Error",
+	})
+
+	for i, s := range run.Steps {
+		duration := ""
+		if s.Started != nil && s.Finished != nil {
+			duration = s.Finished.Sub(*s.Started).Round(100 * time.Millisecond).String()
+		}
+
+		t.AppendRow(table.Row{
+			i + 1,
+			s.Outlets,
+			s.Status,
+			duration,
+			deref(s.Error),
+		})
+	}
+
+	renderTable(t, f, format)
+}
+
+func onOff(state bool) string {
+	if state {
+		return "on"
+	}
+
+	return "off"
+}
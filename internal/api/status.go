@@ -86,6 +86,14 @@ func (s *Status) Print(f io.Writer, format string) {
 	fmt.Fprintf(f, "Total Energy: %.0f kWh\n", s.TotalEnergy)
 	fmt.Fprintf(f, "Temperature: %.1f °C\n", s.Temperature)
 
+	if c := s.Connection; c != nil {
+		fmt.Fprintf(f, "Connection: %s since %s (%d reconnects)\n", c.State, c.Since.Format(time.RFC3339), c.Reconnects)
+
+		if c.State != Connected && c.LastError != nil {
+			fmt.Fprintf(f, "Last Error: %s\n", *c.LastError)
+		}
+	}
+
 	if len(s.Switches) > 0 {
 		fmt.Fprintln(f)
 		s.PrintSwitches(f, format)
@@ -106,6 +114,11 @@ func (s *Status) Print(f io.Writer, format string) {
 		s.PrintOutlets(f, format)
 	}
 
+	if len(s.Watchdogs) > 0 {
+		fmt.Fprintln(f)
+		s.PrintWatchdogs(f, format)
+	}
+
 	if age := time.Now().Sub(s.Timestamp); age > time.Minute {
 		fmt.Fprintln(f)
 		fmt.Fprintf(f, "Warning: data is stale. Updated %s ago\n", age)
@@ -188,6 +201,14 @@ func (s *Status) PrintGroups(f io.Writer, format string) {
 }
 
 func (s *Status) PrintOutlets(f io.Writer, format string) {
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(s.Outlets)
+
+		return
+	}
+
 	t := table.NewWriter()
 	t.AppendHeader(table.Row{
 		"Outlet",
@@ -226,6 +247,51 @@ func (s *Status) PrintOutlets(f io.Writer, format string) {
 	renderTable(t, f, format)
 }
 
+func (s *Status) PrintWatchdogs(f io.Writer, format string) {
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		"Watchdog",
+		"Type",
+		"Target",
+		"Outlets",
+		"State",
+		"Failures",
+		"Reboots",
+		"Last Reboot",
+		"Last Error",
+	})
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{Number: 6, Align: text.AlignRight},
+		{Number: 7, Align: text.AlignRight},
+	})
+
+	for _, w := range s.Watchdogs {
+		lastReboot := ""
+		if w.LastReboot != nil {
+			lastReboot = w.LastReboot.Format(time.RFC3339)
+		}
+
+		lastError := ""
+		if w.LastError != nil {
+			lastError = *w.LastError
+		}
+
+		t.AppendRow(table.Row{
+			w.Name,
+			w.Type,
+			w.Target,
+			w.Outlets,
+			w.State,
+			w.Failures,
+			w.Reboots,
+			lastReboot,
+			lastError,
+		})
+	}
+
+	renderTable(t, f, format)
+}
+
 func (s *OutletStatus) Print(f io.Writer, format string) {
 	if format == "json" {
 		enc := json.NewEncoder(f)
@@ -270,6 +336,73 @@ func (s *OutletStatus) Print(f io.Writer, format string) {
 	renderTable(t, f, format)
 }
 
+func PrintOutletResults(f io.Writer, format string, results []OutletResult) {
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(results)
+
+		return
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		"ID",
+		"Outlet",
+		"Result",
+	})
+
+	for _, r := range results {
+		result := "ok"
+		if r.Error != nil {
+			result = *r.Error
+		}
+
+		t.AppendRow(table.Row{
+			r.ID,
+			r.Name,
+			result,
+		})
+	}
+
+	renderTable(t, f, format)
+}
+
+func PrintUsers(f io.Writer, format string, users []User) {
+	if format == "json" {
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		enc.Encode(users)
+
+		return
+	}
+
+	t := table.NewWriter()
+	t.AppendHeader(table.Row{
+		"User",
+		"Outlets",
+	})
+
+	for _, u := range users {
+		outlets := "none"
+		if len(u.Outlets) > 0 {
+			ids := []string{}
+			for _, id := range u.Outlets {
+				ids = append(ids, fmt.Sprint(id))
+			}
+
+			outlets = strings.Join(ids, ",")
+		}
+
+		t.AppendRow(table.Row{
+			u.Name,
+			outlets,
+		})
+	}
+
+	renderTable(t, f, format)
+}
+
 func withUnit(n float32, unit string, digits int) string {
 	fmt := message.NewPrinter(language.English)
 	return fmt.Sprintf("%v %s", number.Decimal(n, number.MinFractionDigits(digits), number.MaxFractionDigits(digits)), unit)
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrUnsupportedJWK = errors.New("unsupported JSON web key")
+
+// jwtMethods are the asymmetric signature algorithms of RFC 7518 and RFC 8037.
+// Symmetric algorithms and unsigned tokens are rejected.
+var jwtMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// jwk is a single public key of a JSON Web Key Set.
+type jwk struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
+
+	// RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC and OKP
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+
+	key crypto.PublicKey
+}
+
+// jwks is a JSON Web Key Set as published by OpenID Connect providers at their jwks_uri.
+type jwks []jwk
+
+// loadJWKS reads the public keys from a JSON Web Key Set file.
+// Keys which are not used for signatures are skipped.
+func loadJWKS(path string) (jwks, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.Unmarshal(buf, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	keys := jwks{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		if k.key, err = k.publicKey(); err != nil {
+			return nil, fmt.Errorf("invalid key %s in JWKS: %w", k.KeyID, err)
+		}
+
+		keys = append(keys, k)
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no signing keys in JWKS: %s", path)
+	}
+
+	return keys, nil
+}
+
+func (k *jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		// Larger exponents would be truncated by rsa.PublicKey
+		exp := new(big.Int).SetBytes(e)
+		if !exp.IsInt64() || exp.Int64() < 3 || exp.Int64() > math.MaxInt32 {
+			return nil, fmt.Errorf("%w: RSA exponent %s", ErrUnsupportedJWK, exp)
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(exp.Int64()),
+		}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("%w: curve %s", ErrUnsupportedJWK, k.Curve)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+
+	case "OKP":
+		if k.Curve != "Ed25519" {
+			return nil, fmt.Errorf("%w: curve %s", ErrUnsupportedJWK, k.Curve)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		} else if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid key size", ErrUnsupportedJWK)
+		}
+
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, fmt.Errorf("%w: type %s", ErrUnsupportedJWK, k.KeyType)
+}
+
+// verify checks the signature and the claims of the token and returns the identity of the claims.
+// Tokens must expire.
+func (c *JWTConfig) verify(token string, now time.Time) (*Identity, error) {
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(jwtMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(c.Leeway),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	}
+
+	if c.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(c.Issuer))
+	}
+
+	if c.Audience != "" {
+		opts = append(opts, jwt.WithAudience(c.Audience))
+	}
+
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, c.keyFunc, opts...); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	name, ok := claims[c.Claim].(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("%w: missing claim %s", ErrInvalidCredentials, c.Claim)
+	}
+
+	return &Identity{
+		Name:   name,
+		Method: AuthMethodJWT,
+		Groups: claimStrings(claims[c.GroupsClaim]),
+	}, nil
+}
+
+// keyFunc selects the keys matching the key ID and algorithm of the token.
+func (c *JWTConfig) keyFunc(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	set := jwt.VerificationKeySet{}
+	for _, k := range c.keys {
+		if (kid != "" && k.KeyID != kid) || (k.Algorithm != "" && k.Algorithm != t.Method.Alg()) {
+			continue
+		}
+
+		set.Keys = append(set.Keys, k.key)
+	}
+
+	if len(set.Keys) == 0 {
+		return nil, fmt.Errorf("no key for %s token with ID %q", t.Method.Alg(), kid)
+	}
+
+	return set, nil
+}
+
+// claimStrings returns the values of a claim which is either a string or an array of strings.
+func claimStrings(claim any) (values []string) {
+	switch v := claim.(type) {
+	case string:
+		values = append(values, v)
+	case []any:
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	return values
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// newTestJWTConfig returns a configuration which trusts a new EC key.
+func newTestJWTConfig(t *testing.T) (*JWTConfig, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	coord := func(i interface{ FillBytes([]byte) []byte }) string {
+		return base64.RawURLEncoding.EncodeToString(i.FillBytes(make([]byte, 32)))
+	}
+
+	set := fmt.Sprintf(`{"keys": [{"kty": "EC", "kid": "test", "use": "sig", "crv": "P-256", "x": %q, "y": %q}]}`,
+		coord(key.X), coord(key.Y))
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, []byte(set), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := &JWTConfig{
+		JWKS:        path,
+		Issuer:      "https://idp.example.com",
+		Audience:    "pdud",
+		Claim:       "sub",
+		GroupsClaim: "groups",
+		Leeway:      time.Minute,
+	}
+
+	if c.keys, err = loadJWKS(path); err != nil {
+		t.Fatalf("Failed to load JWKS: %v", err)
+	}
+
+	return c, key
+}
+
+// testClaims returns valid claims for the configuration.
+func testClaims(now time.Time) jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":    "alice",
+		"iss":    "https://idp.example.com",
+		"aud":    []string{"pdud"},
+		"groups": []string{"admins", "ops"},
+		"iat":    now.Unix(),
+		"exp":    now.Add(time.Hour).Unix(),
+	}
+}
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+	t.Helper()
+
+	tok := jwt.NewWithClaims(method, claims)
+	tok.Header["kid"] = "test"
+
+	s, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	return s
+}
+
+func TestJWTVerify(t *testing.T) {
+	c, key := newTestJWTConfig(t)
+	now := time.Now()
+
+	id, err := c.verify(signTestToken(t, jwt.SigningMethodES256, key, testClaims(now)), now)
+	if err != nil {
+		t.Fatalf("Failed to verify token: %v", err)
+	}
+
+	if id.Name != "alice" || id.Method != AuthMethodJWT || !slices.Equal(id.Groups, []string{"admins", "ops"}) {
+		t.Errorf("Unexpected identity: %+v", id)
+	}
+}
+
+func TestJWTClaims(t *testing.T) {
+	c, key := newTestJWTConfig(t)
+	now := time.Now()
+
+	for _, tc := range []struct {
+		name   string
+		modify func(jwt.MapClaims)
+		err    error
+	}{
+		{"expired", func(m jwt.MapClaims) { m["exp"] = now.Add(-2 * time.Minute).Unix() }, jwt.ErrTokenExpired},
+		{"expired within leeway", func(m jwt.MapClaims) { m["exp"] = now.Add(-30 * time.Second).Unix() }, nil},
+		{"missing expiry", func(m jwt.MapClaims) { delete(m, "exp") }, jwt.ErrTokenRequiredClaimMissing},
+		{"not yet valid", func(m jwt.MapClaims) { m["nbf"] = now.Add(time.Hour).Unix() }, jwt.ErrTokenNotValidYet},
+		{"wrong issuer", func(m jwt.MapClaims) { m["iss"] = "https://evil.example.com" }, jwt.ErrTokenInvalidIssuer},
+		{"wrong audience", func(m jwt.MapClaims) { m["aud"] = "other" }, jwt.ErrTokenInvalidAudience},
+		{"missing subject", func(m jwt.MapClaims) { delete(m, "sub") }, ErrInvalidCredentials},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			claims := testClaims(now)
+			tc.modify(claims)
+
+			_, err := c.verify(signTestToken(t, jwt.SigningMethodES256, key, claims), now)
+			if tc.err == nil {
+				if err != nil {
+					t.Errorf("Failed to verify token: %v", err)
+				}
+			} else if !errors.Is(err, tc.err) || !errors.Is(err, ErrInvalidCredentials) {
+				t.Errorf("Expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestJWTSignature(t *testing.T) {
+	c, _ := newTestJWTConfig(t)
+	now := time.Now()
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tok := signTestToken(t, jwt.SigningMethodES256, other, testClaims(now))
+	if _, err := c.verify(tok, now); !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Errorf("Expected invalid signature, got %v", err)
+	}
+
+	if _, err := c.verify(tok[:len(tok)-4]+"AAAA", now); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected invalid credentials for a modified signature, got %v", err)
+	}
+
+	if _, err := c.verify("not.a.token", now); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("Expected invalid credentials for a malformed token, got %v", err)
+	}
+}
+
+func TestJWTAlgorithm(t *testing.T) {
+	c, _ := newTestJWTConfig(t)
+	now := time.Now()
+
+	// Unsigned tokens
+	none := signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, testClaims(now))
+	if _, err := c.verify(none, now); !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Errorf("Expected unsigned token to be rejected, got %v", err)
+	}
+
+	// Symmetric signatures using the public key as secret
+	hs := signTestToken(t, jwt.SigningMethodHS256, []byte(c.keys[0].X), testClaims(now))
+	if _, err := c.verify(hs, now); !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Errorf("Expected HS256 token to be rejected, got %v", err)
+	}
+}
+
+func TestJWKRSAExponent(t *testing.T) {
+	n := base64.RawURLEncoding.EncodeToString(make([]byte, 256))
+
+	for _, tc := range []struct {
+		e  []byte
+		ok bool
+	}{
+		{[]byte{1, 0, 1}, true},
+		{[]byte{3}, true},
+		{[]byte{0x7f, 0xff, 0xff, 0xff}, true},
+		{[]byte{1}, false},
+		{[]byte{0x80, 0, 0, 0}, false},
+		{[]byte{1, 0, 0, 0, 0, 0, 0, 0, 1}, false},
+	} {
+		k := &jwk{KeyType: "RSA", N: n, E: base64.RawURLEncoding.EncodeToString(tc.e)}
+		if _, err := k.publicKey(); tc.ok && err != nil {
+			t.Errorf("Failed to decode exponent %x: %v", tc.e, err)
+		} else if !tc.ok && !errors.Is(err, ErrUnsupportedJWK) {
+			t.Errorf("Expected exponent %x to be rejected, got %v", tc.e, err)
+		}
+	}
+}
@@ -0,0 +1,354 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/stv0g/pductl/internal/api"
+)
+
+const (
+	// Default current below which shed outlets are restored as fraction of the limit
+	loadSheddingDefaultRestore = 0.9
+
+	// Default time the current must stay below the restore current before restoring the next outlet
+	loadSheddingDefaultRestoreAfter = time.Minute
+)
+
+type LoadSheddingAction = api.LoadSheddingAction
+
+const (
+	LoadShed    = api.Shed
+	LoadRestore = api.Restore
+
+	EventLoadShedding = api.EventTypeLoadShedding
+)
+
+type loadSheddingPolicy struct {
+	*LoadSheddingConfig
+
+	// IDs of the outlets switched off by the policy in the order of shedding
+	shed []int
+
+	overSince  time.Time
+	underSince time.Time
+	exhausted  bool
+	unknown    bool
+}
+
+// LoadShedder protects breakers and groups from overload by switching off outlets
+// in the order of their priority and restores them in reverse order once the current fell.
+// Locked and critical outlets are never switched off.
+type LoadShedder struct {
+	pdu      PDU
+	name     string
+	aliases  map[string]string
+	critical string
+	events   *EventBroker
+
+	policies []*loadSheddingPolicy
+
+	updates chan *Status
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewLoadShedder creates a load shedder for the PDU with the policies from the configuration.
+// Actions are published to the event broker unless it is nil.
+func NewLoadShedder(pc *PDUConfig, p PDU, cfgs []LoadSheddingConfig, events *EventBroker) *LoadShedder {
+	l := &LoadShedder{
+		pdu:      p,
+		name:     pc.Name,
+		aliases:  pc.Aliases,
+		critical: pc.Critical,
+		events:   events,
+		updates:  make(chan *Status, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	for i := range cfgs {
+		l.policies = append(l.policies, &loadSheddingPolicy{
+			LoadSheddingConfig: &cfgs[i],
+		})
+	}
+
+	go l.loop()
+
+	return l
+}
+
+// Close stops the load shedder. Shed outlets remain switched off.
+func (l *LoadShedder) Close() error {
+	close(l.stop)
+	<-l.done
+
+	return nil
+}
+
+// Update passes a new status to the load shedder.
+// Outlets are switched in the background as the PDU can not be controlled from within its poll loop.
+func (l *LoadShedder) Update(sts *Status) {
+	if len(l.policies) == 0 {
+		return
+	}
+
+	// Drop an outdated status which has not been evaluated yet
+	select {
+	case <-l.updates:
+	default:
+	}
+
+	l.updates <- sts
+}
+
+func (l *LoadShedder) loop() {
+	defer close(l.done)
+
+	for {
+		select {
+		case <-l.stop:
+			return
+
+		case sts := <-l.updates:
+			for _, p := range l.policies {
+				l.evaluate(p, sts)
+			}
+		}
+	}
+}
+
+// evaluate sheds the next outlet after the current exceeded the limit for the hold time
+// and restores the last shed outlet after the current stayed below the restore current.
+func (l *LoadShedder) evaluate(p *loadSheddingPolicy, sts *Status) {
+	current, feeds, ok := p.measure(sts)
+	if !ok {
+		if !p.unknown {
+			p.unknown = true
+
+			slog.Error("Failed to find breaker or group of load shedding policy", slog.String("pdu", l.name), slog.String("policy", p.Name))
+		}
+
+		return
+	}
+
+	now := sts.Timestamp
+
+	switch {
+	case current > p.Limit:
+		p.underSince = time.Time{}
+
+		if p.overSince.IsZero() {
+			p.overSince = now
+		}
+
+		if now.Sub(p.overSince) < p.For {
+			return
+		}
+
+		// Wait for the hold time again before shedding the next outlet
+		if l.shedNext(p, sts, current, feeds) {
+			p.overSince = now
+		}
+
+	case current < p.Restore && len(p.shed) > 0:
+		p.overSince = time.Time{}
+
+		if p.underSince.IsZero() {
+			p.underSince = now
+		}
+
+		if now.Sub(p.underSince) < p.RestoreAfter {
+			return
+		}
+
+		l.restoreLast(p, sts, current)
+		p.underSince = now
+
+	default:
+		p.overSince = time.Time{}
+		p.underSince = time.Time{}
+		p.exhausted = false
+	}
+}
+
+// shedNext switches off the outlet with the highest priority which is neither locked nor critical.
+func (l *LoadShedder) shedNext(p *loadSheddingPolicy, sts *Status, current float32, feeds func(OutletStatus) bool) bool {
+	critical := l.criticalOutlets(sts)
+
+	for _, expr := range p.Outlets {
+		id, err := ExpandAliases(expr, l.aliases)
+		if err != nil {
+			slog.Error("Invalid outlets of load shedding policy", slog.String("pdu", l.name), slog.String("policy", p.Name), slog.Any("error", err))
+			continue
+		}
+
+		outlets, err := ResolveOutlets(id, sts.Outlets)
+		if err != nil {
+			slog.Error("Invalid outlets of load shedding policy", slog.String("pdu", l.name), slog.String("policy", p.Name), slog.Any("error", err))
+			continue
+		}
+
+		for _, o := range outlets {
+			if !o.State || o.Locked || critical[o.ID] || !feeds(o) || slices.Contains(p.shed, o.ID) {
+				continue
+			}
+
+			p.exhausted = false
+
+			if err := l.switchOutlet(p, o, false, current); err != nil {
+				return false
+			}
+
+			p.shed = append(p.shed, o.ID)
+
+			return true
+		}
+	}
+
+	if !p.exhausted {
+		p.exhausted = true
+
+		slog.Error("Load shedding policy has no outlets left to switch off", slog.String("pdu", l.name), slog.String("policy", p.Name), slog.Float64("current", float64(current)), slog.Float64("limit", float64(p.Limit)))
+	}
+
+	return false
+}
+
+// restoreLast switches on the outlet which has been shed last.
+func (l *LoadShedder) restoreLast(p *loadSheddingPolicy, sts *Status, current float32) {
+	id := p.shed[len(p.shed)-1]
+
+	i := slices.IndexFunc(sts.Outlets, func(o OutletStatus) bool {
+		return o.ID == id
+	})
+	if i < 0 {
+		p.shed = p.shed[:len(p.shed)-1]
+		return
+	}
+
+	o := sts.Outlets[i]
+
+	// Outlets which have been switched on or locked in the meantime are left alone
+	if !o.State && !o.Locked {
+		if err := l.switchOutlet(p, o, true, current); err != nil {
+			return
+		}
+	}
+
+	p.shed = p.shed[:len(p.shed)-1]
+}
+
+func (l *LoadShedder) switchOutlet(p *loadSheddingPolicy, o OutletStatus, state bool, current float32) error {
+	action := LoadSheddingAction{
+		Policy: p.Name,
+		Action: LoadShed,
+		Outlet: OutletResult{
+			ID:   o.ID,
+			Name: o.Name,
+		},
+		Current: current,
+		Limit:   p.Limit,
+	}
+
+	if state {
+		action.Action = LoadRestore
+	}
+
+	_, err := l.pdu.SwitchOutlet(fmt.Sprint(o.ID), state)
+
+	logger := slog.With(slog.String("pdu", l.name), slog.String("policy", p.Name), slog.String("outlet", o.Name), slog.Float64("current", float64(current)), slog.Float64("limit", float64(p.Limit)))
+
+	if err != nil {
+		e := err.Error()
+		action.Outlet.Error = &e
+
+		logger.Error("Load shedding failed to switch outlet "+onOff(state), slog.Any("error", err))
+	} else if state {
+		logger.Info("Load shedding restored outlet")
+	} else {
+		logger.Warn("Load shedding switched off outlet")
+	}
+
+	if l.events != nil {
+		l.events.Publish(&Event{
+			Type:         EventLoadShedding,
+			Timestamp:    time.Now(),
+			LoadShedding: &action,
+		})
+	}
+
+	return err
+}
+
+// criticalOutlets returns the IDs of the outlets which must never be switched off.
+func (l *LoadShedder) criticalOutlets(sts *Status) map[int]bool {
+	critical := map[int]bool{}
+	if l.critical == "" {
+		return critical
+	}
+
+	id, err := ExpandAliases(l.critical, l.aliases)
+	if err == nil {
+		var outlets []OutletStatus
+		if outlets, err = ResolveOutlets(id, sts.Outlets); err == nil {
+			for _, o := range outlets {
+				critical[o.ID] = true
+			}
+
+			return critical
+		}
+	}
+
+	// Protect all outlets if the critical outlets are unknown
+	slog.Error("Invalid critical outlets", slog.String("pdu", l.name), slog.Any("error", err))
+
+	for _, o := range sts.Outlets {
+		critical[o.ID] = true
+	}
+
+	return critical
+}
+
+// measure returns the current of the breaker or group of the policy
+// and a function which checks if an outlet is fed by it.
+func (p *loadSheddingPolicy) measure(sts *Status) (float32, func(OutletStatus) bool, bool) {
+	match := func(id int, name, expr string) bool {
+		return fmt.Sprint(id) == expr || strings.EqualFold(name, expr)
+	}
+
+	if p.Breaker != "" {
+		for _, b := range sts.Breakers {
+			if match(b.ID, b.Name, p.Breaker) {
+				// The breaker with ID 0 feeds all outlets
+				return b.TrueRMSCurrent, func(o OutletStatus) bool {
+					return b.ID == 0 || o.BreakerID == b.ID
+				}, true
+			}
+		}
+	} else {
+		for _, g := range sts.Groups {
+			if match(g.ID, g.Name, p.Group) {
+				return g.TrueRMSCurrent, func(o OutletStatus) bool {
+					return o.GroupID == g.ID
+				}, true
+			}
+		}
+	}
+
+	return 0, nil, false
+}
+
+func onOff(state bool) string {
+	if state {
+		return "on"
+	}
+
+	return "off"
+}
@@ -35,6 +35,13 @@ type OutletMetrics struct {
 	Energy         prometheus.Counter
 }
 
+type WatchdogMetrics struct {
+	Up         prometheus.Gauge
+	Failures   prometheus.Gauge
+	Reboots    prometheus.Gauge
+	LastReboot prometheus.Gauge
+}
+
 type Metrics struct {
 	Timestamp   prometheus.Gauge
 	Temperature prometheus.Gauge
@@ -43,14 +50,19 @@ type Metrics struct {
 	Breakers []BreakerMetrics
 	Groups   []GroupMetrics
 	Outlets  []OutletMetrics
+
+	Watchdogs []WatchdogMetrics
 }
 
 func (m *Metrics) Update(prevSts, newSts *Status) {
 	m.Timestamp.Set(float64(newSts.Timestamp.UnixNano()) / 1e9)
 	m.Temperature.Set(float64(newSts.Temperature))
 
+	// Counters start with the absolute values to continue after a restart
 	if prevSts != nil {
 		m.TotalEnergy.Add(float64(newSts.TotalEnergy - prevSts.TotalEnergy))
+	} else {
+		m.TotalEnergy.Add(float64(newSts.TotalEnergy))
 	}
 
 	for i := range newSts.Breakers {
@@ -73,6 +85,8 @@ func (m *Metrics) Update(prevSts, newSts *Status) {
 			prevGroup := prevSts.Groups[i]
 
 			m.Groups[i].Energy.Add(float64(newGroup.Energy - prevGroup.Energy))
+		} else {
+			m.Groups[i].Energy.Add(float64(newGroup.Energy))
 		}
 	}
 
@@ -89,6 +103,8 @@ func (m *Metrics) Update(prevSts, newSts *Status) {
 			prevOutlet := prevSts.Outlets[i]
 
 			m.Outlets[i].Energy.Add(float64(newOutlet.Energy - prevOutlet.Energy))
+		} else {
+			m.Outlets[i].Energy.Add(float64(newOutlet.Energy))
 		}
 
 		if newOutlet.Locked {
@@ -103,23 +119,81 @@ func (m *Metrics) Update(prevSts, newSts *Status) {
 			m.Outlets[i].State.Set(0)
 		}
 	}
+
+	for i := range newSts.Watchdogs {
+		newWatchdog := newSts.Watchdogs[i]
+
+		m.Watchdogs[i].Failures.Set(float64(newWatchdog.Failures))
+		m.Watchdogs[i].Reboots.Set(float64(newWatchdog.Reboots))
+
+		if newWatchdog.State == WatchdogOK {
+			m.Watchdogs[i].Up.Set(1)
+		} else {
+			m.Watchdogs[i].Up.Set(0)
+		}
+
+		if newWatchdog.LastReboot != nil {
+			m.Watchdogs[i].LastReboot.Set(float64(newWatchdog.LastReboot.UnixNano()) / 1e9)
+		}
+	}
+}
+
+// ReloadMetrics reports the result of reloading the configuration.
+type ReloadMetrics struct {
+	Successful prometheus.Gauge
+	Timestamp  prometheus.Gauge
 }
 
-func NewMetrics(sts *Status) *Metrics {
+// NewReloadMetrics registers the metrics of configuration reloads.
+func NewReloadMetrics() *ReloadMetrics {
+	return &ReloadMetrics{
+		Successful: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pdud",
+			Name:      "config_last_reload_successful",
+		}),
+		Timestamp: promauto.NewGauge(prometheus.GaugeOpts{
+			Namespace: "pdud",
+			Name:      "config_last_reload_success_timestamp_seconds",
+		}),
+	}
+}
+
+// Update records the result of a reload.
+func (m *ReloadMetrics) Update(err error) {
+	if err != nil {
+		m.Successful.Set(0)
+		return
+	}
+
+	m.Successful.Set(1)
+	m.Timestamp.SetToCurrentTime()
+}
+
+// NewMetrics registers the metrics of a PDU.
+// All metrics are labeled with the name of the PDU.
+func NewMetrics(name string, sts *Status) *Metrics {
+	pduLabels := prometheus.Labels{
+		"pdu": name,
+	}
+
 	m := &Metrics{
 		Temperature: promauto.NewGauge(prometheus.GaugeOpts{
-			Name: "temperature",
+			Name:        "temperature",
+			ConstLabels: pduLabels,
 		}),
 		TotalEnergy: promauto.NewCounter(prometheus.CounterOpts{
-			Name: "total_energy",
+			Name:        "total_energy",
+			ConstLabels: pduLabels,
 		}),
 		Timestamp: promauto.NewGauge(prometheus.GaugeOpts{
-			Name: "timestamp",
+			Name:        "timestamp",
+			ConstLabels: pduLabels,
 		}),
 	}
 
 	for _, breaker := range sts.Breakers {
 		labels := prometheus.Labels{
+			"pdu":  name,
 			"name": breaker.Name,
 		}
 
@@ -139,6 +213,7 @@ func NewMetrics(sts *Status) *Metrics {
 
 	for _, group := range sts.Groups {
 		labels := prometheus.Labels{
+			"pdu":        name,
 			"name":       group.Name,
 			"id":         fmt.Sprint(group.ID),
 			"breaker_id": fmt.Sprint(group.BreakerID),
@@ -186,6 +261,7 @@ func NewMetrics(sts *Status) *Metrics {
 
 	for _, outlet := range sts.Outlets {
 		labels := prometheus.Labels{
+			"pdu":        name,
 			"name":       outlet.Name,
 			"id":         fmt.Sprint(outlet.ID),
 			"group_id":   fmt.Sprint(outlet.GroupID),
@@ -244,5 +320,41 @@ func NewMetrics(sts *Status) *Metrics {
 		})
 	}
 
+	for _, watchdog := range sts.Watchdogs {
+		labels := prometheus.Labels{
+			"pdu":     name,
+			"name":    watchdog.Name,
+			"type":    watchdog.Type,
+			"outlets": watchdog.Outlets,
+		}
+
+		m.Watchdogs = append(m.Watchdogs, WatchdogMetrics{
+			Up: promauto.NewGauge(prometheus.GaugeOpts{
+				Namespace:   "pdu",
+				Subsystem:   "watchdog",
+				Name:        "up",
+				ConstLabels: labels,
+			}),
+			Failures: promauto.NewGauge(prometheus.GaugeOpts{
+				Namespace:   "pdu",
+				Subsystem:   "watchdog",
+				Name:        "failures",
+				ConstLabels: labels,
+			}),
+			Reboots: promauto.NewGauge(prometheus.GaugeOpts{
+				Namespace:   "pdu",
+				Subsystem:   "watchdog",
+				Name:        "reboots",
+				ConstLabels: labels,
+			}),
+			LastReboot: promauto.NewGauge(prometheus.GaugeOpts{
+				Namespace:   "pdu",
+				Subsystem:   "watchdog",
+				Name:        "last_reboot",
+				ConstLabels: labels,
+			}),
+		})
+	}
+
 	return m
 }
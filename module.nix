@@ -40,7 +40,11 @@ in
 
         serviceConfig = {
           Type = "notify";
+          StateDirectory = "pdud";
           ExecStart = "${pkgs.pductl}/bin/pdud --config /etc/pdud/config.yaml";
+          ExecReload = "${pkgs.coreutils}/bin/kill -HUP $MAINPID";
+          WatchdogSec = "1min";
+          Restart = "on-failure";
         };
       };
 
@@ -0,0 +1,355 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+const mqttDisconnectTimeout = 250 // ms
+
+// MQTTPublisher publishes the status of a PDU to an MQTT broker and
+// switches outlets on messages to command topics.
+// Home Assistant discovery payloads are published to make outlets
+// appear as switches with power sensors.
+type MQTTPublisher struct {
+	cfg     *MQTTConfig
+	pdu     PDU
+	name    string
+	prefix  string
+	aliases map[string]string
+
+	client mqtt.Client
+
+	sts        *Status
+	discovered bool
+	mu         sync.Mutex
+}
+
+// NewMQTTPublisher connects to the broker in the background and
+// keeps reconnecting after the connection has been lost.
+func NewMQTTPublisher(cfg *MQTTConfig, pc *PDUConfig, p PDU) (*MQTTPublisher, error) {
+	for i := range cfg.Outlets {
+		if err := cfg.Outlets[i].init(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	m := &MQTTPublisher{
+		cfg:     cfg,
+		pdu:     p,
+		name:    pc.Name,
+		prefix:  cfg.TopicPrefix + "/" + pc.Name,
+		aliases: pc.Aliases,
+	}
+
+	opts := mqtt.NewClientOptions().
+		AddBroker(cfg.Broker).
+		SetClientID(cfg.ClientID+"-"+pc.Name).
+		SetUsername(cfg.Username).
+		SetPassword(cfg.Password).
+		SetAutoReconnect(true).
+		SetConnectRetry(true).
+		SetWill(m.topic("availability"), "offline", 1, true).
+		SetOnConnectHandler(m.onConnect).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			slog.Warn("Lost connection to MQTT broker", slog.String("pdu", m.name), slog.Any("error", err))
+		})
+
+	m.client = mqtt.NewClient(opts)
+	m.client.Connect()
+
+	return m, nil
+}
+
+// Close marks the PDU as unavailable and disconnects from the broker.
+func (m *MQTTPublisher) Close() error {
+	if m.client.IsConnectionOpen() {
+		m.client.Publish(m.topic("availability"), 1, true, "offline").WaitTimeout(time.Second)
+	}
+
+	m.client.Disconnect(mqttDisconnectTimeout)
+
+	return nil
+}
+
+// Publish sends a status update to the broker.
+func (m *MQTTPublisher) Publish(sts *Status) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sts = sts
+
+	if !m.client.IsConnectionOpen() {
+		return
+	}
+
+	if !m.discovered && m.cfg.Discovery {
+		m.publishDiscovery(sts)
+	}
+
+	m.publishJSON(m.topic("status"), false, sts)
+	m.publish(m.topic("temperature"), false, strconv.FormatFloat(float64(sts.Temperature), 'f', 1, 32))
+
+	for _, b := range sts.Breakers {
+		m.publishJSON(m.topic("breaker", b.ID), false, b)
+	}
+
+	for _, g := range sts.Groups {
+		m.publishJSON(m.topic("group", g.ID), false, g)
+	}
+
+	for _, o := range sts.Outlets {
+		m.publishJSON(m.topic("outlet", o.ID), false, o)
+	}
+}
+
+func (m *MQTTPublisher) onConnect(c mqtt.Client) {
+	slog.Info("Connected to MQTT broker", slog.String("pdu", m.name), slog.String("broker", m.cfg.Broker))
+
+	m.publish(m.topic("availability"), true, "online")
+
+	for _, t := range []string{
+		m.topic("outlet", "+", "set"),
+		m.topic("outlet", "+", "lock", "set"),
+		m.topic("outlet", "+", "reboot"),
+	} {
+		c.Subscribe(t, 1, m.onCommand)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Publish discovery payloads again as the broker might have lost retained messages
+	m.discovered = false
+	if m.sts != nil && m.cfg.Discovery {
+		m.publishDiscovery(m.sts)
+	}
+}
+
+// onCommand handles messages to the command topics of outlets.
+func (m *MQTTPublisher) onCommand(_ mqtt.Client, msg mqtt.Message) {
+	parts := strings.Split(strings.TrimPrefix(msg.Topic(), m.prefix+"/outlet/"), "/")
+	payload := strings.ToUpper(strings.TrimSpace(string(msg.Payload())))
+
+	id, err := strconv.Atoi(parts[0])
+	if err != nil {
+		slog.Warn("Ignoring MQTT command for invalid outlet", slog.String("topic", msg.Topic()))
+		return
+	}
+
+	m.mu.Lock()
+	o := m.outlet(id)
+	sts := m.sts
+	m.mu.Unlock()
+
+	if o == nil {
+		slog.Warn("Ignoring MQTT command for unknown outlet", slog.String("topic", msg.Topic()))
+		return
+	}
+
+	var operationID string
+	var cmd func() ([]OutletResult, error)
+
+	switch strings.Join(parts[1:], "/") {
+	case "set":
+		operationID = "switch-outlet"
+		cmd = func() ([]OutletResult, error) {
+			return m.pdu.SwitchOutlet(parts[0], payload == "ON")
+		}
+
+	case "lock/set":
+		operationID = "lock-outlet"
+		cmd = func() ([]OutletResult, error) {
+			return m.pdu.LockOutlet(parts[0], payload == "LOCK")
+		}
+
+	case "reboot":
+		operationID = "reboot-outlet"
+		cmd = func() ([]OutletResult, error) {
+			return m.pdu.RebootOutlet(parts[0])
+		}
+
+	default:
+		return
+	}
+
+	if !m.allowed(operationID, sts, o) {
+		slog.Warn("MQTT command denied", slog.String("pdu", m.name), slog.String("operation", operationID), slog.Int("outlet", id))
+		return
+	}
+
+	slog.Info("MQTT command", slog.String("pdu", m.name), slog.String("operation", operationID), slog.Int("outlet", id), slog.String("payload", payload))
+
+	// Do not block the message router while the PDU is busy
+	go func() {
+		if _, err := cmd(); err != nil {
+			slog.Error("Failed to run MQTT command", slog.String("pdu", m.name), slog.String("operation", operationID), slog.Int("outlet", id), slog.Any("error", err))
+		}
+	}()
+}
+
+// allowed checks whether the operation is permitted via MQTT for the outlet
+// by the same matching of IDs, names, groups, breakers and tags as the ACL.
+func (m *MQTTPublisher) allowed(operationID string, sts *Status, o *OutletStatus) bool {
+	return permitsOutlet(m.cfg.Outlets, &AccessRequest{
+		PDU:       m.name,
+		Operation: operationID,
+		Time:      time.Now(),
+		Status:    sts,
+		Aliases:   m.aliases,
+	}, o)
+}
+
+// outlet returns the outlet with the given ID from the last status. The caller must hold m.mu.
+func (m *MQTTPublisher) outlet(id int) *OutletStatus {
+	if m.sts == nil {
+		return nil
+	}
+
+	for i := range m.sts.Outlets {
+		if o := &m.sts.Outlets[i]; o.ID == id {
+			return o
+		}
+	}
+
+	return nil
+}
+
+// publishDiscovery publishes Home Assistant discovery payloads for all entities of the PDU.
+// The caller must hold m.mu.
+func (m *MQTTPublisher) publishDiscovery(sts *Status) {
+	node := "pdud_" + m.name
+	device := map[string]any{
+		"identifiers":  []string{node},
+		"name":         "PDU " + m.name,
+		"manufacturer": "Baytech",
+	}
+
+	entity := func(component, objectID, name string, cfg map[string]any) {
+		cfg["name"] = name
+		cfg["unique_id"] = node + "_" + objectID
+		cfg["object_id"] = node + "_" + objectID
+		cfg["device"] = device
+		cfg["availability_topic"] = m.topic("availability")
+
+		m.publishJSON(strings.Join([]string{m.cfg.DiscoveryPrefix, component, node, objectID, "config"}, "/"), true, cfg)
+	}
+
+	sensor := func(objectID, name, stateTopic, value, unit, deviceClass, stateClass string) {
+		cfg := map[string]any{
+			"state_topic":         stateTopic,
+			"value_template":      "{{ value_json." + value + " }}",
+			"unit_of_measurement": unit,
+			"device_class":        deviceClass,
+			"state_class":         stateClass,
+		}
+
+		entity("sensor", objectID, name, cfg)
+	}
+
+	entity("sensor", "temperature", "Temperature", map[string]any{
+		"state_topic":         m.topic("temperature"),
+		"unit_of_measurement": "°C",
+		"device_class":        "temperature",
+		"state_class":         "measurement",
+	})
+
+	for _, b := range sts.Breakers {
+		id := fmt.Sprintf("breaker%d", b.ID)
+		sensor(id+"_current", b.Name+" current", m.topic("breaker", b.ID), "true_rms_current", "A", "current", "measurement")
+	}
+
+	for _, g := range sts.Groups {
+		id := fmt.Sprintf("group%d", g.ID)
+		t := m.topic("group", g.ID)
+
+		sensor(id+"_current", g.Name+" current", t, "true_rms_current", "A", "current", "measurement")
+		sensor(id+"_voltage", g.Name+" voltage", t, "true_rms_voltage", "V", "voltage", "measurement")
+		sensor(id+"_power", g.Name+" power", t, "avg_power", "W", "power", "measurement")
+		sensor(id+"_energy", g.Name+" energy", t, "energy", "kWh", "energy", "total_increasing")
+	}
+
+	for i := range sts.Outlets {
+		o := &sts.Outlets[i]
+		id := fmt.Sprintf("outlet%d", o.ID)
+		t := m.topic("outlet", o.ID)
+
+		state := map[string]any{
+			"state_topic":    t,
+			"value_template": "{{ 'ON' if value_json.state else 'OFF' }}",
+			"icon":           "mdi:power-socket-eu",
+		}
+
+		// Outlets which can not be switched via MQTT appear as binary sensors
+		if m.allowed("switch-outlet", sts, o) {
+			state["command_topic"] = m.topic("outlet", o.ID, "set")
+			entity("switch", id, o.Name, state)
+		} else {
+			state["device_class"] = "power"
+			entity("binary_sensor", id, o.Name, state)
+		}
+
+		if m.allowed("lock-outlet", sts, o) {
+			entity("lock", id+"_lock", o.Name+" lock", map[string]any{
+				"state_topic":    t,
+				"value_template": "{{ 'LOCKED' if value_json.locked else 'UNLOCKED' }}",
+				"command_topic":  m.topic("outlet", o.ID, "lock", "set"),
+			})
+		}
+
+		if m.allowed("reboot-outlet", sts, o) {
+			entity("button", id+"_reboot", o.Name+" reboot", map[string]any{
+				"command_topic": m.topic("outlet", o.ID, "reboot"),
+				"payload_press": "REBOOT",
+				"device_class":  "restart",
+			})
+		}
+
+		sensor(id+"_current", o.Name+" current", t, "true_rms_current", "A", "current", "measurement")
+		sensor(id+"_voltage", o.Name+" voltage", t, "true_rms_voltage", "V", "voltage", "measurement")
+		sensor(id+"_power", o.Name+" power", t, "avg_power", "W", "power", "measurement")
+		sensor(id+"_energy", o.Name+" energy", t, "energy", "kWh", "energy", "total_increasing")
+	}
+
+	m.discovered = true
+}
+
+func (m *MQTTPublisher) publishJSON(topic string, retained bool, v any) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		slog.Error("Failed to encode MQTT payload", slog.String("topic", topic), slog.Any("error", err))
+		return
+	}
+
+	m.publish(topic, retained, payload)
+}
+
+func (m *MQTTPublisher) publish(topic string, retained bool, payload any) {
+	t := m.client.Publish(topic, 0, retained, payload)
+
+	go func() {
+		if t.Wait(); t.Error() != nil {
+			slog.Error("Failed to publish MQTT message", slog.String("topic", topic), slog.Any("error", t.Error()))
+		}
+	}()
+}
+
+// topic returns the topic below the prefix of the PDU.
+func (m *MQTTPublisher) topic(parts ...any) string {
+	t := m.prefix
+	for _, p := range parts {
+		t += "/" + fmt.Sprint(p)
+	}
+
+	return t
+}
@@ -0,0 +1,181 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const maxAliasDepth = 8
+
+var ErrAliasLoop = errors.New("alias loop")
+
+// ExpandAliases replaces all terms of a comma-separated outlet expression
+// which match one of the aliases by the expression of the alias.
+func ExpandAliases(expr string, aliases map[string]string) (string, error) {
+	return expandAliases(expr, aliases, 0)
+}
+
+func expandAliases(expr string, aliases map[string]string, depth int) (string, error) {
+	if len(aliases) == 0 {
+		return expr, nil
+	}
+
+	if depth > maxAliasDepth {
+		return "", fmt.Errorf("%w: %s", ErrAliasLoop, expr)
+	}
+
+	terms := strings.Split(expr, ",")
+	for i, term := range terms {
+		term = strings.TrimSpace(term)
+
+		// Viper lower-cases all map keys
+		alias, ok := aliases[strings.ToLower(term)]
+		if !ok {
+			continue
+		}
+
+		expanded, err := expandAliases(alias, aliases, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		terms[i] = expanded
+	}
+
+	return strings.Join(terms, ","), nil
+}
+
+// ResolveOutlets resolves an outlet expression into the list of matching outlets sorted by their ID.
+// An expression is a comma-separated list of terms. Each term is either "all", an outlet ID,
+// a range of IDs (e.g. "1-5"), an outlet name or a glob pattern matched against the outlet names (e.g. "web*").
+// Outlet names are matched case-insensitively. The ID 0 selects all outlets.
+func ResolveOutlets(expr string, outlets []OutletStatus) ([]OutletStatus, error) {
+	ids := map[int]bool{}
+
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+
+		matches, err := resolveOutletTerm(term, outlets)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range matches {
+			ids[id] = true
+		}
+	}
+
+	resolved := []OutletStatus{}
+	for _, o := range outlets {
+		if ids[o.ID] {
+			resolved = append(resolved, o)
+		}
+	}
+
+	slices.SortFunc(resolved, func(a, b OutletStatus) int {
+		return a.ID - b.ID
+	})
+
+	return resolved, nil
+}
+
+func resolveOutletTerm(term string, outlets []OutletStatus) (ids []int, err error) {
+	if term == "" {
+		return nil, fmt.Errorf("%w: empty outlet expression", ErrInvalidOutletID)
+	}
+
+	hasID := func(id int) bool {
+		return slices.ContainsFunc(outlets, func(o OutletStatus) bool {
+			return o.ID == id
+		})
+	}
+
+	// All outlets
+	if strings.EqualFold(term, All) || term == "0" {
+		for _, o := range outlets {
+			ids = append(ids, o.ID)
+		}
+
+		return ids, nil
+	}
+
+	// Single ID
+	if id, err := strconv.Atoi(term); err == nil {
+		if !hasID(id) {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidOutletID, id)
+		}
+
+		return []int{id}, nil
+	}
+
+	// Range of IDs
+	if first, last, ok := strings.Cut(term, "-"); ok {
+		firstID, err1 := strconv.Atoi(strings.TrimSpace(first))
+		lastID, err2 := strconv.Atoi(strings.TrimSpace(last))
+
+		if err1 == nil && err2 == nil {
+			if firstID > lastID || !hasID(firstID) || !hasID(lastID) {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidOutletID, term)
+			}
+
+			for id := firstID; id <= lastID; id++ {
+				ids = append(ids, id)
+			}
+
+			return ids, nil
+		}
+	}
+
+	// Name or glob pattern
+	pattern := strings.ToLower(term)
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidOutletID, term, err)
+	}
+
+	for _, o := range outlets {
+		if ok, _ := path.Match(pattern, strings.ToLower(o.Name)); ok {
+			ids = append(ids, o.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, term)
+	}
+
+	return ids, nil
+}
+
+// NewOutletResult returns the result of an operation on a single outlet.
+func NewOutletResult(o OutletStatus, err error) OutletResult {
+	r := OutletResult{
+		ID:   o.ID,
+		Name: o.Name,
+	}
+
+	if err != nil {
+		errStr := err.Error()
+		r.Error = &errStr
+	}
+
+	return r
+}
+
+// OutletResultsError returns an error combining the errors of all failed outlets.
+func OutletResultsError(results []OutletResult) error {
+	errs := []error{}
+
+	for _, r := range results {
+		if r.Error != nil {
+			errs = append(errs, fmt.Errorf("outlet %d: %s", r.ID, *r.Error))
+		}
+	}
+
+	return errors.Join(errs...)
+}
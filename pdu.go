@@ -4,11 +4,14 @@
 package pductl
 
 import (
+	"context"
+
 	"github.com/stv0g/pductl/internal/api"
 )
 
 const (
-	All = "all"
+	All  = "all"
+	None = "none"
 )
 
 type (
@@ -16,23 +19,147 @@ type (
 	BreakerStatus = api.BreakerStatus
 	OutletStatus  = api.OutletStatus
 	GroupStatus   = api.GroupStatus
+	OutletResult  = api.OutletResult
+	User          = api.User
+
+	Connection      = api.Connection
+	ConnectionState = api.ConnectionState
+
+	Diagnostics = api.Diagnostics
+)
+
+const (
+	StateConnected    = api.Connected
+	StateConnecting   = api.Connecting
+	StateDisconnected = api.Disconnected
 )
 
 type PDU interface {
 	Close() error
-	SwitchOutlet(id string, state bool) (err error)
-	LockOutlet(id string, state bool) (err error)
-	RebootOutlet(id string) error
+	SwitchOutlet(id string, state bool) ([]OutletResult, error)
+	LockOutlet(id string, state bool) ([]OutletResult, error)
+	RebootOutlet(id string) ([]OutletResult, error)
 	ClearMaximumCurrents() error
 	Status(detailed bool) (*Status, error)
+	StatusOutlets(id string) ([]OutletStatus, error)
 	Temperature() (float64, error)
 	WhoAmI() (string, error)
+
+	Users() ([]User, error)
+	AddUser(name, password string) error
+	DeleteUser(name string) error
+	ChangePassword(name, password string) error
+	SetUserOutlets(name, id string) error
 }
 
 type LoginPDU interface {
 	PDU
 
 	Login(username, password string) error
+	LoginContext(ctx context.Context, username, password string) error
 	Logout() error
 	WithLogin(username, password string, cb func()) error
+	WithLoginContext(ctx context.Context, username, password string, cb func()) error
+}
+
+// ContextPDU is implemented by PDUs whose commands can be canceled
+// or bound to a deadline by a context.
+type ContextPDU interface {
+	PDU
+
+	SwitchOutletContext(ctx context.Context, id string, state bool) ([]OutletResult, error)
+	LockOutletContext(ctx context.Context, id string, state bool) ([]OutletResult, error)
+	RebootOutletContext(ctx context.Context, id string) ([]OutletResult, error)
+	ClearMaximumCurrentsContext(ctx context.Context) error
+	StatusContext(ctx context.Context, detailed bool) (*Status, error)
+	StatusOutletsContext(ctx context.Context, id string) ([]OutletStatus, error)
+	TemperatureContext(ctx context.Context) (float64, error)
+	WhoAmIContext(ctx context.Context) (string, error)
+
+	UsersContext(ctx context.Context) ([]User, error)
+	AddUserContext(ctx context.Context, name, password string) error
+	DeleteUserContext(ctx context.Context, name string) error
+	ChangePasswordContext(ctx context.Context, name, password string) error
+	SetUserOutletsContext(ctx context.Context, name, id string) error
+}
+
+// AsContextPDU returns the PDU itself if it accepts contexts.
+// Otherwise, the contexts are ignored.
+func AsContextPDU(p PDU) ContextPDU {
+	if cp, ok := p.(ContextPDU); ok {
+		return cp
+	}
+
+	return contextlessPDU{p}
+}
+
+// contextlessPDU ignores the contexts passed to the commands of a PDU.
+type contextlessPDU struct {
+	PDU
+}
+
+func (p contextlessPDU) SwitchOutletContext(_ context.Context, id string, state bool) ([]OutletResult, error) {
+	return p.SwitchOutlet(id, state)
+}
+
+func (p contextlessPDU) LockOutletContext(_ context.Context, id string, state bool) ([]OutletResult, error) {
+	return p.LockOutlet(id, state)
+}
+
+func (p contextlessPDU) RebootOutletContext(_ context.Context, id string) ([]OutletResult, error) {
+	return p.RebootOutlet(id)
+}
+
+func (p contextlessPDU) ClearMaximumCurrentsContext(_ context.Context) error {
+	return p.ClearMaximumCurrents()
+}
+
+func (p contextlessPDU) StatusContext(_ context.Context, detailed bool) (*Status, error) {
+	return p.Status(detailed)
+}
+
+func (p contextlessPDU) StatusOutletsContext(_ context.Context, id string) ([]OutletStatus, error) {
+	return p.StatusOutlets(id)
+}
+
+func (p contextlessPDU) TemperatureContext(_ context.Context) (float64, error) {
+	return p.Temperature()
+}
+
+func (p contextlessPDU) WhoAmIContext(_ context.Context) (string, error) {
+	return p.WhoAmI()
+}
+
+func (p contextlessPDU) UsersContext(_ context.Context) ([]User, error) {
+	return p.Users()
+}
+
+func (p contextlessPDU) AddUserContext(_ context.Context, name, password string) error {
+	return p.AddUser(name, password)
+}
+
+func (p contextlessPDU) DeleteUserContext(_ context.Context, name string) error {
+	return p.DeleteUser(name)
+}
+
+func (p contextlessPDU) ChangePasswordContext(_ context.Context, name, password string) error {
+	return p.ChangePassword(name, password)
+}
+
+func (p contextlessPDU) SetUserOutletsContext(_ context.Context, name, id string) error {
+	return p.SetUserOutlets(name, id)
+}
+
+// ConnectionPDU is implemented by PDUs which automatically
+// recover from a lost connection.
+type ConnectionPDU interface {
+	PDU
+
+	Connection() Connection
+}
+
+// DiagnosticsPDU is implemented by PDUs which report
+// the health of their connection and polling.
+type DiagnosticsPDU interface {
+	Diagnostics() (*Diagnostics, error)
 }
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"os"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+// Protocol numbers of ICMP and ICMPv6
+const (
+	protocolICMP   = 1
+	protocolICMPv6 = 58
+)
+
+var (
+	ErrNoEchoReply  = errors.New("no echo reply")
+	ErrHostNotFound = errors.New("host not found")
+)
+
+// ping sends an ICMP echo request to the host and waits for the reply until the context is done.
+// It uses unprivileged ICMP sockets if permitted by net.ipv4.ping_group_range and raw sockets otherwise.
+func ping(ctx context.Context, host string) error {
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return err
+	} else if len(addrs) == 0 {
+		return fmt.Errorf("%w: %s", ErrHostNotFound, host)
+	}
+
+	ip := addrs[0].IP
+
+	network, rawNetwork, proto := "udp4", "ip4:icmp", protocolICMP
+	var typ, replyTyp icmp.Type = ipv4.ICMPTypeEcho, ipv4.ICMPTypeEchoReply
+	if ip.To4() == nil {
+		network, rawNetwork, proto = "udp6", "ip6:ipv6-icmp", protocolICMPv6
+		typ, replyTyp = ipv6.ICMPTypeEchoRequest, ipv6.ICMPTypeEchoReply
+	}
+
+	privileged := false
+
+	conn, err := icmp.ListenPacket(network, "")
+	if err != nil {
+		var errRaw error
+		if conn, errRaw = icmp.ListenPacket(rawNetwork, ""); errRaw != nil {
+			return fmt.Errorf("failed to open ICMP socket: %w", err)
+		}
+
+		privileged = true
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+
+	// Unblock the read once the context is cancelled
+	stop := context.AfterFunc(ctx, func() {
+		conn.Close()
+	})
+	defer stop()
+
+	// The kernel replaces the ID by the port of unprivileged sockets
+	id := os.Getpid() & 0xffff
+	seq := rand.IntN(0xffff)
+
+	req := icmp.Message{
+		Type: typ,
+		Body: &icmp.Echo{
+			ID:   id,
+			Seq:  seq,
+			Data: []byte("pdud"),
+		},
+	}
+
+	wb, err := req.Marshal(nil)
+	if err != nil {
+		return err
+	}
+
+	var dst net.Addr = &net.UDPAddr{IP: ip, Zone: addrs[0].Zone}
+	if privileged {
+		dst = &net.IPAddr{IP: ip, Zone: addrs[0].Zone}
+	}
+
+	if _, err := conn.WriteTo(wb, dst); err != nil {
+		return err
+	}
+
+	rb := make([]byte, 1500)
+	for {
+		n, peer, err := conn.ReadFrom(rb)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, os.ErrDeadlineExceeded) {
+				return fmt.Errorf("%w from %s", ErrNoEchoReply, ip)
+			}
+
+			return err
+		}
+
+		if !peerIP(peer).Equal(ip) {
+			continue
+		}
+
+		rep, err := icmp.ParseMessage(proto, rb[:n])
+		if err != nil || rep.Type != replyTyp {
+			continue
+		}
+
+		if echo, ok := rep.Body.(*icmp.Echo); ok && echo.Seq == seq && (!privileged || echo.ID == id) {
+			return nil
+		}
+	}
+}
+
+func peerIP(addr net.Addr) net.IP {
+	switch a := addr.(type) {
+	case *net.UDPAddr:
+		return a.IP
+	case *net.IPAddr:
+		return a.IP
+	}
+
+	return nil
+}
@@ -4,142 +4,605 @@
 package pductl
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log/slog"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
-var ErrNotPolledYet = errors.New("status has not been polled yet")
+var (
+	ErrNotPolledYet = errors.New("status has not been polled yet")
+	ErrClosed       = errors.New("PDU has been closed")
+	ErrNotStarted   = errors.New("polling has not been started")
+	ErrStale        = errors.New("status is stale")
+	ErrPollStalled  = errors.New("polling is stalled")
 
+	ErrInvalidPollInterval = errors.New("poll interval must be positive")
+)
+
+const (
+	// Failed polls are retried after the poll interval which is doubled after each further failure up to this limit
+	maxPollBackoff = time.Minute
+
+	// Polls taking longer than this are considered stalled
+	maxPollDuration = time.Minute
+
+	// The status is considered stale after this number of poll intervals without a successful poll
+	maxStalePolls = 3
+
+	// Commands still running after closing the PDU for this duration are aborted
+	defaultCloseTimeout = 10 * time.Second
+)
+
+// PolledPDU periodically polls the status of a PDU and serves status requests from the last snapshot.
+// Commands are passed through to the PDU and trigger an immediate poll.
 type PolledPDU struct {
 	PDU
 
-	pollInterval time.Duration
+	cp ContextPDU
+
+	pollInterval atomic.Int64
 	username     string
 	password     string
-	lastStatus   *Status
-	stop         chan any
-	trigger      chan any
 	onStatus     func(*Status)
+
+	// Snapshots are not modified after being published
+	lastStatus atomic.Pointer[Status]
+
+	trigger chan any
+	done    chan any
+
+	// Canceled by Close to abort commands which are still running after the close timeout
+	closing      context.Context
+	abort        context.CancelFunc
+	closeTimeout time.Duration
+
+	// Health of the polling goroutine and the function stopping it
+	muPoll      sync.Mutex
+	cancel      context.CancelFunc
+	started     time.Time
+	lastPoll    time.Time
+	lastSuccess time.Time
+	failures    int
+	lastError   error
+
+	// Commands hold a read lock which Close acquires exclusively to wait for them
+	mu     sync.RWMutex
+	closed atomic.Bool
 }
 
+// NewPolledPDU creates a PDU whose status is polled after it has been started.
+// The onStatus callback is invoked from the polling goroutine before a new status is published.
 func NewPolledPDU(p PDU, interval time.Duration, username, password string, onStatus func(*Status)) *PolledPDU {
 	pp := &PolledPDU{
 		PDU: p,
+		cp:  AsContextPDU(p),
+
+		username: username,
+		password: password,
+		onStatus: onStatus,
+		trigger:  make(chan any, 1),
+		done:     make(chan any),
 
-		pollInterval: interval,
-		username:     username,
-		password:     password,
-		stop:         make(chan any),
-		trigger:      make(chan any, 16),
-		onStatus:     onStatus,
+		closeTimeout: defaultCloseTimeout,
 	}
 
-	go pp.loop()
+	pp.closing, pp.abort = context.WithCancel(context.Background())
+	pp.pollInterval.Store(int64(interval))
 
 	return pp
 }
 
+// Start polls the status until the PDU is closed or the context is canceled.
+// It does nothing if the PDU has already been closed.
+func (p *PolledPDU) Start(ctx context.Context) {
+	p.muPoll.Lock()
+	defer p.muPoll.Unlock()
+
+	if p.closed.Load() || p.cancel != nil {
+		return
+	}
+
+	ctx, p.cancel = context.WithCancel(ctx)
+	p.started = time.Now()
+
+	go p.loop(ctx)
+}
+
+// Close rejects new commands, stops polling and closes the PDU after the running commands finished.
+// Commands which are still running after the close timeout are aborted with ErrClosed.
 func (p *PolledPDU) Close() error {
-	close(p.stop)
+	if p.closed.Swap(true) {
+		return ErrClosed
+	}
+
+	p.muPoll.Lock()
+	cancel := p.cancel
+	p.muPoll.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-p.done
+	}
+
+	// Commands acquired before the PDU was marked as closed hold a read lock
+	drained := make(chan any)
+	go func() {
+		p.mu.Lock()
+		p.mu.Unlock() //nolint:staticcheck
+		close(drained)
+	}()
+
+	tmr := time.NewTimer(p.closeTimeout)
+	defer tmr.Stop()
+
+	select {
+	case <-drained:
+	case <-tmr.C:
+		slog.Warn("Aborting running commands", slog.Duration("timeout", p.closeTimeout))
+
+		p.abort()
+		<-drained
+	}
+
+	p.abort()
 
 	return p.PDU.Close()
 }
 
-func (p *PolledPDU) SwitchOutlet(id string, state bool) (err error) {
-	if err := p.PDU.SwitchOutlet(id, state); err != nil {
-		return err
+// PollInterval returns the interval between status updates.
+func (p *PolledPDU) PollInterval() time.Duration {
+	return time.Duration(p.pollInterval.Load())
+}
+
+// SetPollInterval changes the interval between status updates starting with an immediate update.
+func (p *PolledPDU) SetPollInterval(interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: %s", ErrInvalidPollInterval, interval)
 	}
 
-	p.trigger <- nil
+	if time.Duration(p.pollInterval.Swap(int64(interval))) != interval {
+		p.requestPoll()
+	}
 
 	return nil
 }
 
-func (p *PolledPDU) LockOutlet(id string, state bool) (err error) {
-	if err := p.PDU.LockOutlet(id, state); err != nil {
+func (p *PolledPDU) SwitchOutlet(id string, state bool) ([]OutletResult, error) {
+	return p.SwitchOutletContext(context.Background(), id, state)
+}
+
+func (p *PolledPDU) SwitchOutletContext(ctx context.Context, id string, state bool) ([]OutletResult, error) {
+	ctx, release, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	results, err := p.cp.SwitchOutletContext(ctx, id, state)
+	if len(results) > 0 {
+		p.requestPoll()
+	}
+
+	return results, err
+}
+
+func (p *PolledPDU) LockOutlet(id string, state bool) ([]OutletResult, error) {
+	return p.LockOutletContext(context.Background(), id, state)
+}
+
+func (p *PolledPDU) LockOutletContext(ctx context.Context, id string, state bool) ([]OutletResult, error) {
+	ctx, release, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	results, err := p.cp.LockOutletContext(ctx, id, state)
+	if len(results) > 0 {
+		p.requestPoll()
+	}
+
+	return results, err
+}
+
+func (p *PolledPDU) RebootOutlet(id string) ([]OutletResult, error) {
+	return p.RebootOutletContext(context.Background(), id)
+}
+
+func (p *PolledPDU) RebootOutletContext(ctx context.Context, id string) ([]OutletResult, error) {
+	ctx, release, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	results, err := p.cp.RebootOutletContext(ctx, id)
+	if len(results) > 0 {
+		p.requestPoll()
+	}
+
+	return results, err
+}
+
+func (p *PolledPDU) ClearMaximumCurrents() error {
+	return p.ClearMaximumCurrentsContext(context.Background())
+}
+
+func (p *PolledPDU) ClearMaximumCurrentsContext(ctx context.Context) error {
+	ctx, release, err := p.acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	if err := p.cp.ClearMaximumCurrentsContext(ctx); err != nil {
 		return err
 	}
 
-	p.trigger <- nil
+	p.requestPoll()
 
 	return nil
 }
 
-func (p *PolledPDU) RebootOutlet(id string) error {
-	if err := p.PDU.RebootOutlet(id); err != nil {
+func (p *PolledPDU) WhoAmI() (string, error) {
+	return p.WhoAmIContext(context.Background())
+}
+
+func (p *PolledPDU) WhoAmIContext(ctx context.Context) (string, error) {
+	ctx, release, err := p.acquire(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	defer release()
+
+	return p.cp.WhoAmIContext(ctx)
+}
+
+func (p *PolledPDU) Users() ([]User, error) {
+	return p.UsersContext(context.Background())
+}
+
+func (p *PolledPDU) UsersContext(ctx context.Context) ([]User, error) {
+	ctx, release, err := p.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	return p.cp.UsersContext(ctx)
+}
+
+func (p *PolledPDU) AddUser(name, password string) error {
+	return p.AddUserContext(context.Background(), name, password)
+}
+
+func (p *PolledPDU) AddUserContext(ctx context.Context, name, password string) error {
+	ctx, release, err := p.acquire(ctx)
+	if err != nil {
 		return err
 	}
 
-	p.trigger <- nil
+	defer release()
 
-	return nil
+	return p.cp.AddUserContext(ctx, name, password)
 }
 
-func (p *PolledPDU) ClearMaximumCurrents() error {
-	if err := p.PDU.ClearMaximumCurrents(); err != nil {
+func (p *PolledPDU) DeleteUser(name string) error {
+	return p.DeleteUserContext(context.Background(), name)
+}
+
+func (p *PolledPDU) DeleteUserContext(ctx context.Context, name string) error {
+	ctx, release, err := p.acquire(ctx)
+	if err != nil {
 		return err
 	}
 
-	p.trigger <- nil
+	defer release()
 
-	return nil
+	return p.cp.DeleteUserContext(ctx, name)
+}
+
+func (p *PolledPDU) ChangePassword(name, password string) error {
+	return p.ChangePasswordContext(context.Background(), name, password)
+}
+
+func (p *PolledPDU) ChangePasswordContext(ctx context.Context, name, password string) error {
+	ctx, release, err := p.acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	return p.cp.ChangePasswordContext(ctx, name, password)
+}
+
+func (p *PolledPDU) SetUserOutlets(name, id string) error {
+	return p.SetUserOutletsContext(context.Background(), name, id)
+}
+
+func (p *PolledPDU) SetUserOutletsContext(ctx context.Context, name, id string) error {
+	ctx, release, err := p.acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	return p.cp.SetUserOutletsContext(ctx, name, id)
 }
 
 func (p *PolledPDU) Status(detailed bool) (*Status, error) {
-	if p.lastStatus == nil {
+	last := p.lastStatus.Load()
+	if last == nil {
 		return nil, ErrNotPolledYet
 	}
 
-	if detailed {
-		return p.lastStatus, nil
-	} else {
-		sts := *p.lastStatus
+	sts := *last
+
+	if !detailed {
 		sts.Outlets = nil
+	}
+
+	if cp, ok := p.PDU.(ConnectionPDU); ok {
+		conn := cp.Connection()
+		sts.Connection = &conn
+	}
+
+	return &sts, nil
+}
+
+func (p *PolledPDU) StatusContext(_ context.Context, detailed bool) (*Status, error) {
+	return p.Status(detailed)
+}
+
+func (p *PolledPDU) StatusOutlets(id string) ([]OutletStatus, error) {
+	last := p.lastStatus.Load()
+	if last == nil {
+		return nil, ErrNotPolledYet
+	}
+
+	return ResolveOutlets(id, last.Outlets)
+}
+
+func (p *PolledPDU) StatusOutletsContext(_ context.Context, id string) ([]OutletStatus, error) {
+	return p.StatusOutlets(id)
+}
+
+// Connection returns the connection state of the underlying PDU.
+func (p *PolledPDU) Connection() Connection {
+	if cp, ok := p.PDU.(ConnectionPDU); ok {
+		return cp.Connection()
+	}
 
-		return &sts, nil
+	return Connection{
+		State: StateConnected,
 	}
 }
 
+// Diagnostics returns the state of the connection and the health of the polling.
+func (p *PolledPDU) Diagnostics() (*Diagnostics, error) {
+	d := &Diagnostics{
+		Connection: p.Connection(),
+	}
+
+	if dp, ok := p.PDU.(DiagnosticsPDU); ok {
+		var err error
+		if d, err = dp.Diagnostics(); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+
+	p.muPoll.Lock()
+
+	if !p.lastPoll.IsZero() {
+		lastPoll := p.lastPoll
+		d.LastPoll = &lastPoll
+	}
+
+	if !p.lastSuccess.IsZero() {
+		lastSuccess := p.lastSuccess
+		d.LastSuccessfulPoll = &lastSuccess
+	}
+
+	if p.lastError != nil {
+		lastError := p.lastError.Error()
+		d.LastError = &lastError
+	}
+
+	d.ConsecutiveFailures = p.failures
+
+	p.muPoll.Unlock()
+
+	if last := p.lastStatus.Load(); last != nil {
+		staleness := float32(now.Sub(last.Timestamp).Seconds())
+		d.Staleness = &staleness
+	}
+
+	d.PollInterval = float32(p.PollInterval().Seconds())
+	d.Alive = p.alive(now) == nil
+	d.Ready = p.ready(now) == nil
+
+	return d, nil
+}
+
+// Alive returns an error if the status is not polled regularly.
+// Failing polls do not affect the liveness as long as they are retried.
+func (p *PolledPDU) Alive() error {
+	return p.alive(time.Now())
+}
+
+// Ready returns an error if no recent status has been polled.
+func (p *PolledPDU) Ready() error {
+	return p.ready(time.Now())
+}
+
+func (p *PolledPDU) alive(now time.Time) error {
+	if p.closed.Load() {
+		return ErrClosed
+	}
+
+	p.muPoll.Lock()
+	defer p.muPoll.Unlock()
+
+	if p.started.IsZero() {
+		return ErrNotStarted
+	}
+
+	last := p.lastPoll
+	if last.IsZero() {
+		last = p.started
+	}
+
+	// The next poll is due after the backoff at the latest
+	if since := now.Sub(last); since > max(p.PollInterval(), maxPollBackoff)+maxPollDuration {
+		return fmt.Errorf("%w: last poll %s ago", ErrPollStalled, since.Round(time.Second))
+	}
+
+	return nil
+}
+
+func (p *PolledPDU) ready(now time.Time) error {
+	last := p.lastStatus.Load()
+	if last == nil {
+		return ErrNotPolledYet
+	}
+
+	if age := now.Sub(last.Timestamp); age > maxStalePolls*p.PollInterval() {
+		return fmt.Errorf("%w: updated %s ago", ErrStale, age.Round(time.Second))
+	}
+
+	return nil
+}
+
 func (p *PolledPDU) Temperature() (float64, error) {
-	if p.lastStatus == nil {
+	last := p.lastStatus.Load()
+	if last == nil {
 		return -1, ErrNotPolledYet
 	}
 
-	return float64(p.lastStatus.Temperature), nil
+	return float64(last.Temperature), nil
+}
+
+func (p *PolledPDU) TemperatureContext(_ context.Context) (float64, error) {
+	return p.Temperature()
+}
+
+// acquire marks a command as running unless the PDU has been closed.
+// The returned context is canceled with ErrClosed when the PDU is closed.
+func (p *PolledPDU) acquire(ctx context.Context) (context.Context, func(), error) {
+	// Do not queue up behind Close which waits for the running commands
+	if p.closed.Load() {
+		return nil, nil, ErrClosed
+	}
+
+	p.mu.RLock()
+
+	if p.closed.Load() {
+		p.mu.RUnlock()
+		return nil, nil, ErrClosed
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(p.closing, func() {
+		cancel(ErrClosed)
+	})
+
+	return ctx, func() {
+		stop()
+		cancel(nil)
+		p.mu.RUnlock()
+	}, nil
+}
+
+// requestPoll wakes up the polling goroutine unless a poll is already pending.
+func (p *PolledPDU) requestPoll() {
+	select {
+	case p.trigger <- nil:
+	default:
+	}
+}
+
+// poll fetches and publishes a new status.
+func (p *PolledPDU) poll(ctx context.Context) error {
+	sts, err := p.cp.StatusContext(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	if p.onStatus != nil {
+		p.onStatus(sts)
+	}
+
+	p.lastStatus.Store(sts)
+
+	return nil
 }
 
-func (p *PolledPDU) loop() {
-	tmr := time.NewTicker(p.pollInterval)
+func (p *PolledPDU) loop(ctx context.Context) {
+	defer close(p.done)
 
 	if pp, ok := p.PDU.(LoginPDU); ok {
-		if err := pp.Login(p.username, p.password); err != nil {
+		// The PDU will retry the login after re-establishing a lost connection
+		// and logs out when it is closed
+		if err := pp.LoginContext(ctx, p.username, p.password); err != nil {
 			slog.Error("Failed to login", slog.Any("error", err))
-			return
 		}
-
-		defer pp.Logout()
 	}
 
+	wait := time.Duration(0)
+
 	for {
-		newSts, err := p.PDU.Status(true)
-		if err != nil {
-			slog.Error("Failed to get status", slog.Any("error", err))
-			continue
+		tmr := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			tmr.Stop()
+			return
+
+		case <-tmr.C:
+		case <-p.trigger:
+			tmr.Stop()
 		}
 
-		p.lastStatus = newSts
+		wait = p.PollInterval()
+
+		err := p.poll(ctx)
 
-		if p.onStatus != nil {
-			p.onStatus(p.lastStatus)
+		p.muPoll.Lock()
+		p.lastPoll = time.Now()
+
+		if err != nil {
+			p.failures++
+			p.lastError = err
+		} else {
+			p.failures = 0
+			p.lastSuccess = p.lastPoll
 		}
 
-		// Wait for next tick
-		select {
-		case <-p.stop:
-			break
-		case <-tmr.C:
-		case <-p.trigger:
+		failures := p.failures
+		p.muPoll.Unlock()
+
+		if err != nil {
+			for i := 1; i < failures && wait < maxPollBackoff; i++ {
+				wait *= 2
+			}
+
+			wait = max(min(wait, maxPollBackoff), p.PollInterval())
+
+			slog.Error("Failed to get status", slog.Any("error", err), slog.Any("connection", p.Connection().State), slog.Int("failures", failures), slog.Duration("retry", wait))
 		}
 	}
 }
@@ -0,0 +1,304 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var errTestPoll = errors.New("poll failed")
+
+// fakePDU serves a static status and blocks switch commands while blocking is set.
+// All other commands are not implemented.
+type fakePDU struct {
+	ContextPDU
+
+	polls    atomic.Int64
+	fail     atomic.Bool
+	closes   atomic.Int64
+	running  chan any // Receives a value when a blocking command started
+	block    chan any // Blocking commands return once this is closed
+	blocking atomic.Bool
+}
+
+func newFakePDU() *fakePDU {
+	return &fakePDU{
+		running: make(chan any, 1),
+		block:   make(chan any),
+	}
+}
+
+func (f *fakePDU) Close() error {
+	f.closes.Add(1)
+	return nil
+}
+
+func (f *fakePDU) StatusContext(ctx context.Context, _ bool) (*Status, error) {
+	f.polls.Add(1)
+
+	if f.fail.Load() {
+		return nil, errTestPoll
+	}
+
+	sts := &Status{
+		Timestamp: time.Now(),
+	}
+
+	for id := 1; id <= 4; id++ {
+		sts.Outlets = append(sts.Outlets, OutletStatus{ID: id, Name: "outlet", State: true})
+	}
+
+	return sts, ctx.Err()
+}
+
+func (f *fakePDU) SwitchOutletContext(ctx context.Context, id string, state bool) ([]OutletResult, error) {
+	if f.blocking.Load() {
+		f.running <- nil
+
+		select {
+		case <-f.block:
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
+	}
+
+	return []OutletResult{{ID: 1}}, nil
+}
+
+// newTestPolledPDU starts polling a fake PDU and waits for the first status.
+func newTestPolledPDU(t *testing.T, interval time.Duration) (*PolledPDU, *fakePDU) {
+	t.Helper()
+
+	f := newFakePDU()
+	p := NewPolledPDU(f, interval, "", "", nil)
+	p.Start(context.Background())
+
+	t.Cleanup(func() {
+		p.Close() //nolint:errcheck
+	})
+
+	waitFor(t, func() bool { return p.Ready() == nil })
+
+	return p, f
+}
+
+// waitFor fails the test if the condition does not become true within a second.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	for deadline := time.Now().Add(time.Second); !cond(); time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+	}
+}
+
+func TestPolledPDUConcurrent(t *testing.T) {
+	p, f := newTestPolledPDU(t, time.Millisecond)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 100 {
+				if _, err := p.Status(true); err != nil {
+					t.Errorf("Failed to get status: %v", err)
+				}
+
+				if _, err := p.StatusOutlets("1-2"); err != nil {
+					t.Errorf("Failed to get status of outlets: %v", err)
+				}
+
+				if _, err := p.SwitchOutlet("1", true); err != nil {
+					t.Errorf("Failed to switch outlet: %v", err)
+				}
+
+				if _, err := p.Diagnostics(); err != nil {
+					t.Errorf("Failed to get diagnostics: %v", err)
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Failed to close: %v", err)
+	}
+
+	if f.polls.Load() < 2 {
+		t.Errorf("Expected multiple polls, got %d", f.polls.Load())
+	}
+}
+
+func TestPolledPDUCloseWaitsForCommand(t *testing.T) {
+	p, f := newTestPolledPDU(t, time.Hour)
+	f.blocking.Store(true)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := p.SwitchOutlet("1", false)
+		result <- err
+	}()
+
+	<-f.running
+
+	closed := make(chan error, 1)
+	go func() {
+		closed <- p.Close()
+	}()
+
+	// New commands are rejected while closing
+	waitFor(t, func() bool { return p.Alive() != nil })
+
+	if _, err := p.SwitchOutlet("2", false); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected closed error for new command, got %v", err)
+	}
+
+	// A second Close neither waits nor aborts the running command
+	if err := p.Close(); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected closed error for second close, got %v", err)
+	}
+
+	select {
+	case err := <-closed:
+		t.Fatalf("Closed before the running command finished: %v", err)
+	case err := <-result:
+		t.Fatalf("Running command was aborted: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(f.block)
+
+	if err := <-result; err != nil {
+		t.Errorf("Running command failed: %v", err)
+	}
+
+	if err := <-closed; err != nil {
+		t.Errorf("Failed to close: %v", err)
+	}
+
+	if n := f.closes.Load(); n != 1 {
+		t.Errorf("Expected PDU to be closed once, got %d", n)
+	}
+}
+
+func TestPolledPDUCloseAbortsCommand(t *testing.T) {
+	p, f := newTestPolledPDU(t, time.Hour)
+	p.closeTimeout = 20 * time.Millisecond
+	f.blocking.Store(true)
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := p.SwitchOutlet("1", false)
+		result <- err
+	}()
+
+	<-f.running
+
+	if err := p.Close(); err != nil {
+		t.Fatalf("Failed to close: %v", err)
+	}
+
+	if err := <-result; !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected running command to be aborted with closed error, got %v", err)
+	}
+
+	if err := p.Close(); !errors.Is(err, ErrClosed) {
+		t.Errorf("Expected closed error for second close, got %v", err)
+	}
+
+	if n := f.closes.Load(); n != 1 {
+		t.Errorf("Expected PDU to be closed once, got %d", n)
+	}
+}
+
+func TestPolledPDUBackoff(t *testing.T) {
+	p, f := newTestPolledPDU(t, 5*time.Millisecond)
+	f.fail.Store(true)
+
+	polls := f.polls.Load()
+	time.Sleep(300 * time.Millisecond)
+	polls = f.polls.Load() - polls
+
+	// Without backoff, there would be about 60 polls
+	if polls < 3 || polls > 10 {
+		t.Errorf("Expected polls to back off, got %d polls", polls)
+	}
+
+	d, err := p.Diagnostics()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d.ConsecutiveFailures < 3 || d.LastError == nil || *d.LastError != errTestPoll.Error() {
+		t.Errorf("Unexpected diagnostics: %d failures, last error %v", d.ConsecutiveFailures, d.LastError)
+	}
+
+	// Failed polls do not affect the liveness
+	if err := p.Alive(); err != nil {
+		t.Errorf("Expected PDU to be alive: %v", err)
+	}
+
+	// Commands trigger an immediate poll which ends the backoff
+	f.fail.Store(false)
+
+	if _, err := p.SwitchOutlet("1", true); err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, func() bool {
+		d, err := p.Diagnostics()
+		return err == nil && d.ConsecutiveFailures == 0
+	})
+}
+
+func TestPolledPDUSetPollInterval(t *testing.T) {
+	p, _ := newTestPolledPDU(t, time.Hour)
+
+	for _, interval := range []time.Duration{0, -time.Second} {
+		if err := p.SetPollInterval(interval); !errors.Is(err, ErrInvalidPollInterval) {
+			t.Errorf("Expected poll interval %s to be rejected, got %v", interval, err)
+		}
+	}
+
+	if p.PollInterval() != time.Hour {
+		t.Errorf("Expected poll interval to be unchanged, got %s", p.PollInterval())
+	}
+
+	if err := p.SetPollInterval(time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPolledPDUStartClose(t *testing.T) {
+	for range 10 {
+		f := newFakePDU()
+		p := NewPolledPDU(f, time.Millisecond, "", "", nil)
+
+		go p.Start(context.Background())
+
+		if err := p.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		// Polling is not started after the PDU has been closed
+		p.Start(context.Background())
+
+		polls := f.polls.Load()
+		time.Sleep(5 * time.Millisecond)
+
+		if f.polls.Load() != polls {
+			t.Error("Polled the PDU after it has been closed")
+		}
+	}
+}
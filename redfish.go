@@ -0,0 +1,688 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const redfishRoot = "/redfish/v1"
+
+var (
+	ErrResourceNotFound  = errors.New("failed to find resource")
+	ErrInvalidPowerState = errors.New("invalid power state")
+	ErrMalformedJSON     = errors.New("malformed JSON")
+)
+
+// Redfish power states of the Outlet.PowerControl action
+const (
+	redfishPowerOn    = "On"
+	redfishPowerOff   = "Off"
+	redfishPowerCycle = "PowerCycle"
+)
+
+type redfishPDU struct {
+	*PDUConfig
+
+	pdu     PDU
+	cp      ContextPDU
+	audited *AuditedPDU
+}
+
+// RedfishService serves the PDUs as DMTF Redfish PowerDistribution resources.
+// Outlets of the PDU are exposed as Outlets, groups as Branches and breakers as Mains circuits.
+type RedfishService struct {
+	cfg  *Config
+	pdus []redfishPDU
+}
+
+type redfishLink struct {
+	ODataID string `json:"@odata.id"`
+}
+
+type redfishReading struct {
+	Reading float32 `json:"Reading"`
+}
+
+type redfishStatus struct {
+	State  string `json:"State"`
+	Health string `json:"Health"`
+}
+
+type redfishResource struct {
+	ODataID   string `json:"@odata.id"`
+	ODataType string `json:"@odata.type"`
+	ID        string `json:"Id"`
+	Name      string `json:"Name"`
+}
+
+type redfishCollection struct {
+	ODataID      string        `json:"@odata.id"`
+	ODataType    string        `json:"@odata.type"`
+	Name         string        `json:"Name"`
+	MembersCount int           `json:"Members@odata.count"`
+	Members      []redfishLink `json:"Members"`
+}
+
+type redfishPowerDistribution struct {
+	redfishResource
+
+	EquipmentType string        `json:"EquipmentType"`
+	Manufacturer  string        `json:"Manufacturer"`
+	Status        redfishStatus `json:"Status"`
+	Mains         redfishLink   `json:"Mains"`
+	Branches      redfishLink   `json:"Branches"`
+	Outlets       redfishLink   `json:"Outlets"`
+	Metrics       redfishLink   `json:"Metrics"`
+}
+
+type redfishMetrics struct {
+	redfishResource
+
+	PowerWatts         redfishReading `json:"PowerWatts"`
+	EnergykWh          redfishReading `json:"EnergykWh"`
+	TemperatureCelsius redfishReading `json:"TemperatureCelsius"`
+}
+
+type redfishCircuit struct {
+	redfishResource
+
+	CircuitType string          `json:"CircuitType"`
+	Status      redfishStatus   `json:"Status"`
+	CurrentAmps redfishReading  `json:"CurrentAmps"`
+	Voltage     *redfishReading `json:"Voltage,omitempty"`
+	PowerWatts  *redfishReading `json:"PowerWatts,omitempty"`
+	EnergykWh   *redfishReading `json:"EnergykWh,omitempty"`
+	Links       struct {
+		Outlets []redfishLink `json:"Outlets,omitempty"`
+	} `json:"Links"`
+}
+
+type redfishOutlet struct {
+	redfishResource
+
+	PowerState         string         `json:"PowerState"`
+	PowerControlLocked bool           `json:"PowerControlLocked"`
+	Status             redfishStatus  `json:"Status"`
+	CurrentAmps        redfishReading `json:"CurrentAmps"`
+	Voltage            redfishReading `json:"Voltage"`
+	PowerWatts         redfishReading `json:"PowerWatts"`
+	EnergykWh          redfishReading `json:"EnergykWh"`
+	Links              struct {
+		BranchCircuit redfishLink `json:"BranchCircuit"`
+	} `json:"Links"`
+	Actions struct {
+		PowerControl struct {
+			Target          string   `json:"target"`
+			AllowableValues []string `json:"PowerState@Redfish.AllowableValues"`
+		} `json:"#Outlet.PowerControl"`
+	} `json:"Actions"`
+}
+
+type redfishPowerControl struct {
+	PowerState string `json:"PowerState"`
+}
+
+type redfishError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// NewRedfishService creates a Redfish service using the ACL of the configuration.
+func NewRedfishService(cfg *Config) *RedfishService {
+	return &RedfishService{
+		cfg: cfg,
+	}
+}
+
+// AddPDU adds a PDU to the RackPDUs collection.
+// Power control actions are recorded in the audit log.
+func (rs *RedfishService) AddPDU(pc *PDUConfig, p PDU, audit *AuditLog) {
+	rs.pdus = append(rs.pdus, redfishPDU{
+		PDUConfig: pc,
+		pdu:       p,
+		cp:        AsContextPDU(p),
+		audited:   NewAuditedPDU(audit, pc.Name, p, AuditSourceRedfish),
+	})
+}
+
+// Handler registers the Redfish service below /redfish.
+func (rs *RedfishService) Handler(mux *http.ServeMux) {
+	pdu := redfishRoot + "/PowerEquipment/RackPDUs/{pdu}"
+
+	mux.HandleFunc("GET /redfish", rs.handle("", rs.versions))
+	mux.HandleFunc("GET "+redfishRoot, rs.handle("", rs.serviceRoot))
+	mux.HandleFunc("GET "+redfishRoot+"/{$}", rs.handle("", rs.serviceRoot))
+	mux.HandleFunc("GET "+redfishRoot+"/PowerEquipment", rs.handle("", rs.powerEquipment))
+	mux.HandleFunc("GET "+redfishRoot+"/PowerEquipment/RackPDUs", rs.handle("", rs.rackPDUs))
+	mux.HandleFunc("GET "+pdu, rs.handle("status", rs.powerDistribution))
+	mux.HandleFunc("GET "+pdu+"/Metrics", rs.handle("status", rs.metrics))
+	mux.HandleFunc("GET "+pdu+"/Mains", rs.handle("status", rs.mains))
+	mux.HandleFunc("GET "+pdu+"/Mains/{circuit}", rs.handle("status", rs.main))
+	mux.HandleFunc("GET "+pdu+"/Branches", rs.handle("status", rs.branches))
+	mux.HandleFunc("GET "+pdu+"/Branches/{circuit}", rs.handle("status", rs.branch))
+	mux.HandleFunc("GET "+pdu+"/Outlets", rs.handle("status", rs.outlets))
+	mux.HandleFunc("GET "+pdu+"/Outlets/{outlet}", rs.handle("status-outlet", rs.outlet))
+	mux.HandleFunc("POST "+pdu+"/Outlets/{outlet}/Actions/Outlet.PowerControl", rs.handle("", rs.powerControl))
+}
+
+type redfishHandlerFunc func(r *http.Request, p *redfishPDU, identity *Identity) (any, error)
+
+// handle looks up the PDU of the request and checks the access of the client.
+// Resources without an operation ID are accessible by all authenticated clients.
+func (rs *RedfishService) handle(operationID string, fn redfishHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := rs.serve(r, operationID, fn)
+
+		slog.Debug("Redfish Request", slog.String("method", r.Method), slog.String("path", r.URL.Path), slog.Any("error", err))
+
+		w.Header().Set("OData-Version", "4.0")
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+
+		if err != nil {
+			writeRedfishError(w, err)
+			return
+		}
+
+		if resp == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func (rs *RedfishService) serve(r *http.Request, operationID string, fn redfishHandlerFunc) (any, error) {
+	var identity *Identity
+	if acc := rs.cfg.Access(); acc.Enabled() {
+		var err error
+		if identity, err = acc.Auth.Authenticate(r); err != nil {
+			return nil, err
+		}
+	}
+
+	var p *redfishPDU
+	if name := r.PathValue("pdu"); name != "" {
+		for i := range rs.pdus {
+			if rs.pdus[i].Name == name {
+				p = &rs.pdus[i]
+			}
+		}
+
+		if p == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPDU, name)
+		}
+	}
+
+	if operationID != "" {
+		if err := rs.checkAccess(r, p, identity, operationID); err != nil {
+			return nil, err
+		}
+	}
+
+	return fn(r, p, identity)
+}
+
+// checkAccess checks the ACL for the PDU and the outlet of the request if any.
+func (rs *RedfishService) checkAccess(r *http.Request, p *redfishPDU, identity *Identity, operationID string) error {
+	acc := rs.cfg.Access()
+	if !acc.Enabled() {
+		return nil
+	}
+
+	req := &AccessRequest{
+		Identity:  identity,
+		PDU:       p.Name,
+		Operation: operationID,
+		Time:      time.Now(),
+		Aliases:   p.Aliases,
+	}
+
+	id := r.PathValue("outlet")
+	if id == "" {
+		return acc.ACL.Check(req)
+	}
+
+	outlets, err := p.resolveOutlet(r.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	if acc.ACL.Scoped() {
+		if req.Status, err = p.cp.StatusContext(r.Context(), true); err != nil {
+			return err
+		}
+	}
+
+	return acc.ACL.CheckOutlets(req, outlets)
+}
+
+func (rs *RedfishService) versions(_ *http.Request, _ *redfishPDU, _ *Identity) (any, error) {
+	return map[string]string{
+		"v1": redfishRoot + "/",
+	}, nil
+}
+
+func (rs *RedfishService) serviceRoot(_ *http.Request, _ *redfishPDU, _ *Identity) (any, error) {
+	return struct {
+		redfishResource
+
+		RedfishVersion string      `json:"RedfishVersion"`
+		PowerEquipment redfishLink `json:"PowerEquipment"`
+	}{
+		redfishResource: redfishResource{
+			ODataID:   redfishRoot,
+			ODataType: "#ServiceRoot.v1_15_0.ServiceRoot",
+			ID:        "RootService",
+			Name:      "pdud Redfish Service",
+		},
+		RedfishVersion: "1.17.0",
+		PowerEquipment: redfishLink{redfishRoot + "/PowerEquipment"},
+	}, nil
+}
+
+func (rs *RedfishService) powerEquipment(_ *http.Request, _ *redfishPDU, _ *Identity) (any, error) {
+	return struct {
+		redfishResource
+
+		Status   redfishStatus `json:"Status"`
+		RackPDUs redfishLink   `json:"RackPDUs"`
+	}{
+		redfishResource: redfishResource{
+			ODataID:   redfishRoot + "/PowerEquipment",
+			ODataType: "#PowerEquipment.v1_2_0.PowerEquipment",
+			ID:        "PowerEquipment",
+			Name:      "Power Equipment",
+		},
+		Status: redfishStatus{
+			State:  "Enabled",
+			Health: "OK",
+		},
+		RackPDUs: redfishLink{redfishRoot + "/PowerEquipment/RackPDUs"},
+	}, nil
+}
+
+func (rs *RedfishService) rackPDUs(_ *http.Request, _ *redfishPDU, _ *Identity) (any, error) {
+	c := newRedfishCollection(redfishRoot+"/PowerEquipment/RackPDUs", "PowerDistribution", "Rack PDUs")
+
+	for _, p := range rs.pdus {
+		c.add(p.path())
+	}
+
+	return c, nil
+}
+
+func (rs *RedfishService) powerDistribution(_ *http.Request, p *redfishPDU, _ *Identity) (any, error) {
+	pd := redfishPowerDistribution{
+		redfishResource: redfishResource{
+			ODataID:   p.path(),
+			ODataType: "#PowerDistribution.v1_3_0.PowerDistribution",
+			ID:        p.Name,
+			Name:      p.Name,
+		},
+		EquipmentType: "RackPDU",
+		Manufacturer:  "Baytech",
+		Status: redfishStatus{
+			State:  "Enabled",
+			Health: "OK",
+		},
+		Mains:    redfishLink{p.path("Mains")},
+		Branches: redfishLink{p.path("Branches")},
+		Outlets:  redfishLink{p.path("Outlets")},
+		Metrics:  redfishLink{p.path("Metrics")},
+	}
+
+	if cp, ok := p.pdu.(ConnectionPDU); ok {
+		switch cp.Connection().State {
+		case StateConnecting:
+			pd.Status = redfishStatus{"Starting", "Warning"}
+		case StateDisconnected:
+			pd.Status = redfishStatus{"UnavailableOffline", "Critical"}
+		}
+	}
+
+	return pd, nil
+}
+
+func (rs *RedfishService) metrics(r *http.Request, p *redfishPDU, identity *Identity) (any, error) {
+	sts, err := rs.status(r, p, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	var power float32
+	for _, o := range sts.Outlets {
+		power += o.Power
+	}
+
+	return redfishMetrics{
+		redfishResource: redfishResource{
+			ODataID:   p.path("Metrics"),
+			ODataType: "#PowerDistributionMetrics.v1_3_0.PowerDistributionMetrics",
+			ID:        "Metrics",
+			Name:      "Metrics of " + p.Name,
+		},
+		PowerWatts:         redfishReading{power},
+		EnergykWh:          redfishReading{sts.TotalEnergy},
+		TemperatureCelsius: redfishReading{sts.Temperature},
+	}, nil
+}
+
+func (rs *RedfishService) mains(r *http.Request, p *redfishPDU, identity *Identity) (any, error) {
+	sts, err := rs.status(r, p, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newRedfishCollection(p.path("Mains"), "Circuit", "Mains of "+p.Name)
+
+	// The breaker with ID 0 feeds all outlets
+	for _, b := range sts.Breakers {
+		if slices.ContainsFunc(sts.Outlets, func(o OutletStatus) bool { return b.ID == 0 || o.BreakerID == b.ID }) {
+			c.add(p.path("Mains", b.ID))
+		}
+	}
+
+	return c, nil
+}
+
+func (rs *RedfishService) main(r *http.Request, p *redfishPDU, identity *Identity) (any, error) {
+	sts, err := rs.status(r, p, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range sts.Breakers {
+		if strconv.Itoa(b.ID) != r.PathValue("circuit") {
+			continue
+		}
+
+		c := redfishCircuit{
+			redfishResource: redfishResource{
+				ODataID:   p.path("Mains", b.ID),
+				ODataType: "#Circuit.v1_7_0.Circuit",
+				ID:        strconv.Itoa(b.ID),
+				Name:      b.Name,
+			},
+			CircuitType: "Mains",
+			Status:      redfishStatus{"Enabled", "OK"},
+			CurrentAmps: redfishReading{b.TrueRMSCurrent},
+		}
+
+		// The breaker with ID 0 feeds all outlets
+		for _, o := range sts.Outlets {
+			if b.ID == 0 || o.BreakerID == b.ID {
+				c.Links.Outlets = append(c.Links.Outlets, redfishLink{p.path("Outlets", o.ID)})
+			}
+		}
+
+		return c, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, r.URL.Path)
+}
+
+func (rs *RedfishService) branches(r *http.Request, p *redfishPDU, identity *Identity) (any, error) {
+	sts, err := rs.status(r, p, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newRedfishCollection(p.path("Branches"), "Circuit", "Branches of "+p.Name)
+
+	for _, g := range sts.Groups {
+		if slices.ContainsFunc(sts.Outlets, func(o OutletStatus) bool { return o.GroupID == g.ID }) {
+			c.add(p.path("Branches", g.ID))
+		}
+	}
+
+	return c, nil
+}
+
+func (rs *RedfishService) branch(r *http.Request, p *redfishPDU, identity *Identity) (any, error) {
+	sts, err := rs.status(r, p, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range sts.Groups {
+		if strconv.Itoa(g.ID) != r.PathValue("circuit") {
+			continue
+		}
+
+		c := redfishCircuit{
+			redfishResource: redfishResource{
+				ODataID:   p.path("Branches", g.ID),
+				ODataType: "#Circuit.v1_7_0.Circuit",
+				ID:        strconv.Itoa(g.ID),
+				Name:      g.Name,
+			},
+			CircuitType: "Branch",
+			Status:      redfishStatus{"Enabled", "OK"},
+			CurrentAmps: redfishReading{g.TrueRMSCurrent},
+			Voltage:     &redfishReading{g.TrueRMSVoltage},
+			PowerWatts:  &redfishReading{g.Power},
+			EnergykWh:   &redfishReading{g.Energy},
+		}
+
+		for _, o := range sts.Outlets {
+			if o.GroupID == g.ID {
+				c.Links.Outlets = append(c.Links.Outlets, redfishLink{p.path("Outlets", o.ID)})
+			}
+		}
+
+		return c, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrResourceNotFound, r.URL.Path)
+}
+
+func (rs *RedfishService) outlets(r *http.Request, p *redfishPDU, identity *Identity) (any, error) {
+	sts, err := rs.status(r, p, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	c := newRedfishCollection(p.path("Outlets"), "Outlet", "Outlets of "+p.Name)
+
+	for _, o := range sts.Outlets {
+		c.add(p.path("Outlets", o.ID))
+	}
+
+	return c, nil
+}
+
+func (rs *RedfishService) outlet(r *http.Request, p *redfishPDU, _ *Identity) (any, error) {
+	outlets, err := p.resolveOutlet(r.Context(), r.PathValue("outlet"))
+	if err != nil {
+		return nil, err
+	}
+
+	o := outlets[0]
+
+	ro := redfishOutlet{
+		redfishResource: redfishResource{
+			ODataID:   p.path("Outlets", o.ID),
+			ODataType: "#Outlet.v1_4_0.Outlet",
+			ID:        strconv.Itoa(o.ID),
+			Name:      o.Name,
+		},
+		PowerState:         redfishPowerOff,
+		PowerControlLocked: o.Locked,
+		Status:             redfishStatus{"Enabled", "OK"},
+		CurrentAmps:        redfishReading{o.TrueRMSCurrent},
+		Voltage:            redfishReading{o.TrueRMSVoltage},
+		PowerWatts:         redfishReading{o.Power},
+		EnergykWh:          redfishReading{o.Energy},
+	}
+
+	if o.State {
+		ro.PowerState = redfishPowerOn
+	}
+
+	ro.Links.BranchCircuit = redfishLink{p.path("Branches", o.GroupID)}
+	ro.Actions.PowerControl.Target = p.path("Outlets", o.ID, "Actions", "Outlet.PowerControl")
+	ro.Actions.PowerControl.AllowableValues = []string{redfishPowerOn, redfishPowerOff, redfishPowerCycle}
+
+	return ro, nil
+}
+
+// Switch or power cycle an outlet
+// (POST .../Outlets/{outlet}/Actions/Outlet.PowerControl)
+func (rs *RedfishService) powerControl(r *http.Request, p *redfishPDU, identity *Identity) (any, error) {
+	var req redfishPowerControl
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedJSON, err)
+	}
+
+	var operationID string
+	var state *bool
+	switch req.PowerState {
+	case redfishPowerOn, redfishPowerOff:
+		operationID = "switch-outlet"
+		on := req.PowerState == redfishPowerOn
+		state = &on
+	case redfishPowerCycle:
+		operationID = "reboot-outlet"
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPowerState, req.PowerState)
+	}
+
+	audited := p.audited.WithClient(identity, r.RemoteAddr)
+
+	// The operation depends on the requested power state
+	if err := rs.checkAccess(r, p, identity, operationID); err != nil {
+		audited.Denied(r.Context(), operationID, r.PathValue("outlet"), state, err)
+		return nil, err
+	}
+
+	outlets, err := p.resolveOutlet(r.Context(), r.PathValue("outlet"))
+	if err != nil {
+		return nil, err
+	}
+
+	id := strconv.Itoa(outlets[0].ID)
+
+	if state == nil {
+		_, err = audited.RebootOutletContext(r.Context(), id)
+	} else {
+		_, err = audited.SwitchOutletContext(r.Context(), id, *state)
+	}
+
+	return nil, err
+}
+
+// status returns the detailed status of the PDU with the outlets whose status the client may query.
+// Groups and breakers without any of these outlets are omitted by the collections.
+func (rs *RedfishService) status(r *http.Request, p *redfishPDU, identity *Identity) (*Status, error) {
+	sts, err := p.cp.StatusContext(r.Context(), true)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := rs.cfg.Access()
+	if !acc.Enabled() {
+		return sts, nil
+	}
+
+	f := &eventFilter{
+		acl: acc.ACL,
+		req: &AccessRequest{
+			Identity:  identity,
+			PDU:       p.Name,
+			Operation: "status-outlet",
+			Time:      time.Now(),
+			Aliases:   p.Aliases,
+		},
+	}
+
+	return f.status(sts), nil
+}
+
+// resolveOutlet returns the outlet with the ID of a Redfish resource.
+// Only the canonical decimal IDs of existing outlets are accepted.
+func (p *redfishPDU) resolveOutlet(ctx context.Context, id string) ([]OutletStatus, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil || n < 1 || strconv.Itoa(n) != id {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	sts, err := p.cp.StatusContext(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range sts.Outlets {
+		if o.ID == n {
+			return []OutletStatus{o}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// path returns the URL of a resource of the PDU.
+func (p *redfishPDU) path(parts ...any) string {
+	path := redfishRoot + "/PowerEquipment/RackPDUs/" + p.Name
+	for _, part := range parts {
+		path += "/" + fmt.Sprint(part)
+	}
+
+	return path
+}
+
+func newRedfishCollection(path, typ, name string) *redfishCollection {
+	return &redfishCollection{
+		ODataID:   path,
+		ODataType: "#" + typ + "Collection." + typ + "Collection",
+		Name:      name,
+		Members:   []redfishLink{},
+	}
+}
+
+func (c *redfishCollection) add(path string) {
+	c.Members = append(c.Members, redfishLink{path})
+	c.MembersCount = len(c.Members)
+}
+
+func writeRedfishError(w http.ResponseWriter, err error) {
+	var status int
+	var code string
+
+	switch {
+	case errors.Is(err, ErrMissingClientCert), errors.Is(err, ErrMissingCredentials), errors.Is(err, ErrInvalidCredentials):
+		status, code = http.StatusUnauthorized, "NoValidSession"
+	case errors.Is(err, ErrAccessDenied):
+		status, code = http.StatusForbidden, "InsufficientPrivilege"
+	case errors.Is(err, ErrUnknownPDU), errors.Is(err, ErrNotFound), errors.Is(err, ErrInvalidOutletID), errors.Is(err, ErrResourceNotFound):
+		status, code = http.StatusNotFound, "ResourceNotFound"
+	case errors.Is(err, ErrInvalidPowerState):
+		status, code = http.StatusBadRequest, "ActionParameterValueNotInList"
+	case errors.Is(err, ErrMalformedJSON):
+		status, code = http.StatusBadRequest, "MalformedJSON"
+	case errors.Is(err, ErrRejected):
+		status, code = http.StatusBadRequest, "GeneralError"
+	default:
+		status, code = http.StatusInternalServerError, "GeneralError"
+	}
+
+	var e redfishError
+	e.Error.Code = "Base.1.16." + code
+	e.Error.Message = err.Error()
+
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(e)
+}
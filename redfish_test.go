@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// redfishTestPDU serves the status of outlets 1 to 4.
+// All other commands are not implemented.
+type redfishTestPDU struct {
+	PDU
+}
+
+func (redfishTestPDU) Status(bool) (*Status, error) {
+	sts := &Status{}
+
+	for id := 1; id <= 4; id++ {
+		sts.Outlets = append(sts.Outlets, OutletStatus{ID: id, Name: "outlet", State: true})
+	}
+
+	return sts, nil
+}
+
+func TestRedfishResolveOutlet(t *testing.T) {
+	rs := NewRedfishService(&Config{})
+	rs.AddPDU(&PDUConfig{Name: "test"}, redfishTestPDU{}, nil)
+	p := &rs.pdus[0]
+
+	for _, id := range []string{"0", "-1", "+3", "03", "5", "1-2", "all", ""} {
+		if _, err := p.resolveOutlet(context.Background(), id); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected outlet %q to be rejected, got %v", id, err)
+		}
+	}
+
+	for _, id := range []string{"1", "4"} {
+		if outlets, err := p.resolveOutlet(context.Background(), id); err != nil {
+			t.Errorf("Failed to resolve outlet %q: %v", id, err)
+		} else if len(outlets) != 1 || outlets[0].ID != int(id[0]-'0') {
+			t.Errorf("Expected outlet %s, got %v", id, outlets)
+		}
+	}
+}
@@ -0,0 +1,548 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stv0g/pductl/internal/api"
+)
+
+const (
+	// Number of executed runs which are retained in the run log
+	maxScheduleRuns = 100
+
+	// Upper bound for sleeping between checks to tolerate clock jumps
+	maxScheduleWait = time.Minute
+)
+
+var (
+	ErrInvalidSchedule  = errors.New("invalid schedule")
+	ErrScheduleNotFound = errors.New("failed to find schedule")
+	ErrScheduleReadOnly = errors.New("schedule is defined in configuration")
+)
+
+type (
+	Schedule       = api.Schedule
+	NewSchedule    = api.NewSchedule
+	ScheduleRun    = api.ScheduleRun
+	ScheduleAction = api.ScheduleAction
+)
+
+const (
+	ActionOn     = api.On
+	ActionOff    = api.Off
+	ActionReboot = api.Reboot
+	ActionLock   = api.Lock
+	ActionUnlock = api.Unlock
+)
+
+// SchedulePDU is implemented by PDUs which execute scheduled outlet actions.
+type SchedulePDU interface {
+	Schedules() ([]Schedule, error)
+	AddSchedule(s NewSchedule) (*Schedule, error)
+	DeleteSchedule(id string) error
+	ScheduleRuns() ([]ScheduleRun, error)
+
+	SchedulesContext(ctx context.Context) ([]Schedule, error)
+	AddScheduleContext(ctx context.Context, s NewSchedule) (*Schedule, error)
+	DeleteScheduleContext(ctx context.Context, id string) error
+	ScheduleRunsContext(ctx context.Context) ([]ScheduleRun, error)
+}
+
+type scheduleEntry struct {
+	Schedule
+
+	cron *Cron
+}
+
+// Scheduler executes outlet actions at times given by cron expressions or at a single point in time.
+// Schedules added via the API and the run log are persisted to files to survive restarts.
+type Scheduler struct {
+	pdu      PDU
+	name     string
+	aliases  map[string]string
+	path     string
+	runsPath string
+
+	schedules map[string]*scheduleEntry
+	runs      []ScheduleRun
+
+	wake chan struct{}
+	stop chan struct{}
+	done chan struct{}
+
+	mu sync.Mutex
+}
+
+// NewScheduler creates a scheduler for the PDU with the schedules from the configuration.
+// Schedules added via the API are loaded from and saved to the file at path unless it is empty.
+// The run log is kept next to it.
+func NewScheduler(pc *PDUConfig, p PDU, cfgs []ScheduleConfig, path string) (*Scheduler, error) {
+	s := &Scheduler{
+		pdu:       p,
+		name:      pc.Name,
+		aliases:   pc.Aliases,
+		path:      path,
+		schedules: map[string]*scheduleEntry{},
+		wake:      make(chan struct{}, 1),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	now := time.Now()
+
+	for _, sc := range cfgs {
+		e, err := newScheduleEntry(sc.Schedule(), now)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %s: %w", sc.Name, err)
+		} else if e == nil {
+			slog.Warn("Skipping one-shot schedule in the past", slog.String("pdu", s.name), slog.String("schedule", sc.Name))
+			continue
+		}
+
+		s.schedules[e.ID] = e
+	}
+
+	if path != "" {
+		s.runsPath = strings.TrimSuffix(path, filepath.Ext(path)) + "-runs.json"
+
+		if err := s.load(now); err != nil {
+			return nil, err
+		}
+
+		if err := s.loadRuns(); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// Start runs the scheduler in the background until Close is called.
+func (s *Scheduler) Start() {
+	go s.run()
+}
+
+// Close stops the scheduler.
+func (s *Scheduler) Close() error {
+	close(s.stop)
+	<-s.done
+
+	return nil
+}
+
+// Schedules returns all schedules ordered by their next run.
+func (s *Scheduler) Schedules() ([]Schedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules := []Schedule{}
+	for _, e := range s.schedules {
+		schedules = append(schedules, e.Schedule)
+	}
+
+	slices.SortFunc(schedules, func(a, b Schedule) int {
+		if c := a.NextRun.Compare(*b.NextRun); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	return schedules, nil
+}
+
+// AddSchedule adds a new schedule on behalf of the client with the given name.
+// The selected outlets must exist at the time the schedule is added.
+func (s *Scheduler) AddSchedule(ns NewSchedule, createdBy string) (*Schedule, error) {
+	id, err := ExpandAliases(ns.Outlets, s.aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.pdu.StatusOutlets(id); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	sch := Schedule{
+		ID:      hex.EncodeToString(buf),
+		Name:    ns.Name,
+		Cron:    ns.Cron,
+		At:      ns.At,
+		Action:  ns.Action,
+		Outlets: ns.Outlets,
+		Source:  api.Api,
+	}
+
+	if createdBy != "" {
+		sch.CreatedBy = &createdBy
+	}
+
+	e, err := newScheduleEntry(sch, time.Now())
+	if err != nil {
+		return nil, err
+	} else if e == nil {
+		return nil, fmt.Errorf("%w: time is in the past", ErrInvalidSchedule)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.schedules[e.ID] = e
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+
+	slog.Info("Added schedule", slog.String("pdu", s.name), slog.String("id", e.ID), slog.String("created_by", createdBy))
+
+	s.notify()
+
+	return &e.Schedule, nil
+}
+
+// DeleteSchedule removes a schedule which has been added via the API.
+func (s *Scheduler) DeleteSchedule(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.schedules[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrScheduleNotFound, id)
+	} else if e.Source == api.Config {
+		return fmt.Errorf("%w: %s", ErrScheduleReadOnly, id)
+	}
+
+	delete(s.schedules, id)
+
+	slog.Info("Deleted schedule", slog.String("pdu", s.name), slog.String("id", id))
+
+	return s.save()
+}
+
+// ScheduleRuns returns the most recent runs of schedules, oldest first.
+func (s *Scheduler) ScheduleRuns() ([]ScheduleRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.runs), nil
+}
+
+// notify wakes up the scheduler loop to reconsider the next run.
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) run() {
+	defer close(s.done)
+
+	tmr := time.NewTimer(0)
+	defer tmr.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-s.wake:
+		case <-tmr.C:
+		}
+
+		next := s.runDue(time.Now())
+
+		wait := maxScheduleWait
+		if !next.IsZero() {
+			wait = min(wait, time.Until(next))
+		}
+
+		tmr.Stop()
+		tmr.Reset(max(wait, 0))
+	}
+}
+
+// runDue executes all schedules which are due and returns the time of the next run.
+func (s *Scheduler) runDue(now time.Time) (next time.Time) {
+	due := []Schedule{}
+
+	s.mu.Lock()
+
+	removed := false
+	for id, e := range s.schedules {
+		if e.NextRun.After(now) {
+			continue
+		}
+
+		due = append(due, e.Schedule)
+
+		if e.cron == nil {
+			delete(s.schedules, id)
+			removed = true
+		} else if n := e.cron.Next(now); n.IsZero() {
+			// Otherwise the schedule would remain due forever
+			slog.Warn("Removing schedule which does not match any time in the future", slog.String("pdu", s.name), slog.String("id", id), slog.String("cron", *e.Cron))
+
+			delete(s.schedules, id)
+			removed = true
+		} else {
+			e.NextRun = &n
+		}
+	}
+
+	if removed {
+		if err := s.save(); err != nil {
+			slog.Error("Failed to save schedules", slog.String("pdu", s.name), slog.Any("error", err))
+		}
+	}
+
+	s.mu.Unlock()
+
+	for _, sch := range due {
+		s.execute(sch, now)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.schedules {
+		if next.IsZero() || e.NextRun.Before(next) {
+			next = *e.NextRun
+		}
+	}
+
+	return next
+}
+
+// execute performs the action of a schedule and records the run.
+func (s *Scheduler) execute(sch Schedule, now time.Time) {
+	run := ScheduleRun{
+		ScheduleID: sch.ID,
+		Name:       sch.Name,
+		Timestamp:  now,
+		Action:     sch.Action,
+		Outlets:    sch.Outlets,
+	}
+
+	var results []OutletResult
+
+	id, err := ExpandAliases(sch.Outlets, s.aliases)
+	if err == nil {
+		switch sch.Action {
+		case ActionOn, ActionOff:
+			results, err = s.pdu.SwitchOutlet(id, sch.Action == ActionOn)
+		case ActionLock, ActionUnlock:
+			results, err = s.pdu.LockOutlet(id, sch.Action == ActionLock)
+		case ActionReboot:
+			results, err = s.pdu.RebootOutlet(id)
+		}
+	}
+
+	if len(results) > 0 {
+		run.Results = &results
+	}
+
+	attrs := []any{
+		slog.String("pdu", s.name),
+		slog.String("id", sch.ID),
+		slog.String("action", string(sch.Action)),
+		slog.String("outlets", sch.Outlets),
+	}
+
+	if err != nil {
+		e := err.Error()
+		run.Error = &e
+
+		slog.Error("Failed to run schedule", append(attrs, slog.Any("error", err))...)
+	} else {
+		slog.Info("Ran schedule", attrs...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.runs = append(s.runs, run)
+	if len(s.runs) > maxScheduleRuns {
+		s.runs = s.runs[len(s.runs)-maxScheduleRuns:]
+	}
+
+	if err := s.saveRuns(); err != nil {
+		slog.Error("Failed to save schedule runs", slog.String("pdu", s.name), slog.Any("error", err))
+	}
+}
+
+// load reads the schedules added via the API from the schedule file.
+func (s *Scheduler) load(now time.Time) error {
+	buf, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read schedules: %w", err)
+	}
+
+	schedules := []Schedule{}
+	if err := json.Unmarshal(buf, &schedules); err != nil {
+		return fmt.Errorf("failed to decode schedules: %s: %w", s.path, err)
+	}
+
+	for _, sch := range schedules {
+		e, err := newScheduleEntry(sch, now)
+		if err != nil {
+			slog.Warn("Skipping invalid schedule", slog.String("path", s.path), slog.String("id", sch.ID), slog.Any("error", err))
+			continue
+		} else if e == nil {
+			slog.Warn("Skipping missed one-shot schedule", slog.String("path", s.path), slog.String("id", sch.ID))
+			continue
+		}
+
+		s.schedules[e.ID] = e
+	}
+
+	return nil
+}
+
+// save writes the schedules added via the API to the schedule file.
+// The caller must hold s.mu.
+func (s *Scheduler) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	schedules := []Schedule{}
+	for _, e := range s.schedules {
+		if e.Source == api.Api {
+			schedules = append(schedules, e.Schedule)
+		}
+	}
+
+	if err := writeJSON(s.path, schedules); err != nil {
+		return fmt.Errorf("failed to write schedules: %w", err)
+	}
+
+	return nil
+}
+
+// loadRuns reads the run log from the file of the runs.
+func (s *Scheduler) loadRuns() error {
+	buf, err := os.ReadFile(s.runsPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read schedule runs: %w", err)
+	}
+
+	if err := json.Unmarshal(buf, &s.runs); err != nil {
+		return fmt.Errorf("failed to decode schedule runs: %s: %w", s.runsPath, err)
+	}
+
+	if len(s.runs) > maxScheduleRuns {
+		s.runs = s.runs[len(s.runs)-maxScheduleRuns:]
+	}
+
+	return nil
+}
+
+// saveRuns writes the run log to the file of the runs.
+// The caller must hold s.mu.
+func (s *Scheduler) saveRuns() error {
+	if s.runsPath == "" {
+		return nil
+	}
+
+	if err := writeJSON(s.runsPath, s.runs); err != nil {
+		return fmt.Errorf("failed to write schedule runs: %w", err)
+	}
+
+	return nil
+}
+
+// writeJSON replaces the file atomically with the encoded value.
+func writeJSON(path string, v any) error {
+	buf, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf, 0o644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// newScheduleEntry validates a schedule and calculates its next run after now.
+// Nil is returned for one-shot schedules in the past.
+func newScheduleEntry(sch Schedule, now time.Time) (*scheduleEntry, error) {
+	switch sch.Action {
+	case ActionOn, ActionOff, ActionReboot, ActionLock, ActionUnlock:
+	default:
+		return nil, fmt.Errorf("%w: unknown action: %s", ErrInvalidSchedule, sch.Action)
+	}
+
+	if sch.Outlets == "" {
+		return nil, fmt.Errorf("%w: missing outlets", ErrInvalidSchedule)
+	}
+
+	e := &scheduleEntry{
+		Schedule: sch,
+	}
+
+	switch {
+	case sch.Cron != nil && sch.At != nil:
+		return nil, fmt.Errorf("%w: cron and at are mutually exclusive", ErrInvalidSchedule)
+
+	case sch.Cron != nil:
+		var err error
+		if e.cron, err = ParseCron(*sch.Cron); err != nil {
+			return nil, err
+		}
+
+		next := e.cron.Next(now)
+		if next.IsZero() {
+			return nil, fmt.Errorf("%w: cron expression never matches", ErrInvalidSchedule)
+		}
+
+		e.NextRun = &next
+
+	case sch.At != nil:
+		if !sch.At.After(now) {
+			return nil, nil
+		}
+
+		e.NextRun = sch.At
+
+	default:
+		return nil, fmt.Errorf("%w: either cron or at is required", ErrInvalidSchedule)
+	}
+
+	return e, nil
+}
@@ -0,0 +1,420 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/stv0g/pductl/internal/api"
+)
+
+const (
+	// Interval for checking the current of outlets while waiting
+	sequencePollInterval = time.Second
+
+	// Default time to wait for outlets to draw the expected current
+	sequenceDefaultTimeout = time.Minute
+)
+
+var (
+	ErrSequenceNotFound = errors.New("failed to find sequence")
+	ErrSequenceRunning  = errors.New("sequence is already running")
+	ErrSequenceTimeout  = errors.New("timeout waiting for current")
+)
+
+type (
+	Sequence        = api.Sequence
+	SequenceStep    = api.SequenceStep
+	SequenceRun     = api.SequenceRun
+	SequenceStepRun = api.SequenceStepRun
+)
+
+const (
+	SequenceRunning   = api.SequenceRunStatusRunning
+	SequenceCompleted = api.SequenceRunStatusCompleted
+	SequenceFailed    = api.SequenceRunStatusFailed
+
+	StepPending   = api.SequenceStepRunStatusPending
+	StepSwitching = api.SequenceStepRunStatusSwitching
+	StepWaiting   = api.SequenceStepRunStatusWaiting
+	StepCompleted = api.SequenceStepRunStatusCompleted
+	StepFailed    = api.SequenceStepRunStatusFailed
+	StepSkipped   = api.SequenceStepRunStatusSkipped
+
+	EventSequence = api.EventTypeSequence
+)
+
+// SequencePDU is implemented by PDUs which run power sequences.
+type SequencePDU interface {
+	Sequences() ([]Sequence, error)
+	Sequence(name string) (*Sequence, error)
+	RunSequence(name string, state bool) (*SequenceRun, error)
+
+	SequencesContext(ctx context.Context) ([]Sequence, error)
+	SequenceContext(ctx context.Context, name string) (*Sequence, error)
+	RunSequenceContext(ctx context.Context, name string, state bool) (*SequenceRun, error)
+}
+
+type sequence struct {
+	*SequenceConfig
+
+	lastRun *SequenceRun
+}
+
+// Sequencer switches groups of outlets in a defined order.
+// Outlets are switched on in the order of the steps and off in reverse order.
+type Sequencer struct {
+	pdu     PDU
+	name    string
+	aliases map[string]string
+	events  *EventBroker
+
+	sequences []*sequence
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mu     sync.Mutex
+}
+
+// NewSequencer creates a sequencer for the PDU with the sequences from the configuration.
+// Progress is published to the event broker unless it is nil.
+func NewSequencer(pc *PDUConfig, p PDU, cfgs []SequenceConfig, events *EventBroker) *Sequencer {
+	s := &Sequencer{
+		pdu:     p,
+		name:    pc.Name,
+		aliases: pc.Aliases,
+		events:  events,
+	}
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	for i := range cfgs {
+		s.sequences = append(s.sequences, &sequence{
+			SequenceConfig: &cfgs[i],
+		})
+	}
+
+	return s
+}
+
+// Close aborts all running sequences.
+func (s *Sequencer) Close() error {
+	s.cancel()
+	s.wg.Wait()
+
+	return nil
+}
+
+// Sequences returns all sequences in the order of the configuration.
+func (s *Sequencer) Sequences() ([]Sequence, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seqs := []Sequence{}
+	for _, seq := range s.sequences {
+		seqs = append(seqs, seq.sequence())
+	}
+
+	return seqs, nil
+}
+
+// Sequence returns a sequence and the progress of its last run.
+func (s *Sequencer) Sequence(name string) (*Sequence, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seq, err := s.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	sq := seq.sequence()
+
+	return &sq, nil
+}
+
+// RunSequence starts switching the outlets of a sequence on or off in the background.
+func (s *Sequencer) RunSequence(name string, state bool) (*SequenceRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seq, err := s.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if seq.lastRun != nil && seq.lastRun.Status == SequenceRunning {
+		return nil, fmt.Errorf("%w: %s", ErrSequenceRunning, name)
+	}
+
+	steps := slices.Clone(seq.Steps)
+	if !state {
+		slices.Reverse(steps)
+	}
+
+	run := &SequenceRun{
+		Sequence: name,
+		State:    state,
+		Status:   SequenceRunning,
+		Started:  time.Now(),
+	}
+
+	for _, step := range steps {
+		run.Steps = append(run.Steps, SequenceStepRun{
+			Outlets: step.Outlets,
+			Status:  StepPending,
+		})
+	}
+
+	seq.lastRun = run
+
+	slog.Info("Starting sequence", slog.String("pdu", s.name), slog.String("sequence", name), slog.Bool("state", state))
+
+	s.publish(run)
+
+	s.wg.Add(1)
+	go s.run(run, steps)
+
+	r := copyRun(run)
+
+	return &r, nil
+}
+
+// lookup returns a sequence by its name. The caller must hold s.mu.
+func (s *Sequencer) lookup(name string) (*sequence, error) {
+	for _, seq := range s.sequences {
+		if seq.Name == name {
+			return seq, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrSequenceNotFound, name)
+}
+
+func (s *Sequencer) run(run *SequenceRun, steps []SequenceStepConfig) {
+	defer s.wg.Done()
+
+	var err error
+	for i, step := range steps {
+		if err = s.runStep(run, i, step); err != nil {
+			break
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	run.Finished = &now
+
+	if err != nil {
+		e := err.Error()
+		run.Error = &e
+		run.Status = SequenceFailed
+
+		for i := range run.Steps {
+			if run.Steps[i].Status == StepPending {
+				run.Steps[i].Status = StepSkipped
+			}
+		}
+
+		slog.Error("Sequence failed", slog.String("pdu", s.name), slog.String("sequence", run.Sequence), slog.Any("error", err))
+	} else {
+		run.Status = SequenceCompleted
+
+		slog.Info("Completed sequence", slog.String("pdu", s.name), slog.String("sequence", run.Sequence), slog.Duration("duration", now.Sub(run.Started)))
+	}
+
+	s.publish(run)
+}
+
+// runStep switches the outlets of a single step, waits for the current
+// if the outlets are switched on and finally waits for the delay.
+func (s *Sequencer) runStep(run *SequenceRun, i int, step SequenceStepConfig) (err error) {
+	s.updateStep(run, i, func(sr *SequenceStepRun) {
+		now := time.Now()
+		sr.Started = &now
+		sr.Status = StepSwitching
+	})
+
+	defer func() {
+		s.updateStep(run, i, func(sr *SequenceStepRun) {
+			now := time.Now()
+			sr.Finished = &now
+
+			if err != nil {
+				e := err.Error()
+				sr.Error = &e
+				sr.Status = StepFailed
+			} else {
+				sr.Status = StepCompleted
+			}
+		})
+	}()
+
+	id, err := ExpandAliases(step.Outlets, s.aliases)
+	if err != nil {
+		return err
+	}
+
+	results, err := s.pdu.SwitchOutlet(id, run.State)
+
+	s.updateStep(run, i, func(sr *SequenceStepRun) {
+		if len(results) > 0 {
+			sr.Results = &results
+		}
+
+		if err == nil {
+			sr.Status = StepWaiting
+		}
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if run.State && step.WaitCurrent > 0 {
+		if err := s.waitCurrent(id, step.WaitCurrent, step.Timeout); err != nil {
+			return err
+		}
+	}
+
+	return s.sleep(step.Delay)
+}
+
+// waitCurrent waits until all selected outlets draw more than the given current.
+func (s *Sequencer) waitCurrent(id string, current float32, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = sequenceDefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(s.ctx, timeout)
+	defer cancel()
+
+	tmr := time.NewTicker(sequencePollInterval)
+	defer tmr.Stop()
+
+	for {
+		outlets, err := s.pdu.StatusOutlets(id)
+		if err != nil {
+			return err
+		}
+
+		below := slices.IndexFunc(outlets, func(o OutletStatus) bool {
+			return o.TrueRMSCurrent <= current
+		})
+		if below < 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				o := outlets[below]
+				return fmt.Errorf("%w: outlet %s draws %.2f A of %.2f A", ErrSequenceTimeout, o.Name, o.TrueRMSCurrent, current)
+			}
+
+			return ctx.Err()
+
+		case <-tmr.C:
+		}
+	}
+}
+
+func (s *Sequencer) sleep(d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	tmr := time.NewTimer(d)
+	defer tmr.Stop()
+
+	select {
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	case <-tmr.C:
+		return nil
+	}
+}
+
+// updateStep modifies the progress of a step and publishes it.
+func (s *Sequencer) updateStep(run *SequenceRun, i int, cb func(sr *SequenceStepRun)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cb(&run.Steps[i])
+
+	s.publish(run)
+}
+
+// publish emits the progress of a run as event. The caller must hold s.mu.
+func (s *Sequencer) publish(run *SequenceRun) {
+	if s.events == nil {
+		return
+	}
+
+	r := copyRun(run)
+
+	s.events.Publish(&Event{
+		Type:      EventSequence,
+		Timestamp: time.Now(),
+		Sequence:  &r,
+	})
+}
+
+// sequence returns the API representation of the sequence. The caller must hold s.mu.
+func (seq *sequence) sequence() Sequence {
+	sq := Sequence{
+		Name:  seq.Name,
+		Steps: []SequenceStep{},
+	}
+
+	for _, step := range seq.Steps {
+		st := SequenceStep{
+			Outlets: step.Outlets,
+		}
+
+		if step.Delay > 0 {
+			d := step.Delay.String()
+			st.Delay = &d
+		}
+
+		if step.WaitCurrent > 0 {
+			c := step.WaitCurrent
+			st.WaitCurrent = &c
+
+			t := sequenceDefaultTimeout
+			if step.Timeout > 0 {
+				t = step.Timeout
+			}
+
+			ts := t.String()
+			st.Timeout = &ts
+		}
+
+		sq.Steps = append(sq.Steps, st)
+	}
+
+	if seq.lastRun != nil {
+		r := copyRun(seq.lastRun)
+		sq.LastRun = &r
+	}
+
+	return sq
+}
+
+// copyRun returns a copy of a run which is not modified by the running sequence.
+func copyRun(run *SequenceRun) SequenceRun {
+	r := *run
+	r.Steps = slices.Clone(run.Steps)
+
+	return r
+}
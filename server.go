@@ -7,8 +7,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"slices"
+	"time"
 
 	"github.com/oapi-codegen/runtime/strictmiddleware/nethttp"
 	"github.com/stv0g/pductl/internal/api"
@@ -16,46 +19,85 @@ import (
 
 var _ api.StrictServerInterface = (*Server)(nil)
 
+const eventKeepAliveInterval = 30 * time.Second
+
 var (
 	ErrMissingClientCert = errors.New("missing client certificate")
 	ErrAccessDenied      = errors.New("access denied")
 )
 
+type contextKey int
+
+// Context keys for the identity and the address of the client
+const (
+	contextKeyIdentity contextKey = iota
+	contextKeyRemoteAddress
+)
+
 type Server struct {
 	PDU
+
+	cp        ContextPDU
+	cfg       *Config
+	name      string
+	aliases   map[string]string
+	events    *EventBroker
+	history   *History
+	scheduler *Scheduler
+	sequencer *Sequencer
+	alerter   *Alerter
+	audit     *AuditLog
+	audited   *AuditedPDU
 }
 
-func Handler(mux *http.ServeMux, p PDU, cfg *Config) http.Handler {
+// Operations which are recorded in the audit log
+var auditedOperations = map[string]bool{
+	"switch-outlet":          true,
+	"lock-outlet":            true,
+	"reboot-outlet":          true,
+	"clear-maximum-currents": true,
+}
+
+// Handler registers the REST API for a single PDU below the base URL.
+func Handler(mux *http.ServeMux, baseURL string, pc *PDUConfig, p PDU, cfg *Config, events *EventBroker, history *History, scheduler *Scheduler, sequencer *Sequencer, alerter *Alerter, audit *AuditLog) http.Handler {
 	svr := &Server{
-		PDU: p,
+		PDU:       p,
+		cp:        AsContextPDU(p),
+		cfg:       cfg,
+		name:      pc.Name,
+		aliases:   pc.Aliases,
+		events:    events,
+		history:   history,
+		scheduler: scheduler,
+		sequencer: sequencer,
+		alerter:   alerter,
+		audit:     audit,
+		audited:   NewAuditedPDU(audit, pc.Name, p, AuditSourceAPI),
 	}
 
 	mwLog := func(f nethttp.StrictHTTPHandlerFunc, operationID string) nethttp.StrictHTTPHandlerFunc {
 		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (response interface{}, err error) {
 			response, err = f(ctx, w, r, request)
 
-			slog.Debug("API Request", slog.String("operation", toKebabCase(operationID)), slog.Any("request", request), slog.Any("response", response), slog.Any("error", err))
+			slog.Debug("API Request", slog.String("pdu", svr.name), slog.String("operation", toKebabCase(operationID)), slog.Any("request", request), slog.Any("response", response), slog.Any("error", err))
 
 			return response, err
 		}
 	}
 
 	mwAuth := func(f nethttp.StrictHTTPHandlerFunc, operationID string) nethttp.StrictHTTPHandlerFunc {
-		if len(cfg.ACL) == 0 || cfg.TLS.Cert == "" || cfg.TLS.Key == "" {
-			return f
-		}
+		operationID = toKebabCase(operationID)
 
 		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (response interface{}, err error) {
-			if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
-				return nil, ErrMissingClientCert
-			}
+			ctx = context.WithValue(ctx, contextKeyRemoteAddress, r.RemoteAddr)
 
-			commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
-			operationID = toKebabCase(operationID)
-			outletID := api.OutletIDFromRequest(request)
+			if ctx, err = svr.authorize(ctx, w, r, operationID, request); err != nil {
+				// Denied state-changing operations never reach the audited PDU
+				if auditedOperations[operationID] {
+					svr.client(ctx).Denied(ctx, operationID, api.OutletIDFromRequest(request), api.StateFromRequest(request), err)
+				}
 
-			if !cfg.ACL.Check(commonName, operationID, outletID) {
-				return nil, ErrAccessDenied
+				return nil, err
 			}
 
 			return f(ctx, w, r, request)
@@ -64,39 +106,43 @@ func Handler(mux *http.ServeMux, p PDU, cfg *Config) http.Handler {
 
 	mws := []nethttp.StrictHTTPMiddlewareFunc{mwLog, mwAuth}
 	si := api.NewStrictHandlerWithOptions(svr, mws, api.StrictHTTPServerOptions{
-		RequestErrorHandlerFunc:  errorHandlerFunc,
+		RequestErrorHandlerFunc:  requestErrorHandlerFunc,
 		ResponseErrorHandlerFunc: errorHandlerFunc,
 	})
 
 	return api.HandlerWithOptions(si, api.StdHTTPServerOptions{
-		BaseURL:          "/api/v1",
+		BaseURL:          baseURL,
 		BaseRouter:       mux,
-		ErrorHandlerFunc: errorHandlerFunc,
+		ErrorHandlerFunc: requestErrorHandlerFunc,
 	})
 }
 
-func errorHandlerFuncFor(err error) http.HandlerFunc {
-	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		w.Header().Set("X-Content-Type-Options", "nosniff")
+// errorHandlerFunc responds with the code and HTTP status code of the sentinel error wrapped by err.
+func errorHandlerFunc(w http.ResponseWriter, r *http.Request, err error) {
+	e := NewError(err)
 
-		switch {
-		case errors.Is(err, ErrAccessDenied):
-			w.WriteHeader(http.StatusForbidden)
-		case errors.Is(err, ErrMissingClientCert):
-			w.WriteHeader(http.StatusUnauthorized)
-		default:
-			w.WriteHeader(http.StatusInternalServerError)
-		}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(e.StatusCode)
 
-		json.NewEncoder(w).Encode(api.Error{
-			Error: err.Error(),
-		})
-	}
+	json.NewEncoder(w).Encode(e.API())
 }
 
-func errorHandlerFunc(w http.ResponseWriter, r *http.Request, err error) {
-	errorHandlerFuncFor(err)(w, r)
+// requestErrorHandlerFunc responds to requests which could not be decoded.
+func requestErrorHandlerFunc(w http.ResponseWriter, r *http.Request, err error) {
+	errorHandlerFunc(w, r, fmt.Errorf("%w: %w", ErrInvalidRequest, err))
+}
+
+// outletError annotates errors caused by the outlet expression of a request with the expression.
+func outletError(id string, err error) error {
+	if !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrInvalidOutletID) && !errors.Is(err, ErrAliasLoop) {
+		return err
+	}
+
+	e := NewError(err)
+	e.Outlet = id
+
+	return e
 }
 
 // Get status of PDU
@@ -107,24 +153,327 @@ func (p *Server) Status(ctx context.Context, request api.StatusRequestObject) (a
 		detailed = *d
 	}
 
-	sts, err := p.PDU.Status(detailed)
+	sts, err := p.cp.StatusContext(ctx, detailed)
 	if err != nil {
-		return api.Status500JSONResponse{
-			Error: err.Error(),
-		}, nil
+		return nil, err
+	}
+
+	if f := p.outletFilter(ctx); f != nil {
+		sts = f.status(sts)
 	}
 
 	return api.Status200JSONResponse(*sts), nil
 }
 
+// Stream status updates and change events
+// (GET /events)
+func (s *Server) Events(ctx context.Context, request api.EventsRequestObject) (api.EventsResponseObject, error) {
+	if s.events == nil {
+		return nil, fmt.Errorf("events are %w", ErrNotEnabled)
+	}
+
+	// Start the stream with the current status
+	var initial *Event
+	if sts, err := s.cp.StatusContext(ctx, true); err == nil {
+		initial = &Event{
+			Type:      EventStatus,
+			Timestamp: sts.Timestamp,
+			Status:    sts,
+		}
+	}
+
+	// Clients only receive events of the outlets whose status they may query
+	filter := s.outletFilter(ctx)
+	if filter != nil && initial != nil {
+		initial = filter.apply(initial)
+	}
+
+	events, unsubscribe := s.events.Subscribe()
+
+	return &eventStream{
+		ctx:         ctx,
+		initial:     initial,
+		events:      events,
+		filter:      filter,
+		unsubscribe: unsubscribe,
+	}, nil
+}
+
+// eventStream encodes events as Server-Sent Events until the request is cancelled.
+type eventStream struct {
+	ctx         context.Context
+	initial     *Event
+	events      <-chan *Event
+	filter      *eventFilter
+	unsubscribe func()
+}
+
+// eventFilter removes outlets from events for which the ACL does not permit the operation of the request.
+// It is also used for the status, history and alerts.
+type eventFilter struct {
+	acl AccessControlList
+	req *AccessRequest
+}
+
+// outletFilter returns a filter for the outlets whose status the client may query.
+// It returns nil if access control is disabled.
+func (s *Server) outletFilter(ctx context.Context) *eventFilter {
+	acc := s.cfg.Access()
+	if !acc.Enabled() {
+		return nil
+	}
+
+	return &eventFilter{
+		acl: acc.ACL,
+		req: s.accessRequest(ctx, "status-outlet"),
+	}
+}
+
+// apply returns the event without the hidden outlets or nil if the event concerns a hidden outlet.
+func (f *eventFilter) apply(e *Event) *Event {
+	f.req.Time = time.Now()
+
+	switch {
+	case e.Status != nil:
+		filtered := *e
+		filtered.Status = f.status(e.Status)
+
+		return &filtered
+
+	case e.Outlet != nil:
+		if !f.visible(e.Outlet) {
+			return nil
+		}
+
+	case e.LoadShedding != nil:
+		if !f.visibleID(e.LoadShedding.Outlet.ID) {
+			return nil
+		}
+
+	case e.Alert != nil:
+		if !f.visibleAlert(e.Alert) {
+			return nil
+		}
+	}
+
+	return e
+}
+
+// status returns a copy of the status without the hidden outlets.
+// Groups, breakers and tags of later checks are resolved with this status.
+func (f *eventFilter) status(sts *Status) *Status {
+	f.req.Status = sts
+	f.req.tags = nil
+
+	filtered := *sts
+	filtered.Outlets = slices.DeleteFunc(slices.Clone(sts.Outlets), func(o OutletStatus) bool {
+		return !f.visible(&o)
+	})
+
+	return &filtered
+}
+
+// visibleAlert returns false for alerts of hidden outlets.
+func (f *eventFilter) visibleAlert(a *Alert) bool {
+	return alertRuleTypes[a.Type].kind != "outlet" || a.ID == nil || f.visibleID(*a.ID)
+}
+
+func (f *eventFilter) visible(o *OutletStatus) bool {
+	return f.acl.permitted(f.req, o)
+}
+
+// visibleID looks up the outlet in the latest status. Unknown outlets are hidden.
+func (f *eventFilter) visibleID(id int) bool {
+	if f.req.Status == nil {
+		return false
+	}
+
+	for i := range f.req.Status.Outlets {
+		if o := &f.req.Status.Outlets[i]; o.ID == id {
+			return f.visible(o)
+		}
+	}
+
+	return false
+}
+
+func (es *eventStream) VisitEventsResponse(w http.ResponseWriter) error {
+	defer es.unsubscribe()
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return errors.New("streaming is not supported")
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(e *Event) error {
+		payload, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, payload); err != nil {
+			return err
+		}
+
+		flusher.Flush()
+
+		return nil
+	}
+
+	if es.initial != nil {
+		if err := write(es.initial); err != nil {
+			return err
+		}
+	} else {
+		flusher.Flush()
+	}
+
+	keepAlive := time.NewTicker(eventKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-es.ctx.Done():
+			return nil
+
+		case e, ok := <-es.events:
+			if !ok {
+				return nil
+			}
+
+			if es.filter != nil {
+				if e = es.filter.apply(e); e == nil {
+					continue
+				}
+			}
+
+			if err := write(e); err != nil {
+				return err
+			}
+
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return err
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+// Get past status samples
+// (GET /history)
+func (s *Server) History(ctx context.Context, request api.HistoryRequestObject) (api.HistoryResponseObject, error) {
+	if s.history == nil {
+		return nil, fmt.Errorf("history is %w", ErrNotEnabled)
+	}
+
+	to := time.Now()
+	if t := request.Params.To; t != nil {
+		to = *t
+	}
+
+	from := to.Add(-time.Hour)
+	if f := request.Params.From; f != nil {
+		from = *f
+	}
+
+	var step time.Duration
+	if st := request.Params.Step; st != nil {
+		var err error
+		if step, err = time.ParseDuration(*st); err != nil {
+			return nil, fmt.Errorf("%w: invalid step: %w", ErrInvalidRequest, err)
+		}
+	}
+
+	outlet, id := "", ""
+	if o := request.Params.Outlet; o != nil {
+		outlet = *o
+
+		var err error
+		if id, err = ExpandAliases(outlet, s.aliases); err != nil {
+			return nil, outletError(outlet, err)
+		}
+	}
+
+	samples, err := s.history.Query(from, to, step, id)
+	if err != nil {
+		return nil, outletError(outlet, err)
+	}
+
+	// Clients only receive the outlets whose status they may query
+	if f := s.outletFilter(ctx); f != nil {
+		for i := range samples {
+			samples[i] = *f.status(&samples[i])
+		}
+	}
+
+	return api.History200JSONResponse(samples), nil
+}
+
+// Query the audit log
+// (GET /audit)
+func (s *Server) Audit(ctx context.Context, request api.AuditRequestObject) (api.AuditResponseObject, error) {
+	if s.audit == nil {
+		return nil, fmt.Errorf("audit log is %w", ErrNotEnabled)
+	}
+
+	to := time.Now()
+	if t := request.Params.To; t != nil {
+		to = *t
+	}
+
+	from := to.Add(-24 * time.Hour)
+	if f := request.Params.From; f != nil {
+		from = *f
+	}
+
+	var ids map[int]bool
+	if o := request.Params.Outlet; o != nil {
+		outlets, err := s.resolveOutlets(ctx, *o)
+		if err != nil {
+			return nil, outletError(*o, err)
+		}
+
+		ids = map[int]bool{}
+		for _, o := range outlets {
+			ids[o.ID] = true
+		}
+	}
+
+	operation := ""
+	if op := request.Params.Operation; op != nil {
+		operation = *op
+	}
+
+	client := ""
+	if c := request.Params.Client; c != nil {
+		client = *c
+	}
+
+	limit := 0
+	if l := request.Params.Limit; l != nil {
+		limit = *l
+	}
+
+	entries, err := s.audit.Query(s.name, from, to, ids, operation, client, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.Audit200JSONResponse(entries), nil
+}
+
 // Get temperature of PDU
 // (GET /temperature)
 func (s *Server) Temperature(ctx context.Context, request api.TemperatureRequestObject) (api.TemperatureResponseObject, error) {
-	t, err := s.PDU.Temperature()
+	t, err := s.cp.TemperatureContext(ctx)
 	if err != nil {
-		return api.Temperature500JSONResponse{
-			Error: err.Error(),
-		}, nil
+		return nil, err
 	}
 
 	return api.Temperature200JSONResponse{
@@ -132,14 +481,28 @@ func (s *Server) Temperature(ctx context.Context, request api.TemperatureRequest
 	}, nil
 }
 
+// Get diagnostics of the PDU connection and poller
+// (GET /diagnostics)
+func (s *Server) Diagnostics(ctx context.Context, request api.DiagnosticsRequestObject) (api.DiagnosticsResponseObject, error) {
+	dp, ok := s.PDU.(DiagnosticsPDU)
+	if !ok {
+		return nil, fmt.Errorf("diagnostics are %w", ErrNotEnabled)
+	}
+
+	d, err := dp.Diagnostics()
+	if err != nil {
+		return nil, err
+	}
+
+	return api.Diagnostics200JSONResponse(*d), nil
+}
+
 // Get current user
 // (GET /whoami)
 func (s *Server) WhoAmI(ctx context.Context, request api.WhoAmIRequestObject) (api.WhoAmIResponseObject, error) {
-	u, err := s.PDU.WhoAmI()
+	u, err := s.cp.WhoAmIContext(ctx)
 	if err != nil {
-		return api.WhoAmI500JSONResponse{
-			Error: err.Error(),
-		}, nil
+		return nil, err
 	}
 
 	return api.WhoAmI200JSONResponse{
@@ -150,75 +513,425 @@ func (s *Server) WhoAmI(ctx context.Context, request api.WhoAmIRequestObject) (a
 // Clear peak RMS current
 // (POST /clear)
 func (s *Server) ClearMaximumCurrents(ctx context.Context, request api.ClearMaximumCurrentsRequestObject) (api.ClearMaximumCurrentsResponseObject, error) {
-	if err := s.PDU.ClearMaximumCurrents(); err != nil {
-		return &api.ClearMaximumCurrents500JSONResponse{
-			Error: err.Error(),
-		}, nil
+	if err := s.client(ctx).ClearMaximumCurrentsContext(ctx); err != nil {
+		return nil, err
 	}
 
 	return api.ClearMaximumCurrents200Response{}, nil
 }
 
-// Switch lock state of outlet
-// (POST /outlet/{id}/lock)
-func (s *Server) LockOutlet(ctx context.Context, request api.LockOutletRequestObject) (api.LockOutletResponseObject, error) {
-	if err := s.PDU.LockOutlet(request.Id, *request.Body); err != nil {
-		if errors.Is(err, ErrNotFound) {
-			return &api.LockOutlet404JSONResponse{
-				Error: err.Error(),
-			}, nil
+// authorize authenticates the client and checks the ACL for the operation and the outlets of the request.
+// The returned context holds the identity of the client.
+func (s *Server) authorize(ctx context.Context, w http.ResponseWriter, r *http.Request, operationID string, request any) (context.Context, error) {
+	// The ACL may be replaced by a reload of the configuration
+	acc := s.cfg.Access()
+	if !acc.Enabled() {
+		return ctx, nil
+	}
+
+	identity, err := acc.Auth.Authenticate(r)
+	if err != nil {
+		for _, challenge := range acc.Auth.Challenges() {
+			w.Header().Add("WWW-Authenticate", challenge)
 		}
 
-		return &api.LockOutlet500JSONResponse{
-			Error: err.Error(),
-		}, nil
+		return ctx, err
+	}
+
+	ctx = context.WithValue(ctx, contextKeyIdentity, identity)
+
+	req := s.accessRequest(ctx, operationID)
+
+	outletID := api.OutletIDFromRequest(request)
+	if outletID == "" {
+		return ctx, acc.ACL.Check(req)
+	}
+
+	// Check access for each of the selected outlets by its ID, name, group, breaker or tag
+	outlets, err := s.resolveOutlets(ctx, outletID)
+	if err != nil {
+		return ctx, outletError(outletID, err)
+	}
+
+	if acc.ACL.Scoped() {
+		if req.Status, err = s.cp.StatusContext(ctx, true); err != nil {
+			return ctx, err
+		}
 	}
 
+	return ctx, acc.ACL.CheckOutlets(req, outlets)
+}
+
+// client returns the PDU which records the commands of the client of the request in the audit log.
+func (s *Server) client(ctx context.Context) *AuditedPDU {
+	identity, _ := ctx.Value(contextKeyIdentity).(*Identity)
+	remoteAddress, _ := ctx.Value(contextKeyRemoteAddress).(string)
+
+	return s.audited.WithClient(identity, remoteAddress)
+}
+
+// accessRequest returns the request of the client authenticated by mwAuth for checking an operation against the ACL.
+func (s *Server) accessRequest(ctx context.Context, operation string) *AccessRequest {
+	identity, _ := ctx.Value(contextKeyIdentity).(*Identity)
+
+	return &AccessRequest{
+		Identity:  identity,
+		PDU:       s.name,
+		Operation: operation,
+		Time:      time.Now(),
+		Aliases:   s.aliases,
+	}
+}
+
+// checkOutlets checks the access of the client to all operations for the outlets selected by an expression.
+// It is used for outlets which are passed in the body of a request and not checked by mwAuth.
+func (s *Server) checkOutlets(ctx context.Context, id string, operations ...string) error {
+	acc := s.cfg.Access()
+	if !acc.Enabled() {
+		return nil
+	}
+
+	outlets, err := s.resolveOutlets(ctx, id)
+	if err != nil {
+		return outletError(id, err)
+	}
+
+	var sts *Status
+	if acc.ACL.Scoped() {
+		if sts, err = s.cp.StatusContext(ctx, true); err != nil {
+			return err
+		}
+	}
+
+	for _, op := range operations {
+		req := s.accessRequest(ctx, op)
+		req.Status = sts
+
+		if err := acc.ACL.CheckOutlets(req, outlets); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveOutlets expands aliases and resolves an outlet expression into the selected outlets.
+func (s *Server) resolveOutlets(ctx context.Context, id string) ([]OutletStatus, error) {
+	id, err := ExpandAliases(id, s.aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.cp.StatusOutletsContext(ctx, id)
+}
+
+// Get status of outlets
+// (GET /outlet/{id})
+func (s *Server) StatusOutlet(ctx context.Context, request api.StatusOutletRequestObject) (api.StatusOutletResponseObject, error) {
+	outlets, err := s.resolveOutlets(ctx, request.Id)
+	if err != nil {
+		return nil, outletError(request.Id, err)
+	}
+
+	return api.StatusOutlet200JSONResponse(outlets), nil
+}
+
+// Switch lock state of outlet
+// (POST /outlet/{id}/lock)
+func (s *Server) LockOutlet(ctx context.Context, request api.LockOutletRequestObject) (api.LockOutletResponseObject, error) {
 	if request.Body == nil {
-		return &api.LockOutlet400JSONResponse{
-			ErrorJSONResponse: api.ErrorJSONResponse{
-				Error: "Missing request body",
-			},
-		}, nil
+		return nil, fmt.Errorf("%w: missing request body", ErrInvalidRequest)
 	}
 
-	if err := s.PDU.LockOutlet(request.Id, *request.Body); err != nil {
-		return &api.LockOutlet500JSONResponse{
-			Error: err.Error(),
-		}, nil
+	id, err := ExpandAliases(request.Id, s.aliases)
+	if err != nil {
+		return nil, outletError(request.Id, err)
+	}
+
+	results, err := s.client(ctx).LockOutletContext(ctx, id, *request.Body)
+	if err != nil && len(results) == 0 {
+		return nil, outletError(request.Id, err)
 	}
 
-	return api.LockOutlet200Response{}, nil
+	return api.LockOutlet200JSONResponse(results), nil
 }
 
 // Reboot the outlet
 // (POST /outlet/{id}/reboot)
 func (s *Server) RebootOutlet(ctx context.Context, request api.RebootOutletRequestObject) (api.RebootOutletResponseObject, error) {
-	if err := s.PDU.RebootOutlet(request.Id); err != nil {
-		return &api.RebootOutlet500JSONResponse{
-			Error: err.Error(),
-		}, nil
+	id, err := ExpandAliases(request.Id, s.aliases)
+	if err != nil {
+		return nil, outletError(request.Id, err)
 	}
 
-	return api.RebootOutlet200Response{}, nil
+	results, err := s.client(ctx).RebootOutletContext(ctx, id)
+	if err != nil && len(results) == 0 {
+		return nil, outletError(request.Id, err)
+	}
+
+	return api.RebootOutlet200JSONResponse(results), nil
 }
 
 // Switch state of outlet
 // (POST /outlet/{id}/state)
 func (s *Server) SwitchOutlet(ctx context.Context, request api.SwitchOutletRequestObject) (api.SwitchOutletResponseObject, error) {
 	if request.Body == nil {
-		return &api.SwitchOutlet400JSONResponse{
-			ErrorJSONResponse: api.ErrorJSONResponse{
-				Error: "Missing request body",
-			},
-		}, nil
+		return nil, fmt.Errorf("%w: missing request body", ErrInvalidRequest)
+	}
+
+	id, err := ExpandAliases(request.Id, s.aliases)
+	if err != nil {
+		return nil, outletError(request.Id, err)
+	}
+
+	results, err := s.client(ctx).SwitchOutletContext(ctx, id, *request.Body)
+	if err != nil && len(results) == 0 {
+		return nil, outletError(request.Id, err)
+	}
+
+	return api.SwitchOutlet200JSONResponse(results), nil
+}
+
+// List user accounts of the PDU
+// (GET /users)
+func (s *Server) ListUsers(ctx context.Context, request api.ListUsersRequestObject) (api.ListUsersResponseObject, error) {
+	users, err := s.cp.UsersContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ListUsers200JSONResponse(users), nil
+}
+
+// Add a user account to the PDU
+// (POST /users)
+func (s *Server) AddUser(ctx context.Context, request api.AddUserRequestObject) (api.AddUserResponseObject, error) {
+	if request.Body == nil {
+		return nil, fmt.Errorf("%w: missing request body", ErrInvalidRequest)
+	}
+
+	if o := request.Body.Outlets; o != nil {
+		if err := s.checkOutlets(ctx, *o, "add-user", "switch-outlet"); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.cp.AddUserContext(ctx, request.Body.Name, request.Body.Password); err != nil {
+		return nil, err
+	}
+
+	if o := request.Body.Outlets; o != nil {
+		id, err := ExpandAliases(*o, s.aliases)
+		if err == nil {
+			err = s.cp.SetUserOutletsContext(ctx, request.Body.Name, id)
+		}
+
+		if err != nil {
+			return nil, outletError(*o, err)
+		}
+	}
+
+	return api.AddUser200Response{}, nil
+}
+
+// Remove a user account from the PDU
+// (DELETE /user/{name})
+func (s *Server) DeleteUser(ctx context.Context, request api.DeleteUserRequestObject) (api.DeleteUserResponseObject, error) {
+	if err := s.cp.DeleteUserContext(ctx, request.Name); err != nil {
+		return nil, err
+	}
+
+	return api.DeleteUser200Response{}, nil
+}
+
+// Change the password of a user
+// (POST /user/{name}/password)
+func (s *Server) ChangePassword(ctx context.Context, request api.ChangePasswordRequestObject) (api.ChangePasswordResponseObject, error) {
+	if request.Body == nil {
+		return nil, fmt.Errorf("%w: missing request body", ErrInvalidRequest)
+	}
+
+	if err := s.cp.ChangePasswordContext(ctx, request.Name, *request.Body); err != nil {
+		return nil, err
+	}
+
+	return api.ChangePassword200Response{}, nil
+}
+
+// Set the outlets which a user is allowed to control
+// (POST /user/{name}/outlets)
+func (s *Server) SetUserOutlets(ctx context.Context, request api.SetUserOutletsRequestObject) (api.SetUserOutletsResponseObject, error) {
+	if request.Body == nil {
+		return nil, fmt.Errorf("%w: missing request body", ErrInvalidRequest)
+	}
+
+	if err := s.checkOutlets(ctx, *request.Body, "set-user-outlets", "switch-outlet"); err != nil {
+		return nil, err
+	}
+
+	id, err := ExpandAliases(*request.Body, s.aliases)
+	if err == nil {
+		err = s.cp.SetUserOutletsContext(ctx, request.Name, id)
+	}
+
+	if err != nil {
+		return nil, outletError(*request.Body, err)
+	}
+
+	return api.SetUserOutlets200Response{}, nil
+}
+
+// List scheduled outlet actions
+// (GET /schedules)
+func (s *Server) ListSchedules(ctx context.Context, request api.ListSchedulesRequestObject) (api.ListSchedulesResponseObject, error) {
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("scheduler is %w", ErrNotEnabled)
+	}
+
+	schedules, err := s.scheduler.Schedules()
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ListSchedules200JSONResponse(schedules), nil
+}
+
+// Add a scheduled outlet action
+// (POST /schedules)
+func (s *Server) AddSchedule(ctx context.Context, request api.AddScheduleRequestObject) (api.AddScheduleResponseObject, error) {
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("scheduler is %w", ErrNotEnabled)
+	}
+
+	if request.Body == nil {
+		return nil, fmt.Errorf("%w: missing request body", ErrInvalidRequest)
+	}
+
+	var createdBy string
+	if identity, ok := ctx.Value(contextKeyIdentity).(*Identity); ok {
+		createdBy = identity.Name
+	}
+
+	sch, err := s.scheduler.AddSchedule(*request.Body, createdBy)
+	if err != nil {
+		return nil, outletError(request.Body.Outlets, err)
+	}
+
+	return api.AddSchedule200JSONResponse(*sch), nil
+}
+
+// Remove a scheduled outlet action
+// (DELETE /schedule/{sid})
+func (s *Server) DeleteSchedule(ctx context.Context, request api.DeleteScheduleRequestObject) (api.DeleteScheduleResponseObject, error) {
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("scheduler is %w", ErrNotEnabled)
+	}
+
+	if err := s.scheduler.DeleteSchedule(request.Sid); err != nil {
+		return nil, err
+	}
+
+	return api.DeleteSchedule200Response{}, nil
+}
+
+// List executed runs of scheduled outlet actions
+// (GET /schedules/runs)
+func (s *Server) ListScheduleRuns(ctx context.Context, request api.ListScheduleRunsRequestObject) (api.ListScheduleRunsResponseObject, error) {
+	if s.scheduler == nil {
+		return nil, fmt.Errorf("scheduler is %w", ErrNotEnabled)
+	}
+
+	runs, err := s.scheduler.ScheduleRuns()
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.PDU.SwitchOutlet(request.Id, *request.Body); err != nil {
-		return &api.SwitchOutlet500JSONResponse{
-			Error: err.Error(),
-		}, nil
+	return api.ListScheduleRuns200JSONResponse(runs), nil
+}
+
+// List power sequences
+// (GET /sequences)
+func (s *Server) ListSequences(ctx context.Context, request api.ListSequencesRequestObject) (api.ListSequencesResponseObject, error) {
+	if s.sequencer == nil {
+		return nil, fmt.Errorf("sequences are %w", ErrNotEnabled)
+	}
+
+	seqs, err := s.sequencer.Sequences()
+	if err != nil {
+		return nil, err
+	}
+
+	return api.ListSequences200JSONResponse(seqs), nil
+}
+
+// Get a power sequence and the progress of its last run
+// (GET /sequences/{seq})
+func (s *Server) GetSequence(ctx context.Context, request api.GetSequenceRequestObject) (api.GetSequenceResponseObject, error) {
+	if s.sequencer == nil {
+		return nil, fmt.Errorf("sequences are %w", ErrNotEnabled)
+	}
+
+	seq, err := s.sequencer.Sequence(request.Seq)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.GetSequence200JSONResponse(*seq), nil
+}
+
+// Run a power sequence
+// (POST /sequences/{seq}/run)
+func (s *Server) RunSequence(ctx context.Context, request api.RunSequenceRequestObject) (api.RunSequenceResponseObject, error) {
+	if s.sequencer == nil {
+		return nil, fmt.Errorf("sequences are %w", ErrNotEnabled)
+	}
+
+	if request.Body == nil {
+		return nil, fmt.Errorf("%w: missing request body", ErrInvalidRequest)
+	}
+
+	seq, err := s.sequencer.Sequence(request.Seq)
+	if err != nil {
+		return nil, err
+	}
+
+	// The client must be permitted to switch the outlets of all steps
+	for _, step := range seq.Steps {
+		if err := s.checkOutlets(ctx, step.Outlets, "switch-outlet"); err != nil {
+			return nil, err
+		}
+	}
+
+	run, err := s.sequencer.RunSequence(request.Seq, *request.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return api.RunSequence200JSONResponse(*run), nil
+}
+
+// List active alerts
+// (GET /alerts)
+func (s *Server) ListAlerts(ctx context.Context, request api.ListAlertsRequestObject) (api.ListAlertsResponseObject, error) {
+	if s.alerter == nil {
+		return nil, fmt.Errorf("alerts are %w", ErrNotEnabled)
+	}
+
+	alerts, err := s.alerter.Alerts()
+	if err != nil {
+		return nil, err
+	}
+
+	// Clients only receive the alerts of outlets whose status they may query
+	if f := s.outletFilter(ctx); f != nil {
+		if f.req.Status, err = s.cp.StatusContext(ctx, true); err != nil {
+			return nil, err
+		}
+
+		alerts = slices.DeleteFunc(alerts, func(a Alert) bool {
+			return !f.visibleAlert(&a)
+		})
 	}
 
-	return api.SwitchOutlet200Response{}, nil
+	return api.ListAlerts200JSONResponse(alerts), nil
 }
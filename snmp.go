@@ -0,0 +1,887 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// Object identifiers of the PDUD-MIB (see mibs/PDUD-MIB.txt).
+// Tables are indexed by the position of the PDU in the configuration
+// and the ID of the breaker, group or outlet.
+const (
+	oidPdudMIB      = ".1.3.6.1.3.7707"
+	oidPduTable     = oidPdudMIB + ".1.1.1"
+	oidBreakerTable = oidPdudMIB + ".1.2.1"
+	oidGroupTable   = oidPdudMIB + ".1.3.1"
+	oidOutletTable  = oidPdudMIB + ".1.4.1"
+
+	oidSysDescr    = ".1.3.6.1.2.1.1.1.0"
+	oidSysObjectID = ".1.3.6.1.2.1.1.2.0"
+	oidSysUpTime   = ".1.3.6.1.2.1.1.3.0"
+	oidSysName     = ".1.3.6.1.2.1.1.5.0"
+
+	oidUsmStatsUnsupportedSecLevels = ".1.3.6.1.6.3.15.1.1.1.0"
+	oidUsmStatsNotInTimeWindows     = ".1.3.6.1.6.3.15.1.1.2.0"
+	oidUsmStatsUnknownUserNames     = ".1.3.6.1.6.3.15.1.1.3.0"
+	oidUsmStatsUnknownEngineIDs     = ".1.3.6.1.6.3.15.1.1.4.0"
+)
+
+// Columns of the outlet table
+const (
+	outletName = iota + 2
+	outletState
+	outletLocked
+	outletGroupIndex
+	outletBreakerIndex
+	outletCurrent
+	outletPeakCurrent
+	outletVoltage
+	outletPower
+	outletAveragePower
+	outletEnergy
+)
+
+// Values of the outletState column
+const (
+	snmpOutletOff    = 1
+	snmpOutletOn     = 2
+	snmpOutletReboot = 3
+)
+
+const (
+	// Maximum number of variable bindings in a response to a GETBULK request
+	snmpMaxBulkVarbinds = 256
+
+	// Maximum difference between the engine time of a request and the agent (RFC 3414)
+	snmpTimeWindow = 150
+
+	snmpMaxPacketSize = 65535
+)
+
+var ErrInvalidSNMPConfig = errors.New("invalid SNMP configuration")
+
+var oidOutletStateColumn = mustParseOID(oidOutletTable + "." + strconv.Itoa(outletState))
+
+var snmpAuthProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"":       gosnmp.NoAuth,
+	"md5":    gosnmp.MD5,
+	"sha":    gosnmp.SHA,
+	"sha224": gosnmp.SHA224,
+	"sha256": gosnmp.SHA256,
+	"sha384": gosnmp.SHA384,
+	"sha512": gosnmp.SHA512,
+}
+
+var snmpPrivProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"":        gosnmp.NoPriv,
+	"des":     gosnmp.DES,
+	"aes":     gosnmp.AES,
+	"aes192":  gosnmp.AES192,
+	"aes256":  gosnmp.AES256,
+	"aes192c": gosnmp.AES192C,
+	"aes256c": gosnmp.AES256C,
+}
+
+type snmpPDU struct {
+	name    string
+	pdu     PDU
+	audited *AuditedPDU
+	aliases map[string]string
+}
+
+type snmpUser struct {
+	*SNMPUserConfig
+
+	flags  gosnmp.SnmpV3MsgFlags
+	params *gosnmp.UsmSecurityParameters
+}
+
+// snmpVar is a single object instance served by the agent.
+type snmpVar struct {
+	oid []int
+	gosnmp.SnmpPDU
+}
+
+// SNMPAgent serves the status of PDUs via SNMPv2c and SNMPv3.
+// Outlets can be switched by setting the outletState column.
+type SNMPAgent struct {
+	cfg *SNMPConfig
+
+	pdus  []snmpPDU
+	users map[string]*snmpUser
+	usm   *gosnmp.GoSNMP
+
+	engineID    string
+	engineBoots uint32
+	started     time.Time
+
+	unsupportedSecLevels atomic.Uint32
+	notInTimeWindows     atomic.Uint32
+	unknownUserNames     atomic.Uint32
+	unknownEngineIDs     atomic.Uint32
+
+	conn *net.UDPConn
+	wg   sync.WaitGroup
+}
+
+// NewSNMPAgent creates an agent and validates the SNMPv3 users.
+func NewSNMPAgent(cfg *SNMPConfig) (*SNMPAgent, error) {
+	a := &SNMPAgent{
+		cfg:     cfg,
+		users:   map[string]*snmpUser{},
+		started: time.Now(),
+
+		// Engine boots need to increase after each restart
+		engineBoots: uint32(time.Now().Unix()),
+	}
+
+	if cfg.EngineID != "" {
+		id, err := hex.DecodeString(cfg.EngineID)
+		if err != nil || len(id) < 5 || len(id) > 32 {
+			return nil, fmt.Errorf("%w: engine ID must be 5 to 32 hex-encoded bytes", ErrInvalidSNMPConfig)
+		}
+
+		a.engineID = string(id)
+	} else {
+		// RFC 3411 engine ID in text format
+		hostname, _ := os.Hostname()
+		a.engineID = "\x80\x00\x00\x00\x04" + ("pdud@" + hostname)[:min(len(hostname)+5, 27)]
+	}
+
+	a.usm = &gosnmp.GoSNMP{
+		Version:                     gosnmp.Version3,
+		SecurityModel:               gosnmp.UserSecurityModel,
+		TrapSecurityParametersTable: gosnmp.NewSnmpV3SecurityParametersTable(gosnmp.Logger{}),
+	}
+
+	// Discovery requests are sent without user and authentication
+	if err := a.usm.TrapSecurityParametersTable.Add("", &gosnmp.UsmSecurityParameters{
+		AuthoritativeEngineID:  a.engineID,
+		AuthenticationProtocol: gosnmp.NoAuth,
+		PrivacyProtocol:        gosnmp.NoPriv,
+	}); err != nil {
+		return nil, err
+	}
+
+	for i := range cfg.Users {
+		uc := &cfg.Users[i]
+
+		if uc.Name == "" {
+			return nil, fmt.Errorf("%w: missing user name", ErrInvalidSNMPConfig)
+		} else if _, ok := a.users[uc.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate user: %s", ErrInvalidSNMPConfig, uc.Name)
+		}
+
+		authProto, ok := snmpAuthProtocols[strings.ToLower(uc.AuthProtocol)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown authentication protocol: %s", ErrInvalidSNMPConfig, uc.AuthProtocol)
+		}
+
+		privProto, ok := snmpPrivProtocols[strings.ToLower(uc.PrivProtocol)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown privacy protocol: %s", ErrInvalidSNMPConfig, uc.PrivProtocol)
+		}
+
+		u := &snmpUser{
+			SNMPUserConfig: uc,
+			flags:          gosnmp.NoAuthNoPriv,
+			params: &gosnmp.UsmSecurityParameters{
+				AuthoritativeEngineID:    a.engineID,
+				UserName:                 uc.Name,
+				AuthenticationProtocol:   authProto,
+				AuthenticationPassphrase: uc.AuthPassphrase,
+				PrivacyProtocol:          privProto,
+				PrivacyPassphrase:        uc.PrivPassphrase,
+			},
+		}
+
+		if authProto != gosnmp.NoAuth {
+			u.flags = gosnmp.AuthNoPriv
+
+			if len(uc.AuthPassphrase) < 8 {
+				return nil, fmt.Errorf("%w: authentication passphrase of user %s must have at least 8 characters", ErrInvalidSNMPConfig, uc.Name)
+			}
+		}
+
+		if privProto != gosnmp.NoPriv {
+			if authProto == gosnmp.NoAuth {
+				return nil, fmt.Errorf("%w: privacy requires authentication for user %s", ErrInvalidSNMPConfig, uc.Name)
+			} else if len(uc.PrivPassphrase) < 8 {
+				return nil, fmt.Errorf("%w: privacy passphrase of user %s must have at least 8 characters", ErrInvalidSNMPConfig, uc.Name)
+			}
+
+			u.flags = gosnmp.AuthPriv
+		}
+
+		if err := a.usm.TrapSecurityParametersTable.Add(uc.Name, u.params); err != nil {
+			return nil, fmt.Errorf("failed to add SNMP user %s: %w", uc.Name, err)
+		}
+
+		a.users[uc.Name] = u
+	}
+
+	for i := range cfg.Outlets {
+		if err := cfg.Outlets[i].init(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return a, nil
+}
+
+// AddPDU adds a PDU to the tables of the agent.
+// PDUs are indexed in the order in which they have been added.
+// Outlet commands are recorded in the audit log.
+func (a *SNMPAgent) AddPDU(pc *PDUConfig, p PDU, audit *AuditLog) {
+	a.pdus = append(a.pdus, snmpPDU{
+		name:    pc.Name,
+		pdu:     p,
+		audited: NewAuditedPDU(audit, pc.Name, p, AuditSourceSNMP),
+		aliases: pc.Aliases,
+	})
+}
+
+// Start listens for requests in the background.
+func (a *SNMPAgent) Start() (err error) {
+	addr, err := net.ResolveUDPAddr("udp", a.cfg.Listen)
+	if err != nil {
+		return fmt.Errorf("failed to resolve SNMP listen address: %w", err)
+	}
+
+	if a.conn, err = net.ListenUDP("udp", addr); err != nil {
+		return fmt.Errorf("failed to listen for SNMP requests: %w", err)
+	}
+
+	slog.Info("SNMP agent listening", slog.String("address", a.conn.LocalAddr().String()))
+
+	a.wg.Add(1)
+	go a.serve()
+
+	return nil
+}
+
+// Close stops the agent and waits for pending requests.
+func (a *SNMPAgent) Close() error {
+	if a.conn == nil {
+		return nil
+	}
+
+	err := a.conn.Close()
+	a.wg.Wait()
+
+	return err
+}
+
+func (a *SNMPAgent) serve() {
+	defer a.wg.Done()
+
+	for {
+		buf := make([]byte, snmpMaxPacketSize)
+
+		n, remote, err := a.conn.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+
+			slog.Error("Failed to receive SNMP request", slog.Any("error", err))
+			continue
+		}
+
+		// Do not block other requests while the PDU is busy switching outlets
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+
+			a.handle(buf[:n], remote)
+		}()
+	}
+}
+
+func (a *SNMPAgent) handle(msg []byte, remote *net.UDPAddr) {
+	// The decoder is not hardened against malformed packets
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Debug("Dropped malformed SNMP request", slog.String("remote", remote.String()), slog.Any("error", r))
+		}
+	}()
+
+	resp := a.handleRequest(msg, remote)
+	if resp == nil {
+		return
+	}
+
+	out, err := resp.MarshalMsg()
+	if err != nil {
+		slog.Error("Failed to encode SNMP response", slog.Any("error", err))
+		return
+	}
+
+	if _, err := a.conn.WriteToUDP(out, remote); err != nil {
+		slog.Error("Failed to send SNMP response", slog.String("remote", remote.String()), slog.Any("error", err))
+	}
+}
+
+func (a *SNMPAgent) handleRequest(msg []byte, remote *net.UDPAddr) *gosnmp.SnmpPacket {
+	switch version := snmpVersion(msg); version {
+	case gosnmp.Version2c:
+		return a.handleV2c(msg, remote)
+
+	case gosnmp.Version3:
+		return a.handleV3(msg, remote)
+
+	default:
+		slog.Debug("Dropped SNMP request with unsupported version", slog.String("remote", remote.String()), slog.Int("version", int(version)))
+		return nil
+	}
+}
+
+func (a *SNMPAgent) handleV2c(msg []byte, remote *net.UDPAddr) *gosnmp.SnmpPacket {
+	v2c := &gosnmp.GoSNMP{
+		Version: gosnmp.Version2c,
+	}
+
+	req, err := v2c.UnmarshalTrap(msg, false)
+	if err != nil {
+		slog.Debug("Dropped invalid SNMP request", slog.String("remote", remote.String()), slog.Any("error", err))
+		return nil
+	}
+
+	var write bool
+
+	switch {
+	case a.cfg.WriteCommunity != "" && req.Community == a.cfg.WriteCommunity:
+		write = true
+
+	case a.cfg.Community != "" && req.Community == a.cfg.Community:
+		write = false
+
+	default:
+		slog.Debug("Dropped SNMP request with unknown community", slog.String("remote", remote.String()))
+		return nil
+	}
+
+	resp := a.respond(req, write, remote)
+	resp.Version = gosnmp.Version2c
+	resp.Community = req.Community
+
+	return resp
+}
+
+func (a *SNMPAgent) handleV3(msg []byte, remote *net.UDPAddr) *gosnmp.SnmpPacket {
+	req, err := a.usm.UnmarshalTrap(msg, true)
+	if err != nil {
+		slog.Debug("Dropped invalid SNMPv3 request", slog.String("remote", remote.String()), slog.Any("error", err))
+		return nil
+	}
+
+	sp, ok := req.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+	if !ok {
+		return nil
+	}
+
+	if sp.AuthoritativeEngineID != a.engineID {
+		return a.report(req, nil, oidUsmStatsUnknownEngineIDs, &a.unknownEngineIDs)
+	}
+
+	u, ok := a.users[sp.UserName]
+	if !ok {
+		return a.report(req, nil, oidUsmStatsUnknownUserNames, &a.unknownUserNames)
+	}
+
+	if req.MsgFlags&gosnmp.AuthPriv != u.flags {
+		return a.report(req, nil, oidUsmStatsUnsupportedSecLevels, &a.unsupportedSecLevels)
+	}
+
+	if u.flags&gosnmp.AuthNoPriv != 0 {
+		boots, engineTime := a.engineTime()
+		if sp.AuthoritativeEngineBoots != boots || max(sp.AuthoritativeEngineTime, engineTime)-min(sp.AuthoritativeEngineTime, engineTime) > snmpTimeWindow {
+			return a.report(req, u, oidUsmStatsNotInTimeWindows, &a.notInTimeWindows)
+		}
+	}
+
+	resp := a.respond(req, u.Write, remote)
+	resp.Version = gosnmp.Version3
+	resp.MsgFlags = u.flags
+	resp.MsgID = req.MsgID
+	resp.SecurityModel = gosnmp.UserSecurityModel
+	resp.ContextEngineID = a.engineID
+	resp.ContextName = req.ContextName
+	resp.SecurityParameters = a.securityParameters(u)
+
+	if err := u.params.InitPacket(resp); err != nil {
+		slog.Error("Failed to initialize SNMP response", slog.Any("error", err))
+		return nil
+	}
+
+	return resp
+}
+
+// report returns a report PDU for requests which failed the checks of the user-based security model.
+// Reports are authenticated with the credentials of the user if given.
+func (a *SNMPAgent) report(req *gosnmp.SnmpPacket, u *snmpUser, oid string, counter *atomic.Uint32) *gosnmp.SnmpPacket {
+	cnt := counter.Add(1)
+
+	if req.MsgFlags&gosnmp.Reportable == 0 {
+		return nil
+	}
+
+	var flags gosnmp.SnmpV3MsgFlags
+	var sp *gosnmp.UsmSecurityParameters
+	if u != nil {
+		flags = gosnmp.AuthNoPriv
+		sp = a.securityParameters(u)
+	} else {
+		flags = gosnmp.NoAuthNoPriv
+		sp = &gosnmp.UsmSecurityParameters{
+			AuthoritativeEngineID:  a.engineID,
+			AuthenticationProtocol: gosnmp.NoAuth,
+			PrivacyProtocol:        gosnmp.NoPriv,
+		}
+		sp.AuthoritativeEngineBoots, sp.AuthoritativeEngineTime = a.engineTime()
+
+		if req.SecurityParameters != nil {
+			if rsp, ok := req.SecurityParameters.(*gosnmp.UsmSecurityParameters); ok {
+				sp.UserName = rsp.UserName
+			}
+		}
+	}
+
+	return &gosnmp.SnmpPacket{
+		Version:            gosnmp.Version3,
+		MsgFlags:           flags,
+		MsgID:              req.MsgID,
+		SecurityModel:      gosnmp.UserSecurityModel,
+		SecurityParameters: sp,
+		ContextEngineID:    a.engineID,
+		ContextName:        req.ContextName,
+		PDUType:            gosnmp.Report,
+		RequestID:          req.RequestID,
+		Variables: []gosnmp.SnmpPDU{
+			{
+				Name:  oid,
+				Type:  gosnmp.Counter32,
+				Value: cnt,
+			},
+		},
+	}
+}
+
+// securityParameters returns the parameters for authenticating and encrypting a message to the user.
+func (a *SNMPAgent) securityParameters(u *snmpUser) *gosnmp.UsmSecurityParameters {
+	sp := u.params.Copy().(*gosnmp.UsmSecurityParameters) //nolint:forcetypeassert
+	sp.AuthoritativeEngineBoots, sp.AuthoritativeEngineTime = a.engineTime()
+
+	return sp
+}
+
+func (a *SNMPAgent) engineTime() (boots, secs uint32) {
+	return a.engineBoots, uint32(time.Since(a.started).Seconds())
+}
+
+// respond processes the variable bindings of a request.
+func (a *SNMPAgent) respond(req *gosnmp.SnmpPacket, write bool, remote *net.UDPAddr) *gosnmp.SnmpPacket {
+	resp := &gosnmp.SnmpPacket{
+		PDUType:   gosnmp.GetResponse,
+		RequestID: req.RequestID,
+	}
+
+	vars := a.vars()
+
+	switch req.PDUType {
+	case gosnmp.GetRequest:
+		for _, v := range req.Variables {
+			resp.Variables = append(resp.Variables, get(vars, v.Name))
+		}
+
+	case gosnmp.GetNextRequest:
+		for _, v := range req.Variables {
+			resp.Variables = append(resp.Variables, getNext(vars, v.Name))
+		}
+
+	case gosnmp.GetBulkRequest:
+		nonRepeaters := min(int(req.NonRepeaters), len(req.Variables))
+
+		for _, v := range req.Variables[:nonRepeaters] {
+			resp.Variables = append(resp.Variables, getNext(vars, v.Name))
+		}
+
+		repeaters := req.Variables[nonRepeaters:]
+		names := []string{}
+		for _, v := range repeaters {
+			names = append(names, v.Name)
+		}
+
+		for r := 0; r < int(req.MaxRepetitions) && len(names) > 0; r++ {
+			if len(resp.Variables)+len(names) > snmpMaxBulkVarbinds {
+				break
+			}
+
+			done := true
+			for i, name := range names {
+				v := getNext(vars, name)
+				resp.Variables = append(resp.Variables, v)
+				names[i] = v.Name
+
+				if v.Type != gosnmp.EndOfMibView {
+					done = false
+				}
+			}
+
+			if done {
+				break
+			}
+		}
+
+	case gosnmp.SetRequest:
+		resp.Variables = req.Variables
+		resp.Error, resp.ErrorIndex = a.set(vars, req.Variables, write, remote)
+
+	default:
+		resp.Variables = req.Variables
+		resp.Error = gosnmp.GenErr
+	}
+
+	return resp
+}
+
+// set switches outlets by their outletState column.
+// All variable bindings are checked before any outlet is switched.
+func (a *SNMPAgent) set(vars []snmpVar, reqVars []gosnmp.SnmpPDU, write bool, remote *net.UDPAddr) (gosnmp.SNMPError, uint8) {
+	type change struct {
+		pdu    snmpPDU
+		outlet string
+		value  int
+	}
+
+	changes := []change{}
+
+	for i, rv := range reqVars {
+		idx := uint8(i + 1)
+
+		if !write {
+			return gosnmp.NoAccess, idx
+		}
+
+		oid, err := parseOID(rv.Name)
+		if err != nil {
+			return gosnmp.NoCreation, idx
+		}
+
+		column := oidOutletStateColumn
+		if len(oid) != len(column)+2 || !slices.Equal(oid[:len(column)], column) {
+			if _, found := slices.BinarySearchFunc(vars, oid, compareVar); found {
+				return gosnmp.NotWritable, idx
+			}
+
+			return gosnmp.NoCreation, idx
+		}
+
+		pduIndex, outletID := oid[len(column)], oid[len(column)+1]
+		if pduIndex < 1 || pduIndex > len(a.pdus) {
+			return gosnmp.NoCreation, idx
+		}
+
+		p := a.pdus[pduIndex-1]
+
+		outlets, err := p.pdu.StatusOutlets(strconv.Itoa(outletID))
+		if err != nil || len(outlets) != 1 {
+			return gosnmp.NoCreation, idx
+		}
+
+		value, ok := rv.Value.(int)
+		if rv.Type != gosnmp.Integer || !ok {
+			return gosnmp.WrongType, idx
+		}
+
+		var operationID string
+		switch value {
+		case snmpOutletOn, snmpOutletOff:
+			operationID = "switch-outlet"
+		case snmpOutletReboot:
+			operationID = "reboot-outlet"
+		default:
+			return gosnmp.WrongValue, idx
+		}
+
+		if !a.allowed(p, operationID, &outlets[0]) {
+			slog.Warn("SNMP command denied", slog.String("pdu", p.name), slog.String("operation", operationID), slog.Int("outlet", outletID), slog.String("remote", remote.String()))
+			return gosnmp.NoAccess, idx
+		}
+
+		changes = append(changes, change{
+			pdu:    p,
+			outlet: strconv.Itoa(outletID),
+			value:  value,
+		})
+	}
+
+	for i, c := range changes {
+		slog.Info("SNMP command", slog.String("pdu", c.pdu.name), slog.String("outlet", c.outlet), slog.Int("value", c.value), slog.String("remote", remote.String()))
+
+		audited := c.pdu.audited.WithClient(nil, remote.String())
+
+		var err error
+		if c.value == snmpOutletReboot {
+			_, err = audited.RebootOutlet(c.outlet)
+		} else {
+			_, err = audited.SwitchOutlet(c.outlet, c.value == snmpOutletOn)
+		}
+
+		if err != nil {
+			slog.Error("Failed to run SNMP command", slog.String("pdu", c.pdu.name), slog.String("outlet", c.outlet), slog.Any("error", err))
+			return gosnmp.CommitFailed, uint8(i + 1)
+		}
+	}
+
+	return gosnmp.NoError, 0
+}
+
+// allowed checks whether the operation is permitted via SNMP for the outlet
+// by the same matching of IDs, names, groups, breakers and tags as the ACL.
+func (a *SNMPAgent) allowed(p snmpPDU, operationID string, o *OutletStatus) bool {
+	req := &AccessRequest{
+		PDU:       p.name,
+		Operation: operationID,
+		Time:      time.Now(),
+		Aliases:   p.aliases,
+	}
+
+	if scopedOutletRules(a.cfg.Outlets) {
+		sts, err := p.pdu.Status(true)
+		if err != nil {
+			return false
+		}
+
+		req.Status = sts
+	}
+
+	return permitsOutlet(a.cfg.Outlets, req, o)
+}
+
+// vars returns all object instances served by the agent in lexicographic order.
+func (a *SNMPAgent) vars() []snmpVar {
+	vars := []snmpVar{}
+
+	add := func(oid string, typ gosnmp.Asn1BER, value any) {
+		vars = append(vars, snmpVar{
+			oid: mustParseOID(oid),
+			SnmpPDU: gosnmp.SnmpPDU{
+				Name:  oid,
+				Type:  typ,
+				Value: value,
+			},
+		})
+	}
+
+	column := func(table string, col int, index ...int) string {
+		oid := table + "." + strconv.Itoa(col)
+		for _, i := range index {
+			oid += "." + strconv.Itoa(i)
+		}
+
+		return oid
+	}
+
+	hostname, _ := os.Hostname()
+
+	add(oidSysDescr, gosnmp.OctetString, "pdud - Baytech PDU controller")
+	add(oidSysObjectID, gosnmp.ObjectIdentifier, oidPdudMIB)
+	add(oidSysUpTime, gosnmp.TimeTicks, uint32(time.Since(a.started)/(10*time.Millisecond)))
+	add(oidSysName, gosnmp.OctetString, hostname)
+
+	for n, p := range a.pdus {
+		pi := n + 1
+
+		add(column(oidPduTable, 2, pi), gosnmp.OctetString, p.name)
+
+		if cp, ok := p.pdu.(ConnectionPDU); ok {
+			add(column(oidPduTable, 3, pi), gosnmp.Integer, connectionState(cp.Connection().State))
+		}
+
+		sts, err := p.pdu.Status(true)
+		if err != nil {
+			continue
+		}
+
+		add(column(oidPduTable, 4, pi), gosnmp.Integer, int(sts.Temperature*10))
+		add(column(oidPduTable, 5, pi), gosnmp.Counter64, wattHours(sts.TotalEnergy))
+		add(column(oidPduTable, 6, pi), gosnmp.Integer, len(sts.Outlets))
+
+		for _, b := range sts.Breakers {
+			bi := b.ID + 1
+
+			add(column(oidBreakerTable, 2, pi, bi), gosnmp.OctetString, b.Name)
+			add(column(oidBreakerTable, 3, pi, bi), gosnmp.Gauge32, milli(b.TrueRMSCurrent))
+			add(column(oidBreakerTable, 4, pi, bi), gosnmp.Gauge32, milli(b.PeakRMSCurrent))
+		}
+
+		for _, g := range sts.Groups {
+			gi := g.ID
+
+			add(column(oidGroupTable, 2, pi, gi), gosnmp.OctetString, g.Name)
+			add(column(oidGroupTable, 3, pi, gi), gosnmp.Integer, g.BreakerID+1)
+			add(column(oidGroupTable, 4, pi, gi), gosnmp.Gauge32, milli(g.TrueRMSCurrent))
+			add(column(oidGroupTable, 5, pi, gi), gosnmp.Gauge32, milli(g.PeakRMSCurrent))
+			add(column(oidGroupTable, 6, pi, gi), gosnmp.Gauge32, gauge(g.TrueRMSVoltage*10))
+			add(column(oidGroupTable, 7, pi, gi), gosnmp.Gauge32, gauge(g.Power))
+			add(column(oidGroupTable, 8, pi, gi), gosnmp.Gauge32, gauge(g.AveragePower))
+			add(column(oidGroupTable, 9, pi, gi), gosnmp.Counter64, wattHours(g.Energy))
+		}
+
+		for _, o := range sts.Outlets {
+			oi := o.ID
+
+			state := snmpOutletOff
+			if o.State {
+				state = snmpOutletOn
+			}
+
+			locked := 2 // TruthValue false
+			if o.Locked {
+				locked = 1
+			}
+
+			add(column(oidOutletTable, outletName, pi, oi), gosnmp.OctetString, o.Name)
+			add(column(oidOutletTable, outletState, pi, oi), gosnmp.Integer, state)
+			add(column(oidOutletTable, outletLocked, pi, oi), gosnmp.Integer, locked)
+			add(column(oidOutletTable, outletGroupIndex, pi, oi), gosnmp.Integer, o.GroupID)
+			add(column(oidOutletTable, outletBreakerIndex, pi, oi), gosnmp.Integer, o.BreakerID+1)
+			add(column(oidOutletTable, outletCurrent, pi, oi), gosnmp.Gauge32, milli(o.TrueRMSCurrent))
+			add(column(oidOutletTable, outletPeakCurrent, pi, oi), gosnmp.Gauge32, milli(o.PeakRMSCurrent))
+			add(column(oidOutletTable, outletVoltage, pi, oi), gosnmp.Gauge32, gauge(o.TrueRMSVoltage*10))
+			add(column(oidOutletTable, outletPower, pi, oi), gosnmp.Gauge32, gauge(o.Power))
+			add(column(oidOutletTable, outletAveragePower, pi, oi), gosnmp.Gauge32, gauge(o.AveragePower))
+			add(column(oidOutletTable, outletEnergy, pi, oi), gosnmp.Counter64, wattHours(o.Energy))
+		}
+	}
+
+	slices.SortFunc(vars, func(a, b snmpVar) int {
+		return slices.Compare(a.oid, b.oid)
+	})
+
+	return vars
+}
+
+func get(vars []snmpVar, name string) gosnmp.SnmpPDU {
+	oid, err := parseOID(name)
+	if err == nil {
+		if i, found := slices.BinarySearchFunc(vars, oid, compareVar); found {
+			return vars[i].SnmpPDU
+		}
+	}
+
+	typ := gosnmp.NoSuchObject
+	if strings.HasPrefix(name, oidPdudMIB+".") {
+		typ = gosnmp.NoSuchInstance
+	}
+
+	return gosnmp.SnmpPDU{
+		Name: name,
+		Type: typ,
+	}
+}
+
+func getNext(vars []snmpVar, name string) gosnmp.SnmpPDU {
+	oid, err := parseOID(name)
+	if err == nil {
+		i, found := slices.BinarySearchFunc(vars, oid, compareVar)
+		if found {
+			i++
+		}
+
+		if i < len(vars) {
+			return vars[i].SnmpPDU
+		}
+	}
+
+	return gosnmp.SnmpPDU{
+		Name: name,
+		Type: gosnmp.EndOfMibView,
+	}
+}
+
+// snmpVersion returns the version from the header of a message without decoding it.
+func snmpVersion(msg []byte) gosnmp.SnmpVersion {
+	if len(msg) < 2 || msg[0] != byte(gosnmp.Sequence) {
+		return 0xff
+	}
+
+	// Skip the length of the sequence
+	i := 2
+	if msg[1]&0x80 != 0 {
+		i += int(msg[1] & 0x7f)
+	}
+
+	if len(msg) < i+3 || msg[i] != byte(gosnmp.Integer) || msg[i+1] != 1 {
+		return 0xff
+	}
+
+	return gosnmp.SnmpVersion(msg[i+2])
+}
+
+func compareVar(v snmpVar, oid []int) int {
+	return slices.Compare(v.oid, oid)
+}
+
+func parseOID(s string) ([]int, error) {
+	oid := []int{}
+
+	for _, p := range strings.Split(strings.TrimPrefix(s, "."), ".") {
+		i, err := strconv.Atoi(p)
+		if err != nil || i < 0 {
+			return nil, fmt.Errorf("invalid OID: %s", s)
+		}
+
+		oid = append(oid, i)
+	}
+
+	return oid, nil
+}
+
+func mustParseOID(s string) []int {
+	oid, err := parseOID(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return oid
+}
+
+func connectionState(s ConnectionState) int {
+	switch s {
+	case StateConnected:
+		return 1
+	case StateConnecting:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func gauge(v float32) uint32 {
+	return uint32(max(v, 0))
+}
+
+func milli(v float32) uint32 {
+	return gauge(v * 1000)
+}
+
+func wattHours(kWh float32) uint64 {
+	return uint64(max(kWh, 0) * 1000)
+}
@@ -0,0 +1,398 @@
+// SPDX-FileCopyrightText: 2024 Steffen Vogel <post@steffenvogel.de>
+// SPDX-License-Identifier: Apache-2.0
+
+package pductl
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/stv0g/pductl/internal/api"
+)
+
+// Types of watchdog checks
+const (
+	WatchdogPing = "ping"
+	WatchdogTCP  = "tcp"
+	WatchdogHTTP = "http"
+	WatchdogExec = "exec"
+)
+
+const (
+	watchdogDefaultInterval = 30 * time.Second
+	watchdogDefaultTimeout  = 5 * time.Second
+	watchdogDefaultFailures = 3
+
+	// Default time after a reboot during which the device is not checked to give it time to boot
+	watchdogDefaultCooldown = 5 * time.Minute
+
+	// Time window of the maximum number of reboots per day
+	watchdogRebootWindow = 24 * time.Hour
+)
+
+type WatchdogStatus = api.WatchdogStatus
+
+const (
+	WatchdogUnknown  = api.Unknown
+	WatchdogOK       = api.Ok
+	WatchdogFailing  = api.Failing
+	WatchdogCooldown = api.Cooldown
+	WatchdogLimited  = api.Limited
+	WatchdogPaused   = api.Paused
+
+	EventWatchdog = api.EventTypeWatchdog
+)
+
+var (
+	ErrHTTPStatus          = errors.New("unexpected HTTP status")
+	ErrInvalidWatchdogType = errors.New("invalid watchdog type")
+)
+
+type watchdogCheck struct {
+	*WatchdogConfig
+
+	client *http.Client
+
+	mu      sync.Mutex
+	sts     WatchdogStatus
+	reboots []time.Time
+}
+
+// Watchdog periodically checks devices and reboots their outlets
+// after a number of consecutive failed checks.
+// Reboots are limited by a cooldown and a maximum number of reboots per day.
+type Watchdog struct {
+	pdu     PDU
+	name    string
+	aliases map[string]string
+	events  *EventBroker
+
+	checks []*watchdogCheck
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewWatchdog creates a watchdog for the PDU with the checks from the configuration and starts them.
+// Reboots are published to the event broker unless it is nil.
+func NewWatchdog(pc *PDUConfig, p PDU, cfgs []WatchdogConfig, events *EventBroker) *Watchdog {
+	w := &Watchdog{
+		pdu:     p,
+		name:    pc.Name,
+		aliases: pc.Aliases,
+		events:  events,
+	}
+
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+
+	for i := range cfgs {
+		wc := &cfgs[i]
+
+		c := &watchdogCheck{
+			WatchdogConfig: wc,
+			sts: WatchdogStatus{
+				Name:    wc.Name,
+				Type:    wc.Type,
+				Target:  wc.target(),
+				Outlets: wc.Outlets,
+				State:   WatchdogUnknown,
+			},
+			client: http.DefaultClient,
+		}
+
+		if wc.Insecure {
+			c.client = &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						InsecureSkipVerify: true, //nolint:gosec
+					},
+				},
+			}
+		}
+
+		w.checks = append(w.checks, c)
+
+		w.wg.Add(1)
+		go w.run(c)
+	}
+
+	return w
+}
+
+// Close stops all checks.
+func (w *Watchdog) Close() error {
+	w.cancel()
+	w.wg.Wait()
+
+	return nil
+}
+
+// Status returns the current state of all checks.
+func (w *Watchdog) Status() (stss []WatchdogStatus) {
+	for _, c := range w.checks {
+		stss = append(stss, c.status())
+	}
+
+	return stss
+}
+
+func (w *Watchdog) run(c *watchdogCheck) {
+	defer w.wg.Done()
+
+	tmr := time.NewTicker(c.Interval)
+	defer tmr.Stop()
+
+	for {
+		w.evaluate(c)
+
+		select {
+		case <-w.ctx.Done():
+			return
+		case <-tmr.C:
+		}
+	}
+}
+
+// evaluate runs the check and reboots the outlets after the configured number of consecutive failures.
+func (w *Watchdog) evaluate(c *watchdogCheck) {
+	logger := slog.With(slog.String("pdu", w.name), slog.String("watchdog", c.Name))
+
+	now := time.Now()
+
+	c.mu.Lock()
+	lastReboot := c.sts.LastReboot
+	c.mu.Unlock()
+
+	// Give the device time to boot
+	if lastReboot != nil && now.Sub(*lastReboot) < c.Cooldown {
+		return
+	}
+
+	id, err := ExpandAliases(c.Outlets, w.aliases)
+	if err != nil {
+		logger.Error("Invalid outlets of watchdog", slog.Any("error", err))
+		return
+	}
+
+	outlets, err := w.pdu.StatusOutlets(id)
+	if err != nil {
+		if !errors.Is(err, ErrNotPolledYet) {
+			logger.Error("Failed to get status of watchdog outlets", slog.Any("error", err))
+		}
+
+		return
+	}
+
+	// Devices which have been switched off deliberately are not checked
+	if !anyOn(outlets) {
+		c.update(func(sts *WatchdogStatus) {
+			sts.State = WatchdogPaused
+			sts.Failures = 0
+		})
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(w.ctx, c.Timeout)
+	defer cancel()
+
+	checkErr := w.probe(ctx, c)
+	if w.ctx.Err() != nil {
+		return
+	}
+
+	if !c.record(logger, now, checkErr) {
+		return
+	}
+
+	// The lock is not held while rebooting as the status is queried from the poll loop of the PDU
+	logger.Warn("Watchdog check failed. Rebooting outlets", slog.String("outlets", c.Outlets), slog.Any("error", checkErr))
+
+	if _, err := w.pdu.RebootOutlet(id); err != nil {
+		c.update(func(sts *WatchdogStatus) {
+			sts.State = WatchdogFailing
+		})
+
+		logger.Error("Watchdog failed to reboot outlets", slog.Any("error", err))
+
+		return
+	}
+
+	c.mu.Lock()
+	c.reboots = append(c.reboots, now)
+	c.sts.State = WatchdogCooldown
+	c.sts.Failures = 0
+	c.sts.Reboots = len(c.reboots)
+	c.sts.LastReboot = &now
+	sts := c.sts
+	c.mu.Unlock()
+
+	if w.events != nil {
+		w.events.Publish(&Event{
+			Type:      EventWatchdog,
+			Timestamp: now,
+			Watchdog:  &sts,
+		})
+	}
+}
+
+// record updates the state with the result of a check and returns true if the outlets need to be rebooted.
+func (c *watchdogCheck) record(logger *slog.Logger, now time.Time, checkErr error) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sts.LastCheck = &now
+
+	if checkErr == nil {
+		if c.sts.Failures > 0 {
+			logger.Info("Watchdog check succeeded again", slog.Int("failures", c.sts.Failures))
+		}
+
+		c.sts.State = WatchdogOK
+		c.sts.Failures = 0
+		c.sts.LastError = nil
+
+		return false
+	}
+
+	e := checkErr.Error()
+	c.sts.LastError = &e
+	c.sts.Failures++
+
+	if c.sts.Failures < c.Failures {
+		c.sts.State = WatchdogFailing
+
+		logger.Warn("Watchdog check failed", slog.Int("failures", c.sts.Failures), slog.Any("error", checkErr))
+
+		return false
+	}
+
+	c.pruneReboots(now)
+
+	if c.MaxRebootsPerDay > 0 && len(c.reboots) >= c.MaxRebootsPerDay {
+		if c.sts.State != WatchdogLimited {
+			logger.Error("Watchdog reached the maximum number of reboots per day", slog.Int("failures", c.sts.Failures), slog.Int("reboots", len(c.reboots)), slog.Any("error", checkErr))
+		}
+
+		c.sts.State = WatchdogLimited
+
+		return false
+	}
+
+	return true
+}
+
+func (w *Watchdog) probe(ctx context.Context, c *watchdogCheck) error {
+	switch c.Type {
+	case WatchdogPing:
+		return ping(ctx, c.Host)
+
+	case WatchdogTCP:
+		var d net.Dialer
+
+		conn, err := d.DialContext(ctx, "tcp", c.Host)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+
+	case WatchdogHTTP:
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.URL, nil)
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return err
+		}
+
+		resp.Body.Close()
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("%w: %s", ErrHTTPStatus, resp.Status)
+		}
+
+		return nil
+
+	case WatchdogExec:
+		cmd := exec.CommandContext(ctx, c.Command[0], c.Command[1:]...)
+		cmd.Env = append(os.Environ(),
+			"WATCHDOG_NAME="+c.Name,
+			"WATCHDOG_HOST="+c.Host,
+		)
+
+		if out, err := cmd.CombinedOutput(); err != nil {
+			if out := strings.TrimSpace(string(out)); out != "" {
+				return fmt.Errorf("%w: %s", err, out)
+			}
+
+			return err
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrInvalidWatchdogType, c.Type)
+}
+
+func (c *watchdogCheck) status() WatchdogStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pruneReboots(time.Now())
+
+	return c.sts
+}
+
+func (c *watchdogCheck) update(cb func(sts *WatchdogStatus)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cb(&c.sts)
+}
+
+// pruneReboots forgets reboots which are older than a day.
+func (c *watchdogCheck) pruneReboots(now time.Time) {
+	i := 0
+	for i < len(c.reboots) && now.Sub(c.reboots[i]) >= watchdogRebootWindow {
+		i++
+	}
+
+	c.reboots = c.reboots[i:]
+	c.sts.Reboots = len(c.reboots)
+}
+
+// target returns the host, URL or command which is checked.
+func (wc *WatchdogConfig) target() string {
+	switch wc.Type {
+	case WatchdogHTTP:
+		return wc.URL
+	case WatchdogExec:
+		return strings.Join(wc.Command, " ")
+	}
+
+	return wc.Host
+}
+
+func anyOn(outlets []OutletStatus) bool {
+	for _, o := range outlets {
+		if o.State {
+			return true
+		}
+	}
+
+	return false
+}